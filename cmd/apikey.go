@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/psds-microservice/ticket-service/internal/apikey"
+	"github.com/psds-microservice/ticket-service/internal/config"
+	"github.com/psds-microservice/ticket-service/internal/database"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+	"github.com/spf13/cobra"
+)
+
+var apikeyCmd = &cobra.Command{
+	Use:   "apikey",
+	Short: "API keys for service-to-service calls (authorization: ApiKey <key>)",
+}
+
+var apikeyCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an API key; the key is printed once and stored only as a hash",
+	RunE:  runAPIKeyCreate,
+}
+
+var apikeyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API keys with scopes, expiry and last use",
+	RunE:  runAPIKeyList,
+}
+
+var apikeyRevokeCmd = &cobra.Command{
+	Use:   "revoke <id>",
+	Short: "Revoke an API key",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAPIKeyRevoke,
+}
+
+var (
+	apikeyName       string
+	apikeyTenant     string
+	apikeyAnyTenant  bool
+	apikeyScopes     string
+	apikeyExpiresIn  time.Duration
+	apikeyListTenant string
+)
+
+func init() {
+	apikeyCreateCmd.Flags().StringVar(&apikeyName, "name", "", "name of the calling service (required)")
+	apikeyCreateCmd.Flags().StringVar(&apikeyTenant, "tenant", "", "tenant the key is bound to (default: DEFAULT_TENANT_ID)")
+	apikeyCreateCmd.Flags().BoolVar(&apikeyAnyTenant, "any-tenant", false, "do not bind the key to a tenant (tenant comes from x-tenant-id)")
+	apikeyCreateCmd.Flags().StringVar(&apikeyScopes, "scopes", "", "comma-separated scopes: tickets:create, tickets:read, tickets:admin (required)")
+	apikeyCreateCmd.Flags().DurationVar(&apikeyExpiresIn, "expires-in", 90*24*time.Hour, "key lifetime")
+	apikeyListCmd.Flags().StringVar(&apikeyListTenant, "tenant", "", "only keys of this tenant")
+	apikeyCmd.AddCommand(apikeyCreateCmd, apikeyListCmd, apikeyRevokeCmd)
+}
+
+func apikeySetup() (*config.Config, *apikey.Store, error) {
+	_ = godotenv.Load(".env")
+	_ = godotenv.Load("../.env")
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("config: %w", err)
+	}
+	conn, err := database.Open(cfg.DSN())
+	if err != nil {
+		return nil, nil, fmt.Errorf("db: %w", err)
+	}
+	return cfg, apikey.NewStore(conn), nil
+}
+
+func runAPIKeyCreate(cmd *cobra.Command, args []string) error {
+	if apikeyName == "" {
+		return errors.New("--name is required")
+	}
+	scopes, err := apikey.ParseScopes(apikeyScopes)
+	if err != nil {
+		return err
+	}
+	if apikeyExpiresIn <= 0 {
+		return errors.New("--expires-in must be positive")
+	}
+	cfg, store, err := apikeySetup()
+	if err != nil {
+		return err
+	}
+	tenantID := ""
+	if !apikeyAnyTenant {
+		if tenantID = apikeyTenant; tenantID == "" {
+			tenantID = cfg.DefaultTenantID
+		}
+		if !tenant.Valid(tenantID) {
+			return fmt.Errorf("--tenant or --any-tenant is required (got %q)", tenantID)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	raw, k, err := store.Create(ctx, tenantID, apikeyName, scopes, time.Now().Add(apikeyExpiresIn))
+	if err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+	log.Printf("apikey: created key %d %q (tenant %q, scopes %s, expires %s)",
+		k.ID, k.Name, k.TenantID, strings.Join(k.Scopes, ","), k.ExpiresAt.Format(time.RFC3339))
+	// Сам ключ — в stdout, чтобы его можно было перенаправить в секрет без логов.
+	fmt.Println(raw)
+	return nil
+}
+
+func runAPIKeyList(cmd *cobra.Command, args []string) error {
+	_, store, err := apikeySetup()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	keys, err := store.List(ctx, apikeyListTenant)
+	if err != nil {
+		return fmt.Errorf("list: %w", err)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tTENANT\tPREFIX\tSCOPES\tEXPIRES\tLAST USED\tSTATE")
+	now := time.Now()
+	for _, k := range keys {
+		lastUsed := "-"
+		if k.LastUsedAt != nil {
+			lastUsed = k.LastUsedAt.Format(time.RFC3339)
+		}
+		state := "active"
+		switch {
+		case k.RevokedAt != nil:
+			state = "revoked"
+		case !k.ExpiresAt.After(now):
+			state = "expired"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s…\t%s\t%s\t%s\t%s\n", k.ID, k.Name, k.TenantID, k.Prefix,
+			strings.Join(k.Scopes, ","), k.ExpiresAt.Format(time.RFC3339), lastUsed, state)
+	}
+	return w.Flush()
+}
+
+func runAPIKeyRevoke(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid key id %q", args[0])
+	}
+	_, store, err := apikeySetup()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	k, err := store.Revoke(ctx, id)
+	if err != nil {
+		return fmt.Errorf("revoke: %w", err)
+	}
+	log.Printf("apikey: key %d %q revoked at %s", k.ID, k.Name, k.RevokedAt.Format(time.RFC3339))
+	return nil
+}
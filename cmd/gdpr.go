@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/psds-microservice/ticket-service/internal/changefeed"
+	"github.com/psds-microservice/ticket-service/internal/config"
+	"github.com/psds-microservice/ticket-service/internal/database"
+	"github.com/psds-microservice/ticket-service/internal/encryption"
+	"github.com/psds-microservice/ticket-service/internal/kafka"
+	"github.com/psds-microservice/ticket-service/internal/service"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+	"github.com/spf13/cobra"
+)
+
+var gdprCmd = &cobra.Command{
+	Use:   "gdpr",
+	Short: "GDPR requests by client_id: export data, erase personal text",
+}
+
+var gdprExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export all tickets, links and audit history of a client as JSON",
+	RunE:  runGDPRExport,
+}
+
+var gdprEraseCmd = &cobra.Command{
+	Use:   "erase",
+	Short: "Irreversibly anonymise subject and notes of all tickets of a client",
+	RunE:  runGDPRErase,
+}
+
+var (
+	gdprClientID string
+	gdprTenant   string
+	gdprActor    string
+	gdprOut      string
+	gdprConfirm  bool
+)
+
+func init() {
+	gdprCmd.PersistentFlags().StringVar(&gdprClientID, "client-id", "", "client_id the request is about (required)")
+	gdprCmd.PersistentFlags().StringVar(&gdprTenant, "tenant", "", "tenant of the client (default: DEFAULT_TENANT_ID)")
+	gdprCmd.PersistentFlags().StringVar(&gdprActor, "actor", "cli", "who performs the request (written to the audit log)")
+	gdprExportCmd.Flags().StringVarP(&gdprOut, "out", "o", "", "output file (default: stdout)")
+	gdprEraseCmd.Flags().BoolVar(&gdprConfirm, "yes", false, "confirm irreversible erasure")
+	gdprCmd.AddCommand(gdprExportCmd, gdprEraseCmd)
+}
+
+func gdprSetup() (*config.Config, *service.TicketService, *changefeed.Notifier, error) {
+	_ = godotenv.Load(".env")
+	_ = godotenv.Load("../.env")
+	if gdprClientID == "" {
+		return nil, nil, nil, errors.New("--client-id is required")
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, nil, fmt.Errorf("config: %w", err)
+	}
+	if gdprTenant == "" {
+		gdprTenant = cfg.DefaultTenantID
+	}
+	if !tenant.Valid(gdprTenant) {
+		return nil, nil, nil, fmt.Errorf("--tenant is required (got %q)", gdprTenant)
+	}
+	conn, err := database.Open(cfg.DSN())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("db: %w", err)
+	}
+	if _, err := encryption.Register(conn, cfg.EncryptionKeyfile); err != nil {
+		return nil, nil, nil, fmt.Errorf("encryption: %w", err)
+	}
+	return cfg, service.NewTicketService(conn, service.Options{}), cliNotifier(cfg, conn), nil
+}
+
+func runGDPRExport(cmd *cobra.Command, args []string) error {
+	_, svc, _, err := gdprSetup()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(tenant.WithID(context.Background(), gdprTenant), 5*time.Minute)
+	defer cancel()
+	export, err := svc.ExportClientData(ctx, gdprClientID, gdprActor)
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	out := os.Stdout
+	if gdprOut != "" {
+		f, err := os.Create(gdprOut)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", gdprOut, err)
+		}
+		defer f.Close()
+		out = f
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(export); err != nil {
+		return fmt.Errorf("write export: %w", err)
+	}
+	log.Printf("gdpr export: %d tickets for client %s", len(export.Tickets), gdprClientID)
+	return nil
+}
+
+func runGDPRErase(cmd *cobra.Command, args []string) error {
+	if !gdprConfirm {
+		return errors.New("erasure is irreversible: pass --yes to confirm")
+	}
+	cfg, svc, changes, err := gdprSetup()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(tenant.WithID(context.Background(), gdprTenant), 5*time.Minute)
+	defer cancel()
+	erased, err := svc.EraseClientData(ctx, gdprClientID, gdprActor)
+	if err != nil {
+		return fmt.Errorf("erase: %w", err)
+	}
+	// ticket.erased — search-service удаляет документы обезличенных тикетов.
+	redactor, err := cfg.KafkaRedactor()
+	if err != nil {
+		return fmt.Errorf("redact: %w", err)
+	}
+	producer := kafka.NewProducer(cfg.KafkaBrokers, cfg.KafkaTopicTicket, redactor)
+	defer producer.Close()
+	for i := range erased {
+		changes.Notify(ctx, "ticket.erased", &erased[i])
+		producer.ProduceTicketEvent(ctx, "ticket.erased", kafka.TicketEventPayload(&erased[i]))
+	}
+	log.Printf("gdpr erase: anonymised %d tickets for client %s", len(erased), gdprClientID)
+	return nil
+}
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/psds-microservice/ticket-service/internal/config"
+	"github.com/psds-microservice/ticket-service/internal/database"
+	"github.com/psds-microservice/ticket-service/internal/encryption"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Master keys for encryption of ticket subject and notes",
+}
+
+var keysGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Create a new keyfile with one primary master key",
+	RunE:  runKeysGenerate,
+}
+
+var keysAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a new master key to the keyfile and make it primary",
+	RunE:  runKeysAdd,
+}
+
+var keysRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Re-encrypt stored subject/notes with the primary master key (also encrypts plaintext rows)",
+	RunE:  runKeysRotate,
+}
+
+var (
+	keysKeyfile   string
+	keysKeyID     string
+	keysBatchSize int
+	keysDryRun    bool
+)
+
+func init() {
+	keysCmd.PersistentFlags().StringVar(&keysKeyfile, "keyfile", "", "path to the keyfile (default: ENCRYPTION_KEYFILE)")
+	keysGenerateCmd.Flags().StringVar(&keysKeyID, "key-id", "", "id of the new master key (default: k<unix time>)")
+	keysAddCmd.Flags().StringVar(&keysKeyID, "key-id", "", "id of the new master key (default: k<unix time>)")
+	keysRotateCmd.Flags().IntVar(&keysBatchSize, "batch-size", 500, "tickets per transaction")
+	keysRotateCmd.Flags().BoolVar(&keysDryRun, "dry-run", false, "only count tickets that need re-encryption")
+	keysCmd.AddCommand(keysGenerateCmd, keysAddCmd, keysRotateCmd)
+}
+
+func keysPath() (string, error) {
+	_ = godotenv.Load(".env")
+	_ = godotenv.Load("../.env")
+	if keysKeyfile != "" {
+		return keysKeyfile, nil
+	}
+	if p := os.Getenv("ENCRYPTION_KEYFILE"); p != "" {
+		return p, nil
+	}
+	return "", errors.New("--keyfile or ENCRYPTION_KEYFILE is required")
+}
+
+func newKeyID() string {
+	if keysKeyID != "" {
+		return keysKeyID
+	}
+	return fmt.Sprintf("k%d", time.Now().Unix())
+}
+
+func runKeysGenerate(cmd *cobra.Command, args []string) error {
+	path, err := keysPath()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists (use `keys add` to add a key)", path)
+	}
+	kf := &encryption.Keyfile{}
+	if err := kf.AddKey(newKeyID()); err != nil {
+		return err
+	}
+	if err := encryption.WriteKeyfile(path, kf); err != nil {
+		return err
+	}
+	log.Printf("keys: created %s with primary key %q", path, kf.Primary)
+	return nil
+}
+
+func runKeysAdd(cmd *cobra.Command, args []string) error {
+	path, err := keysPath()
+	if err != nil {
+		return err
+	}
+	kf, err := encryption.ReadKeyfile(path)
+	if err != nil {
+		return err
+	}
+	if err := kf.AddKey(newKeyID()); err != nil {
+		return err
+	}
+	if err := encryption.WriteKeyfile(path, kf); err != nil {
+		return err
+	}
+	log.Printf("keys: primary key is now %q; restart the service, then run `keys rotate`", kf.Primary)
+	return nil
+}
+
+// rotateRow — зашифрованные поля тикета как они лежат в БД.
+type rotateRow struct {
+	ID      uint64
+	Subject string
+	Notes   string
+}
+
+func runKeysRotate(cmd *cobra.Command, args []string) error {
+	path, err := keysPath()
+	if err != nil {
+		return err
+	}
+	kf, err := encryption.ReadKeyfile(path)
+	if err != nil {
+		return err
+	}
+	keyring, err := encryption.NewLocalKeyring(kf)
+	if err != nil {
+		return err
+	}
+	c := encryption.NewCipher(keyring)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	// Плагин шифрования не подключаем: читаем и пишем значения как они лежат в БД.
+	conn, err := database.Open(cfg.DSN())
+	if err != nil {
+		return fmt.Errorf("db: %w", err)
+	}
+	if keysBatchSize <= 0 {
+		keysBatchSize = 500
+	}
+
+	// Перешифровываются строки всех тенантов.
+	ctx, cancel := context.WithTimeout(tenant.WithSystem(context.Background()), 6*time.Hour)
+	defer cancel()
+	var lastID uint64
+	scanned, rotated := 0, 0
+	for {
+		var batch []rotateRow
+		err := conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			// FOR UPDATE: параллельный UpdateTicket не перезапишется старым значением.
+			if err := tx.Table("tickets").Select("id, subject, notes").
+				Where("id > ?", lastID).Order("id").Limit(keysBatchSize).
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Scan(&batch).Error; err != nil {
+				return err
+			}
+			for _, row := range batch {
+				if !c.NeedsRotation(row.Subject) && !c.NeedsRotation(row.Notes) {
+					continue
+				}
+				rotated++
+				if keysDryRun {
+					continue
+				}
+				subject, err := reencrypt(ctx, c, row.Subject)
+				if err != nil {
+					return fmt.Errorf("ticket %d: %w", row.ID, err)
+				}
+				notes, err := reencrypt(ctx, c, row.Notes)
+				if err != nil {
+					return fmt.Errorf("ticket %d: %w", row.ID, err)
+				}
+				// UpdateColumns: перешифрование не меняет тикет, updated_at не трогаем.
+				if err := tx.Table("tickets").Where("id = ?", row.ID).
+					UpdateColumns(map[string]interface{}{"subject": subject, "notes": notes}).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("rotate: %w", err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+		scanned += len(batch)
+		lastID = batch[len(batch)-1].ID
+		log.Printf("keys rotate: scanned %d, re-encrypted %d", scanned, rotated)
+	}
+	if keysDryRun {
+		log.Printf("keys rotate: dry run, %d of %d tickets need re-encryption with %q", rotated, scanned, keyring.PrimaryKeyID())
+		return nil
+	}
+	log.Printf("keys rotate: done, %d of %d tickets re-encrypted with %q; old keys can be removed from the keyfile", rotated, scanned, keyring.PrimaryKeyID())
+	return nil
+}
+
+func reencrypt(ctx context.Context, c *encryption.Cipher, value string) (string, error) {
+	plaintext, err := c.Decrypt(ctx, value)
+	if err != nil {
+		return "", err
+	}
+	return c.Encrypt(ctx, plaintext)
+}
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/psds-microservice/ticket-service/internal/config"
@@ -21,8 +23,14 @@ var migrateUpCmd = &cobra.Command{
 	RunE:  runMigrateUp,
 }
 
+var migrateVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check that row level security policies are active and isolate tenants",
+	RunE:  runMigrateVerify,
+}
+
 func init() {
-	migrateCmd.AddCommand(migrateUpCmd)
+	migrateCmd.AddCommand(migrateUpCmd, migrateVerifyCmd)
 }
 
 func runMigrateUp(cmd *cobra.Command, args []string) error {
@@ -41,3 +49,22 @@ func runMigrateUp(cmd *cobra.Command, args []string) error {
 	log.Println("migrate up: ok")
 	return nil
 }
+
+func runMigrateVerify(cmd *cobra.Command, args []string) error {
+	_ = godotenv.Load(".env")
+	_ = godotenv.Load("../.env")
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := database.VerifyRLS(ctx, cfg.DatabaseURL()); err != nil {
+		return fmt.Errorf("verify: %w", err)
+	}
+	log.Println("migrate verify: ok")
+	return nil
+}
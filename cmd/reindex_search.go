@@ -9,9 +9,11 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/psds-microservice/ticket-service/internal/config"
 	"github.com/psds-microservice/ticket-service/internal/database"
+	"github.com/psds-microservice/ticket-service/internal/encryption"
 	"github.com/psds-microservice/ticket-service/internal/kafka"
 	"github.com/psds-microservice/ticket-service/internal/model"
 	"github.com/psds-microservice/ticket-service/internal/searchindex"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
 	"github.com/spf13/cobra"
 )
 
@@ -40,31 +42,31 @@ func runReindexSearch(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("db: %w", err)
 	}
+	if _, err := encryption.Register(conn, cfg.EncryptionKeyfile); err != nil {
+		return fmt.Errorf("encryption: %w", err)
+	}
+
+	// Переиндексируются тикеты всех тенантов.
+	ctx, cancel := context.WithTimeout(tenant.WithSystem(context.Background()), 10*time.Minute)
+	defer cancel()
 
 	var tickets []model.Ticket
-	if err := conn.Find(&tickets).Error; err != nil {
+	if err := conn.WithContext(ctx).Preload("Sessions").Find(&tickets).Error; err != nil {
 		return fmt.Errorf("list tickets: %w", err)
 	}
 	log.Printf("reindex-search: found %d tickets", len(tickets))
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
-	defer cancel()
-
 	// Prefer Kafka, then HTTP
 	if len(cfg.KafkaBrokers) > 0 && cfg.KafkaTopicTicket != "" {
 		log.Println("reindex-search: using Kafka for reindexing")
-		producer := kafka.NewProducer(cfg.KafkaBrokers, cfg.KafkaTopicTicket)
+		redactor, err := cfg.KafkaRedactor()
+		if err != nil {
+			return fmt.Errorf("redact: %w", err)
+		}
+		producer := kafka.NewProducer(cfg.KafkaBrokers, cfg.KafkaTopicTicket, redactor)
 		for i := range tickets {
 			t := &tickets[i]
-			payload := map[string]interface{}{
-				"ticket_id":   int64(t.ID),
-				"session_id":  t.SessionID,
-				"client_id":   t.ClientID,
-				"operator_id": t.OperatorID,
-				"subject":     t.Subject,
-				"notes":       t.Notes,
-				"status":      string(t.Status),
-			}
+			payload := kafka.TicketEventPayload(t)
 			producer.ProduceTicketEvent(ctx, "ticket.updated", payload)
 			if (i+1)%50 == 0 || i == len(tickets)-1 {
 				log.Printf("reindex-search: sent %d/%d events to Kafka", i+1, len(tickets))
@@ -75,7 +77,11 @@ func runReindexSearch(cmd *cobra.Command, args []string) error {
 	}
 	if cfg.SearchServiceURL != "" {
 		log.Println("reindex-search: using HTTP for reindexing")
-		client := searchindex.NewClient(cfg.SearchServiceURL)
+		redactor, err := cfg.SearchRedactor()
+		if err != nil {
+			return fmt.Errorf("redact: %w", err)
+		}
+		client := searchindex.NewClient(cfg.SearchServiceURL, redactor)
 		for i := range tickets {
 			client.IndexTicket(ctx, &tickets[i])
 			if (i+1)%50 == 0 || i == len(tickets)-1 {
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/psds-microservice/ticket-service/internal/changefeed"
+	"github.com/psds-microservice/ticket-service/internal/config"
+	"github.com/psds-microservice/ticket-service/internal/database"
+	"github.com/psds-microservice/ticket-service/internal/encryption"
+	"github.com/psds-microservice/ticket-service/internal/kafka"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/internal/service"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+	"github.com/spf13/cobra"
+)
+
+var retentionCmd = &cobra.Command{
+	Use:   "retention",
+	Short: "Data retention policies: purge or anonymise old tickets by region and status",
+}
+
+var retentionRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Apply retention policies in batches (tickets on legal hold are skipped)",
+	RunE:  runRetentionRun,
+}
+
+var retentionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List retention policies",
+	RunE:  runRetentionList,
+}
+
+var retentionSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Create or replace the retention policy for a region and status",
+	RunE:  runRetentionSet,
+}
+
+var retentionDeleteCmd = &cobra.Command{
+	Use:   "delete <policy-id>",
+	Short: "Delete a retention policy",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runRetentionDelete,
+}
+
+var (
+	retentionDryRun    bool
+	retentionBatchSize int
+	retentionActor     string
+	retentionRegion    string
+	retentionStatus    string
+	retentionDays      int
+	retentionAction    string
+)
+
+func init() {
+	retentionRunCmd.Flags().BoolVar(&retentionDryRun, "dry-run", false, "only report how many tickets would be processed")
+	retentionRunCmd.Flags().IntVar(&retentionBatchSize, "batch-size", service.DefaultRetentionBatchSize, "tickets per transaction")
+	retentionRunCmd.Flags().StringVar(&retentionActor, "actor", "retention-job", "who runs the job (written to the audit log)")
+	retentionSetCmd.Flags().StringVar(&retentionRegion, "region", "", "region (empty: all regions without their own policy)")
+	retentionSetCmd.Flags().StringVar(&retentionStatus, "status", string(model.TicketStatusClosed), "ticket status the policy applies to")
+	retentionSetCmd.Flags().IntVar(&retentionDays, "days", 0, "retention period in days (required)")
+	retentionSetCmd.Flags().StringVar(&retentionAction, "action", string(model.RetentionPurge), "purge or anonymise")
+	retentionCmd.AddCommand(retentionRunCmd, retentionListCmd, retentionSetCmd, retentionDeleteCmd)
+}
+
+func retentionSetup() (*config.Config, *service.TicketService, *changefeed.Notifier, error) {
+	_ = godotenv.Load(".env")
+	_ = godotenv.Load("../.env")
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, nil, fmt.Errorf("config: %w", err)
+	}
+	conn, err := database.Open(cfg.DSN())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("db: %w", err)
+	}
+	if _, err := encryption.Register(conn, cfg.EncryptionKeyfile); err != nil {
+		return nil, nil, nil, fmt.Errorf("encryption: %w", err)
+	}
+	return cfg, service.NewTicketService(conn, service.Options{}), cliNotifier(cfg, conn), nil
+}
+
+func runRetentionRun(cmd *cobra.Command, args []string) error {
+	cfg, svc, changes, err := retentionSetup()
+	if err != nil {
+		return err
+	}
+	// Политики общие для всех тенантов: задача работает без ограничения тенантом.
+	ctx, cancel := context.WithTimeout(tenant.WithSystem(context.Background()), time.Hour)
+	defer cancel()
+	redactor, err := cfg.KafkaRedactor()
+	if err != nil {
+		return fmt.Errorf("redact: %w", err)
+	}
+	producer := kafka.NewProducer(cfg.KafkaBrokers, cfg.KafkaTopicTicket, redactor)
+	defer producer.Close()
+	report, err := svc.RunRetention(ctx, service.RetentionRunOptions{
+		DryRun:    retentionDryRun,
+		BatchSize: retentionBatchSize,
+		Actor:     retentionActor,
+		OnBatch: func(action model.RetentionAction, tickets []model.Ticket) {
+			// Удалённые и обезличенные тикеты убираются из search-service.
+			event := "ticket.deleted"
+			if action == model.RetentionAnonymise {
+				event = "ticket.erased"
+			}
+			for i := range tickets {
+				changes.Notify(ctx, event, &tickets[i])
+				producer.ProduceTicketEvent(ctx, event, kafka.TicketEventPayload(&tickets[i]))
+			}
+			log.Printf("retention: %s %d tickets", action, len(tickets))
+		},
+	})
+	if report != nil {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(report)
+	}
+	if err != nil {
+		return fmt.Errorf("retention: %w", err)
+	}
+	return nil
+}
+
+func runRetentionList(cmd *cobra.Command, args []string) error {
+	_, svc, _, err := retentionSetup()
+	if err != nil {
+		return err
+	}
+	policies, err := svc.ListRetentionPolicies(context.Background())
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(policies)
+}
+
+func runRetentionSet(cmd *cobra.Command, args []string) error {
+	_, svc, _, err := retentionSetup()
+	if err != nil {
+		return err
+	}
+	p := &model.RetentionPolicy{
+		Region:     retentionRegion,
+		Status:     model.TicketStatus(retentionStatus),
+		RetainDays: retentionDays,
+		Action:     model.RetentionAction(retentionAction),
+	}
+	if err := svc.SetRetentionPolicy(context.Background(), p); err != nil {
+		return fmt.Errorf("retention set: %w", err)
+	}
+	log.Printf("retention: region=%q status=%s keep %d days, then %s", p.Region, p.Status, p.RetainDays, p.Action)
+	return nil
+}
+
+func runRetentionDelete(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid policy id %q", args[0])
+	}
+	_, svc, _, err := retentionSetup()
+	if err != nil {
+		return err
+	}
+	return svc.DeleteRetentionPolicy(context.Background(), id)
+}
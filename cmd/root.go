@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"github.com/psds-microservice/ticket-service/internal/changefeed"
+	"github.com/psds-microservice/ticket-service/internal/config"
 	"github.com/spf13/cobra"
+	"gorm.io/gorm"
 )
 
 var rootCmd = &cobra.Command{
@@ -18,4 +21,17 @@ func init() {
 	rootCmd.AddCommand(apiCmd)
 	rootCmd.AddCommand(migrateCmd)
 	rootCmd.AddCommand(reindexSearchCmd)
+	rootCmd.AddCommand(gdprCmd)
+	rootCmd.AddCommand(retentionCmd)
+	rootCmd.AddCommand(keysCmd)
+	rootCmd.AddCommand(apikeyCmd)
+}
+
+// cliNotifier — уведомления о тикетах, изменённых командой CLI, для WatchTickets на репликах API
+// (nil при CHANGEFEED_ENABLED=false; методы Notifier безопасны для nil).
+func cliNotifier(cfg *config.Config, conn *gorm.DB) *changefeed.Notifier {
+	if !cfg.ChangeFeedEnabled {
+		return nil
+	}
+	return changefeed.NewNotifier(conn, changefeed.NewReplicaID())
 }
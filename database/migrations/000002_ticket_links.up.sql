@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS ticket_links (
+    id               BIGSERIAL    PRIMARY KEY,
+    ticket_id        BIGINT       NOT NULL REFERENCES tickets (id) ON DELETE CASCADE,
+    linked_ticket_id BIGINT       NOT NULL REFERENCES tickets (id) ON DELETE CASCADE,
+    link_type        VARCHAR(32)  NOT NULL,
+    created_at       TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_ticket_links_not_self CHECK (ticket_id <> linked_ticket_id),
+    CONSTRAINT uq_ticket_links UNIQUE (ticket_id, linked_ticket_id, link_type)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_links_linked_ticket_id ON ticket_links (linked_ticket_id);
+-- У тикета не больше одного родителя.
+CREATE UNIQUE INDEX IF NOT EXISTS uq_ticket_links_single_parent ON ticket_links (linked_ticket_id) WHERE link_type = 'parent';
@@ -0,0 +1,3 @@
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS merged_into_id BIGINT REFERENCES tickets (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_merged_into_id ON tickets (merged_into_id) WHERE merged_into_id IS NOT NULL;
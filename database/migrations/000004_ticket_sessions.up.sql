@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS ticket_sessions (
+    ticket_id   BIGINT       NOT NULL REFERENCES tickets (id) ON DELETE CASCADE,
+    session_id  VARCHAR(64)  NOT NULL,
+    attached_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (ticket_id, session_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_sessions_session_id ON ticket_sessions (session_id);
+
+-- Исходная сессия каждого тикета становится его первой привязанной сессией.
+INSERT INTO ticket_sessions (ticket_id, session_id, attached_at)
+SELECT id, session_id, created_at FROM tickets
+ON CONFLICT DO NOTHING;
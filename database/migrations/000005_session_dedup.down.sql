@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS ux_tickets_open_session;
+ALTER TABLE tickets DROP COLUMN IF EXISTS allow_duplicate_session;
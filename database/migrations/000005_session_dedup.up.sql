@@ -0,0 +1,16 @@
+-- allow_duplicate_session = TRUE: тикет создан при политике allow и не участвует в дедупликации.
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS allow_duplicate_session BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Уже существующие дубли: в дедупликации остаётся только самый новый незакрытый тикет сессии.
+UPDATE tickets t SET allow_duplicate_session = TRUE
+WHERE t.status NOT IN ('closed', 'merged')
+  AND EXISTS (
+      SELECT 1 FROM tickets n
+      WHERE n.session_id = t.session_id
+        AND n.status NOT IN ('closed', 'merged')
+        AND (n.created_at, n.id) > (t.created_at, t.id)
+  );
+
+-- Не больше одного незакрытого тикета на сессию.
+CREATE UNIQUE INDEX IF NOT EXISTS ux_tickets_open_session ON tickets (session_id)
+    WHERE status NOT IN ('closed', 'merged') AND NOT allow_duplicate_session;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS ux_tickets_reference;
+ALTER TABLE tickets DROP COLUMN IF EXISTS reference;
+DROP TABLE IF EXISTS ticket_reference_counters;
@@ -0,0 +1,31 @@
+-- Счётчики номеров тикетов: без пропусков, отдельно для каждого префикса и года.
+CREATE TABLE IF NOT EXISTS ticket_reference_counters (
+    prefix     VARCHAR(16) NOT NULL,
+    year       INT         NOT NULL,
+    last_value BIGINT      NOT NULL,
+    PRIMARY KEY (prefix, year)
+);
+
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS reference VARCHAR(32);
+
+-- Существующим тикетам выдаём номера с префиксом по умолчанию (TK) в порядке создания.
+WITH numbered AS (
+    SELECT id,
+           EXTRACT(YEAR FROM created_at AT TIME ZONE 'UTC')::INT AS year,
+           ROW_NUMBER() OVER (PARTITION BY EXTRACT(YEAR FROM created_at AT TIME ZONE 'UTC') ORDER BY created_at, id) AS n
+    FROM tickets
+    WHERE reference IS NULL
+)
+UPDATE tickets t
+SET reference = 'TK-' || numbered.year || '-' || LPAD(numbered.n::TEXT, 6, '0')
+FROM numbered
+WHERE t.id = numbered.id;
+
+INSERT INTO ticket_reference_counters (prefix, year, last_value)
+SELECT 'TK', EXTRACT(YEAR FROM created_at AT TIME ZONE 'UTC')::INT, COUNT(*)
+FROM tickets
+GROUP BY 2
+ON CONFLICT (prefix, year) DO NOTHING;
+
+ALTER TABLE tickets ALTER COLUMN reference SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_tickets_reference ON tickets (reference);
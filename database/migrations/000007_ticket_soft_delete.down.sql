@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS ux_tickets_open_session;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_tickets_open_session ON tickets (session_id)
+    WHERE status NOT IN ('closed', 'merged') AND NOT allow_duplicate_session;
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS archived_at;
+ALTER TABLE tickets DROP COLUMN IF EXISTS deleted_at;
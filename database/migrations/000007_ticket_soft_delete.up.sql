@@ -0,0 +1,10 @@
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_deleted_at ON tickets (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_tickets_archived_at ON tickets (archived_at);
+
+-- Удалённые тикеты не мешают создать новый тикет для той же сессии.
+DROP INDEX IF EXISTS ux_tickets_open_session;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_tickets_open_session ON tickets (session_id)
+    WHERE status NOT IN ('closed', 'merged') AND NOT allow_duplicate_session AND deleted_at IS NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE tickets DROP COLUMN IF EXISTS erased_at;
+DROP TABLE IF EXISTS ticket_audit_log;
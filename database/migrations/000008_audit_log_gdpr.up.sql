@@ -0,0 +1,16 @@
+-- Журнал аудита привилегированных операций. Без FK на tickets: записи переживают удаление тикета.
+CREATE TABLE IF NOT EXISTS ticket_audit_log (
+    id         BIGSERIAL    PRIMARY KEY,
+    ticket_id  BIGINT,
+    client_id  VARCHAR(64),
+    actor      VARCHAR(128) NOT NULL,
+    action     VARCHAR(64)  NOT NULL,
+    details    JSONB,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_audit_log_ticket_id ON ticket_audit_log (ticket_id);
+CREATE INDEX IF NOT EXISTS idx_ticket_audit_log_client_id ON ticket_audit_log (client_id);
+
+-- erased_at — персональный текст тикета обезличен (GDPR, право на удаление).
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS erased_at TIMESTAMPTZ;
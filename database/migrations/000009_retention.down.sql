@@ -0,0 +1,2 @@
+ALTER TABLE tickets DROP COLUMN IF EXISTS legal_hold;
+DROP TABLE IF EXISTS retention_policies;
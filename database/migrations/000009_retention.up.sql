@@ -0,0 +1,16 @@
+-- Политики хранения: тикеты в статусе status старше retain_days (по closed_at, иначе updated_at)
+-- удаляются (purge) или обезличиваются (anonymise). region = '' — для регионов без своей политики.
+CREATE TABLE IF NOT EXISTS retention_policies (
+    id          BIGSERIAL    PRIMARY KEY,
+    region      VARCHAR(64)  NOT NULL DEFAULT '',
+    status      VARCHAR(32)  NOT NULL,
+    retain_days INT          NOT NULL CHECK (retain_days > 0),
+    action      VARCHAR(16)  NOT NULL CHECK (action IN ('purge', 'anonymise')),
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_retention_policies UNIQUE (region, status)
+);
+
+-- legal_hold = TRUE — тикет не удаляется и не обезличивается политиками хранения.
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS legal_hold BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX IF NOT EXISTS idx_tickets_legal_hold ON tickets (legal_hold) WHERE legal_hold;
@@ -0,0 +1,4 @@
+ALTER TABLE tickets DROP COLUMN IF EXISTS legal_hold_at;
+ALTER TABLE tickets DROP COLUMN IF EXISTS legal_hold_by;
+ALTER TABLE tickets DROP COLUMN IF EXISTS legal_hold_case_id;
+ALTER TABLE tickets DROP COLUMN IF EXISTS legal_hold_reason;
@@ -0,0 +1,4 @@
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS legal_hold_reason  TEXT;
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS legal_hold_case_id VARCHAR(128);
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS legal_hold_by      VARCHAR(128);
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS legal_hold_at      TIMESTAMPTZ;
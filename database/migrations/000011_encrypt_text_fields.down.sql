@@ -0,0 +1,2 @@
+-- Перед откатом расшифруйте данные: шифротекст subject не помещается в VARCHAR(255).
+ALTER TABLE tickets ALTER COLUMN subject TYPE VARCHAR(255);
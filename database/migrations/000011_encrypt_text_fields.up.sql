@@ -0,0 +1,2 @@
+-- Зашифрованный subject длиннее исходного (envelope: id ключа, обёрнутый data key, nonce, base64).
+ALTER TABLE tickets ALTER COLUMN subject TYPE TEXT;
@@ -0,0 +1,22 @@
+DROP INDEX IF EXISTS ux_tickets_open_session;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_tickets_open_session ON tickets (session_id)
+    WHERE status NOT IN ('closed', 'merged') AND NOT allow_duplicate_session AND deleted_at IS NULL;
+
+ALTER TABLE ticket_reference_counters DROP CONSTRAINT IF EXISTS ticket_reference_counters_pkey;
+ALTER TABLE ticket_reference_counters ADD PRIMARY KEY (prefix, year);
+DROP INDEX IF EXISTS ux_tickets_tenant_reference;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_tickets_reference ON tickets (reference);
+
+ALTER TABLE ticket_links DROP CONSTRAINT IF EXISTS fk_ticket_links_tenant_linked_ticket;
+ALTER TABLE ticket_links DROP CONSTRAINT IF EXISTS fk_ticket_links_tenant_ticket;
+ALTER TABLE ticket_sessions DROP CONSTRAINT IF EXISTS fk_ticket_sessions_tenant_ticket;
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS uq_tickets_tenant_id;
+
+DROP INDEX IF EXISTS idx_ticket_audit_log_tenant_id;
+DROP INDEX IF EXISTS idx_tickets_tenant_created_at;
+
+ALTER TABLE ticket_reference_counters DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE ticket_audit_log   DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE ticket_links       DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE ticket_sessions    DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE tickets            DROP COLUMN IF EXISTS tenant_id;
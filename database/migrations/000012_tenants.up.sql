@@ -0,0 +1,37 @@
+-- Мультитенантность: у тикетов и дочерних таблиц появляется tenant_id.
+-- Существующие данные принадлежат тенанту default.
+ALTER TABLE tickets            ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE ticket_sessions    ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE ticket_links       ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE ticket_audit_log   ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE ticket_reference_counters ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+
+-- Дальше tenant_id всегда задаёт приложение.
+ALTER TABLE tickets            ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE ticket_sessions    ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE ticket_links       ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE ticket_audit_log   ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE ticket_reference_counters ALTER COLUMN tenant_id DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_tenant_created_at ON tickets (tenant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_ticket_audit_log_tenant_id ON ticket_audit_log (tenant_id);
+
+-- Связи и сессии не могут ссылаться на тикет другого тенанта (составные FK).
+ALTER TABLE tickets ADD CONSTRAINT uq_tickets_tenant_id UNIQUE (tenant_id, id);
+ALTER TABLE ticket_sessions ADD CONSTRAINT fk_ticket_sessions_tenant_ticket
+    FOREIGN KEY (tenant_id, ticket_id) REFERENCES tickets (tenant_id, id) ON DELETE CASCADE;
+ALTER TABLE ticket_links ADD CONSTRAINT fk_ticket_links_tenant_ticket
+    FOREIGN KEY (tenant_id, ticket_id) REFERENCES tickets (tenant_id, id) ON DELETE CASCADE;
+ALTER TABLE ticket_links ADD CONSTRAINT fk_ticket_links_tenant_linked_ticket
+    FOREIGN KEY (tenant_id, linked_ticket_id) REFERENCES tickets (tenant_id, id) ON DELETE CASCADE;
+
+-- Номера тикетов и счётчики — свои у каждого тенанта.
+DROP INDEX IF EXISTS ux_tickets_reference;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_tickets_tenant_reference ON tickets (tenant_id, reference);
+ALTER TABLE ticket_reference_counters DROP CONSTRAINT IF EXISTS ticket_reference_counters_pkey;
+ALTER TABLE ticket_reference_counters ADD PRIMARY KEY (tenant_id, prefix, year);
+
+-- Одна сессия может быть у разных тенантов.
+DROP INDEX IF EXISTS ux_tickets_open_session;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_tickets_open_session ON tickets (tenant_id, session_id)
+    WHERE status NOT IN ('closed', 'merged') AND NOT allow_duplicate_session AND deleted_at IS NULL;
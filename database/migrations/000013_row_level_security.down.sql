@@ -0,0 +1,19 @@
+DROP POLICY IF EXISTS tenant_isolation ON ticket_reference_counters;
+ALTER TABLE ticket_reference_counters NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE ticket_reference_counters DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON ticket_audit_log;
+ALTER TABLE ticket_audit_log NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE ticket_audit_log DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON ticket_links;
+ALTER TABLE ticket_links NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE ticket_links DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON ticket_sessions;
+ALTER TABLE ticket_sessions NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE ticket_sessions DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON tickets;
+ALTER TABLE tickets NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE tickets DISABLE ROW LEVEL SECURITY;
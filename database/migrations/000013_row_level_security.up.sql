@@ -0,0 +1,37 @@
+-- Row-level security: вторая линия изоляции тенантов поверх фильтров в приложении.
+-- Сервис в каждой транзакции выполняет set_config('app.tenant_id', <тенант>, true) (= SET LOCAL);
+-- служебные задачи (retention, reindex, keys rotate) — set_config('app.bypass_rls', 'on', true).
+-- Без этих настроек запрос не видит ни одной строки.
+-- FORCE: политики действуют и на владельца таблиц. Суперпользователь и роли с BYPASSRLS
+-- политики игнорируют — сервис должен подключаться обычной ролью (проверка: migrate verify).
+-- Миграции, меняющие данные, должны сами выполнять SET app.bypass_rls = 'on'.
+
+ALTER TABLE tickets ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tickets FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON tickets
+    USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.bypass_rls', true) = 'on')
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.bypass_rls', true) = 'on');
+
+ALTER TABLE ticket_sessions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ticket_sessions FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON ticket_sessions
+    USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.bypass_rls', true) = 'on')
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.bypass_rls', true) = 'on');
+
+ALTER TABLE ticket_links ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ticket_links FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON ticket_links
+    USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.bypass_rls', true) = 'on')
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.bypass_rls', true) = 'on');
+
+ALTER TABLE ticket_audit_log ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ticket_audit_log FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON ticket_audit_log
+    USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.bypass_rls', true) = 'on')
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.bypass_rls', true) = 'on');
+
+ALTER TABLE ticket_reference_counters ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ticket_reference_counters FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON ticket_reference_counters
+    USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.bypass_rls', true) = 'on')
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.bypass_rls', true) = 'on');
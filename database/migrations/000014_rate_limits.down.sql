@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS ticket_create_quota;
+DROP TABLE IF EXISTS rate_limit_buckets;
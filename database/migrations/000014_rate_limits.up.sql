@@ -0,0 +1,14 @@
+-- Состояние rate limiting для RATE_LIMIT_STORE=postgres (общее для всех реплик).
+-- Ключи уже содержат тенант, поэтому tenant_id и RLS здесь не нужны.
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    key        VARCHAR(512)     PRIMARY KEY,
+    tokens     DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ      NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS ticket_create_quota (
+    key  VARCHAR(512) NOT NULL,
+    day  DATE         NOT NULL,
+    used INT          NOT NULL,
+    PRIMARY KEY (key, day)
+);
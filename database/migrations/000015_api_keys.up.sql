@@ -0,0 +1,17 @@
+-- API-ключи сервисов. Ключ ищется до того, как известен тенант, поэтому RLS на таблице нет:
+-- доступ к ней только у CLI (apikey) и перехватчика аутентификации.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id           BIGSERIAL    PRIMARY KEY,
+    tenant_id    VARCHAR(64)  NOT NULL DEFAULT '',
+    name         VARCHAR(128) NOT NULL,
+    prefix       VARCHAR(16)  NOT NULL,
+    key_hash     CHAR(64)     NOT NULL,
+    scopes       TEXT[]       NOT NULL,
+    expires_at   TIMESTAMPTZ  NOT NULL,
+    revoked_at   TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_api_keys_key_hash ON api_keys (key_hash);
+CREATE INDEX IF NOT EXISTS ix_api_keys_tenant_id ON api_keys (tenant_id);
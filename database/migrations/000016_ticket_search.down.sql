@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tickets_search_vector;
+ALTER TABLE tickets DROP COLUMN IF EXISTS search_vector;
+DROP FUNCTION IF EXISTS ticket_search_config(TEXT);
@@ -0,0 +1,32 @@
+-- Полнотекстовый поиск SearchTickets без search-service.
+-- ticket_search_config — языковая конфигурация по региону тикета. Объявлена IMMUTABLE, чтобы
+-- использоваться в генерируемой колонке: при изменении соответствия колонку нужно пересчитать.
+CREATE OR REPLACE FUNCTION ticket_search_config(region TEXT) RETURNS regconfig
+LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+    SELECT CASE lower(coalesce(region, ''))
+        WHEN 'ru' THEN 'pg_catalog.russian'
+        WHEN 'by' THEN 'pg_catalog.russian'
+        WHEN 'kz' THEN 'pg_catalog.russian'
+        WHEN 'de' THEN 'pg_catalog.german'
+        WHEN 'at' THEN 'pg_catalog.german'
+        WHEN 'fr' THEN 'pg_catalog.french'
+        WHEN 'es' THEN 'pg_catalog.spanish'
+        WHEN 'it' THEN 'pg_catalog.italian'
+        WHEN 'en' THEN 'pg_catalog.english'
+        WHEN 'us' THEN 'pg_catalog.english'
+        WHEN 'uk' THEN 'pg_catalog.english'
+        WHEN 'gb' THEN 'pg_catalog.english'
+        ELSE 'pg_catalog.simple'
+    END::regconfig
+$$;
+
+-- Веса: A/B — subject/notes в конфигурации региона (морфология), C/D — они же без учёта языка
+-- ('simple'), чтобы поиск без region находил слова в любом регионе.
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector(ticket_search_config(region), coalesce(subject, '')), 'A') ||
+    setweight(to_tsvector(ticket_search_config(region), coalesce(notes, '')), 'B') ||
+    setweight(to_tsvector('pg_catalog.simple'::regconfig, coalesce(subject, '')), 'C') ||
+    setweight(to_tsvector('pg_catalog.simple'::regconfig, coalesce(notes, '')), 'D')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_search_vector ON tickets USING GIN (search_vector);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tickets_text_trgm;
+-- Расширение pg_trgm не удаляется: им могут пользоваться другие объекты БД.
@@ -0,0 +1,7 @@
+-- Поиск похожих тикетов (FindSimilarTickets, проверка дублей в CreateTicket).
+-- Создание расширения требует прав владельца БД (или trusted-расширения, PostgreSQL 13+).
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Выражение должно совпадать с similarTextSQL в internal/service/ticket_similar.go.
+CREATE INDEX IF NOT EXISTS idx_tickets_text_trgm ON tickets
+    USING GIN ((coalesce(subject, '') || ' ' || coalesce(notes, '')) gin_trgm_ops);
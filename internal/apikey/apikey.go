@@ -0,0 +1,183 @@
+// Package apikey — API-ключи сервисов (service-to-service) с областями доступа и сроком действия.
+// В БД хранится только SHA-256 ключа: сам ключ показывается один раз при создании.
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/psds-microservice/ticket-service/internal/auth"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+	"gorm.io/gorm"
+)
+
+// keyPrefix отличает ключи ticket-service от других секретов (например, в сканерах утечек).
+const keyPrefix = "tsk_"
+
+// prefixLen — сколько символов ключа хранится открыто для списка ключей.
+const prefixLen = 12
+
+// touchInterval — last_used_at обновляется не чаще раза за интервал, чтобы не писать в БД на каждый вызов.
+const touchInterval = time.Minute
+
+var (
+	// ErrInvalid — ключ не найден, отозван или истёк (вызывающему причина не сообщается).
+	ErrInvalid = errors.New("apikey: invalid api key")
+	// ErrNotFound — ключа с таким id нет.
+	ErrNotFound = errors.New("apikey: key not found")
+)
+
+// Hash — SHA-256 ключа в hex. Ключ случайный и длинный, поэтому соль не нужна.
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Generate возвращает новый ключ вида tsk_<43 символа base64url>.
+func Generate() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("apikey: generate: %w", err)
+	}
+	return keyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// ParseScopes разбирает список через запятую и проверяет, что все scope известны.
+func ParseScopes(s string) ([]string, error) {
+	var out []string
+	for _, sc := range strings.Split(s, ",") {
+		sc = strings.TrimSpace(sc)
+		if sc == "" {
+			continue
+		}
+		if !auth.KnownScope(sc) {
+			return nil, fmt.Errorf("apikey: unknown scope %q", sc)
+		}
+		out = append(out, sc)
+	}
+	if len(out) == 0 {
+		return nil, errors.New("apikey: at least one scope is required")
+	}
+	return out, nil
+}
+
+// Store — API-ключи в таблице api_keys. Таблица не принадлежит тенанту запроса: все обращения
+// идут в служебном контексте, а тенант ключа выставляется явно.
+type Store struct {
+	db *gorm.DB
+
+	mu      sync.Mutex
+	touched map[uint64]time.Time
+}
+
+func NewStore(db *gorm.DB) *Store {
+	return &Store{db: db, touched: make(map[uint64]time.Time)}
+}
+
+func (s *Store) conn(ctx context.Context) *gorm.DB {
+	return s.db.WithContext(tenant.WithSystem(ctx))
+}
+
+// Create создаёт ключ и возвращает его вместе с записью. Ключ больше нигде не сохраняется.
+func (s *Store) Create(ctx context.Context, tenantID, name string, scopes []string, expiresAt time.Time) (string, *model.APIKey, error) {
+	raw, err := Generate()
+	if err != nil {
+		return "", nil, err
+	}
+	k := &model.APIKey{
+		TenantID:  tenantID,
+		Name:      name,
+		Prefix:    raw[:prefixLen],
+		KeyHash:   Hash(raw),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt.UTC(),
+	}
+	if err := s.conn(ctx).Create(k).Error; err != nil {
+		return "", nil, err
+	}
+	return raw, k, nil
+}
+
+// List возвращает ключи тенанта (пустой tenantID — все ключи), новые первыми.
+func (s *Store) List(ctx context.Context, tenantID string) ([]model.APIKey, error) {
+	var keys []model.APIKey
+	tx := s.conn(ctx).Order("id DESC")
+	if tenantID != "" {
+		tx = tx.Where("tenant_id = ?", tenantID)
+	}
+	if err := tx.Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Revoke отзывает ключ. Повторный отзыв не меняет время первого.
+func (s *Store) Revoke(ctx context.Context, id uint64) (*model.APIKey, error) {
+	var k model.APIKey
+	if err := s.conn(ctx).First(&k, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if k.RevokedAt != nil {
+		return &k, nil
+	}
+	now := time.Now().UTC()
+	if err := s.conn(ctx).Model(&k).Update("revoked_at", now).Error; err != nil {
+		return nil, err
+	}
+	k.RevokedAt = &now
+	return &k, nil
+}
+
+// Authenticate находит действующий ключ и отмечает его использование.
+func (s *Store) Authenticate(ctx context.Context, raw string) (*model.APIKey, error) {
+	if !strings.HasPrefix(raw, keyPrefix) {
+		return nil, ErrInvalid
+	}
+	var k model.APIKey
+	err := s.conn(ctx).
+		Where("key_hash = ? AND revoked_at IS NULL AND expires_at > ?", Hash(raw), time.Now()).
+		First(&k).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.touch(k.ID)
+	return &k, nil
+}
+
+// touch обновляет last_used_at в фоне, не чаще touchInterval на ключ в этой реплике.
+func (s *Store) touch(id uint64) {
+	now := time.Now()
+	s.mu.Lock()
+	if now.Sub(s.touched[id]) < touchInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.touched[id] = now
+	s.mu.Unlock()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err := s.conn(ctx).Model(&model.APIKey{}).
+			Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, now.Add(-touchInterval)).
+			Update("last_used_at", now.UTC()).Error
+		if err != nil {
+			log.Printf("apikey: update last_used_at of key %d: %v", id, err)
+		}
+	}()
+}
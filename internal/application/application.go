@@ -4,24 +4,40 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math"
 	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/psds-microservice/helpy/paths"
+	"github.com/psds-microservice/ticket-service/internal/apikey"
+	"github.com/psds-microservice/ticket-service/internal/changefeed"
 	"github.com/psds-microservice/ticket-service/internal/config"
 	"github.com/psds-microservice/ticket-service/internal/database"
+	"github.com/psds-microservice/ticket-service/internal/encryption"
 	grpcserver "github.com/psds-microservice/ticket-service/internal/grpc"
 	"github.com/psds-microservice/ticket-service/internal/handler"
 	"github.com/psds-microservice/ticket-service/internal/kafka"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/internal/ratelimit"
 	"github.com/psds-microservice/ticket-service/internal/service"
+	"github.com/psds-microservice/ticket-service/internal/tlsconfig"
+	"github.com/psds-microservice/ticket-service/internal/watch"
 	"github.com/psds-microservice/ticket-service/pkg/gen/ticket_service"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -52,12 +68,100 @@ func serveOpenAPISpec() http.HandlerFunc {
 	}
 }
 
+// gatewayHeaderMatcher передаёт в gRPC-метаданные заголовки вызывающего и тенанта как есть
+// (остальные — по правилам grpc-gateway, в том числе Grpc-Metadata-*).
+// Идентичность клиентского сертификата и адрес клиента от клиента не принимаются: их выставляет только gateway.
+func gatewayHeaderMatcher(key string) (string, bool) {
+	switch k := strings.ToLower(key); k {
+	case "x-tenant-id", "x-caller-id", "x-caller-role":
+		return k, true
+	case grpcserver.ForwardedIdentityHeader, "grpc-metadata-" + grpcserver.ForwardedIdentityHeader,
+		grpcserver.ForwardedPeerHeader, "grpc-metadata-" + grpcserver.ForwardedPeerHeader:
+		return "", false
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// gatewayErrorHandler — стандартный ответ grpc-gateway плюс Retry-After, если в ошибке есть
+// RetryInfo (ResourceExhausted от rate limiting уже отображается в 429).
+func gatewayErrorHandler(ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if st, ok := status.FromError(err); ok {
+		for _, d := range st.Details() {
+			if ri, ok := d.(*errdetails.RetryInfo); ok {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(ri.GetRetryDelay().AsDuration().Seconds()))))
+			}
+		}
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, mux, m, w, r, err)
+}
+
+// gatewayPeer передаёт во внутренний gRPC-сервер адрес HTTP-клиента (ключ rate limiting
+// для анонимных вызовов).
+func gatewayPeer(_ context.Context, r *http.Request) metadata.MD {
+	return metadata.Pairs(grpcserver.ForwardedPeerHeader, grpcserver.PeerHost(r.RemoteAddr))
+}
+
+// gatewayCertIdentity передаёт во внутренний gRPC-сервер идентичность проверенного
+// клиентского сертификата HTTP-запроса.
+func gatewayCertIdentity(_ context.Context, r *http.Request) metadata.MD {
+	if r.TLS == nil {
+		return nil
+	}
+	if id := tlsconfig.VerifiedIdentity(*r.TLS); id != "" {
+		return metadata.Pairs(grpcserver.ForwardedIdentityHeader, id)
+	}
+	return nil
+}
+
+// streamingNoWriteTimeout снимает WriteTimeout сервера для потоковых ответов (WatchTickets по REST):
+// поток живёт дольше любого таймаута записи.
+func streamingNoWriteTimeout(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/watch") {
+			_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // API приложение: HTTP + gRPC серверы (режим api).
 type API struct {
 	cfg     *config.Config
 	httpSrv *http.Server
 	grpcSrv *grpc.Server
 	lis     net.Listener
+	// gwSrv/gwLis/gwConn — внутренний gRPC-сервер и in-process соединение grpc-gateway с ним.
+	gwSrv  *grpc.Server
+	gwLis  *bufconn.Listener
+	gwConn *grpc.ClientConn
+	// tls — nil, если TLS не настроен.
+	tls   *tlsconfig.Reloader
+	watch *watch.Broadcaster
+	// changes — nil, если CHANGEFEED_ENABLED=false.
+	changes *changefeed.Listener
+}
+
+// newGRPCServer — gRPC-сервер с общей цепочкой перехватчиков. trustForwarded — сервер
+// для grpc-gateway: идентичность сертификата приходит в метаданных, а не из соединения.
+func newGRPCServer(cfg *config.Config, keys grpcserver.APIKeyAuthenticator, limiter *ratelimit.Limiter, trustForwarded bool, opts ...grpc.ServerOption) *grpc.Server {
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+	if cfg.TLSPrincipalFromCert {
+		unary = append(unary, grpcserver.CertPrincipalUnaryInterceptor(trustForwarded))
+		stream = append(stream, grpcserver.CertPrincipalStreamInterceptor(trustForwarded))
+	}
+	unary = append(unary,
+		grpcserver.APIKeyUnaryInterceptor(keys),
+		grpcserver.TenantUnaryInterceptor(cfg.DefaultTenantID),
+		grpcserver.RateLimitUnaryInterceptor(limiter, trustForwarded),
+	)
+	stream = append(stream,
+		grpcserver.APIKeyStreamInterceptor(keys),
+		grpcserver.TenantStreamInterceptor(cfg.DefaultTenantID),
+		grpcserver.RateLimitStreamInterceptor(limiter, trustForwarded),
+	)
+	opts = append(opts, grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(stream...))
+	return grpc.NewServer(opts...)
 }
 
 // NewAPI создаёт приложение для режима api.
@@ -72,25 +176,85 @@ func NewAPI(cfg *config.Config) (*API, error) {
 	if err != nil {
 		return nil, fmt.Errorf("database: %w", err)
 	}
+	if _, err := encryption.Register(db, cfg.EncryptionKeyfile); err != nil {
+		return nil, fmt.Errorf("encryption: %w", err)
+	}
 
-	ticketSvc := service.NewTicketService(db)
-	kafkaProducer := kafka.NewProducer(cfg.KafkaBrokers, cfg.KafkaTopicTicket)
+	ticketSvc := service.NewTicketService(db, service.Options{
+		RefPrefixes:      cfg.TicketRefPrefixes,
+		DefaultRefPrefix: cfg.TicketRefDefaultPrefix,
+		SubjectEncrypted: cfg.EncryptionKeyfile != "",
+	})
+	kafkaRedactor, err := cfg.KafkaRedactor()
+	if err != nil {
+		return nil, fmt.Errorf("redact: %w", err)
+	}
+	kafkaProducer := kafka.NewProducer(cfg.KafkaBrokers, cfg.KafkaTopicTicket, kafkaRedactor)
 
 	grpcAddr := cfg.AppHost + ":" + cfg.GRPCPort
 	lis, err := net.Listen("tcp", grpcAddr)
 	if err != nil {
 		return nil, fmt.Errorf("grpc listen %s: %w (порт занят — остановите другой процесс или задайте GRPC_PORT в .env)", grpcAddr, err)
 	}
-	grpcSrv := grpc.NewServer()
-	grpcImpl := grpcserver.NewServer(grpcserver.Deps{
-		Ticket:   ticketSvc,
-		Producer: kafkaProducer,
-	})
+	rateCfg, err := cfg.RateLimitConfig()
+	if err != nil {
+		return nil, fmt.Errorf("rate limit: %w", err)
+	}
+	var rateStore ratelimit.Store = ratelimit.NewMemoryStore()
+	if cfg.RateLimitStore == "postgres" {
+		rateStore = ratelimit.NewPostgresStore(db)
+	}
+	limiter := ratelimit.NewLimiter(rateCfg, rateStore)
+
+	var reloader *tlsconfig.Reloader
+	var grpcOpts []grpc.ServerOption
+	if cfg.TLSEnabled() {
+		clientAuth, err := tlsconfig.ParseClientAuth(cfg.TLSClientAuth)
+		if err != nil {
+			return nil, fmt.Errorf("tls: %w", err)
+		}
+		if reloader, err = tlsconfig.NewReloader(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCAFile, clientAuth); err != nil {
+			return nil, err
+		}
+		grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(reloader.ServerConfig("h2"))))
+	}
+	apiKeys := apikey.NewStore(db)
+	grpcSrv := newGRPCServer(cfg, apiKeys, limiter, false, grpcOpts...)
+	// Внутренний сервер без TLS слушает только bufconn: через него идёт REST из grpc-gateway.
+	gwSrv := newGRPCServer(cfg, apiKeys, limiter, true)
+	broadcaster := watch.NewBroadcaster(watch.DefaultBufferSize)
+	deps := grpcserver.Deps{
+		Ticket:       ticketSvc,
+		Producer:     kafkaProducer,
+		SessionDedup: service.SessionDedupPolicy(cfg.SessionDedupPolicy),
+		Watch:        broadcaster,
+
+		ListDefaultLimit: cfg.ListDefaultLimit,
+		ListMaxLimit:     cfg.ListMaxLimit,
+	}
+	if cfg.ListPageTokenKey != "" {
+		deps.PageTokens = grpcserver.NewPageTokens([]byte(cfg.ListPageTokenKey))
+	} else {
+		log.Printf("LIST_PAGE_TOKEN_KEY is not set: page tokens are valid only on this replica until restart")
+	}
+	// Изменения с других реплик и из CLI приходят через LISTEN/NOTIFY и раздаются локальным подписчикам.
+	var listener *changefeed.Listener
+	if cfg.ChangeFeedEnabled {
+		replica := changefeed.NewReplicaID()
+		deps.Changes = changefeed.NewNotifier(db, replica)
+		listener = changefeed.NewListener(cfg.DSN(), replica, ticketSvc)
+		listener.Handle(func(event string, t *model.Ticket) { broadcaster.Publish(watch.TypeOf(event), t) })
+	}
+	grpcImpl := grpcserver.NewServer(deps)
 	ticket_service.RegisterTicketServiceServer(grpcSrv, grpcImpl)
+	ticket_service.RegisterTicketServiceServer(gwSrv, grpcImpl)
 	reflection.Register(grpcSrv)
 
 	// Настройка grpc-gateway: возвращаем 201 Created для POST запросов на создание тикетов
-	gatewayMux := runtime.NewServeMux(
+	gatewayOpts := []runtime.ServeMuxOption{
+		runtime.WithIncomingHeaderMatcher(gatewayHeaderMatcher),
+		runtime.WithErrorHandler(gatewayErrorHandler),
+		runtime.WithMetadata(gatewayPeer),
 		runtime.WithForwardResponseOption(func(ctx context.Context, w http.ResponseWriter, resp proto.Message) error {
 			// Проверяем путь запроса из контекста и тип ответа
 			pattern, ok := runtime.HTTPPathPattern(ctx)
@@ -104,8 +268,22 @@ func NewAPI(cfg *config.Config) (*API, error) {
 			}
 			return nil
 		}),
+	}
+	if cfg.TLSPrincipalFromCert {
+		gatewayOpts = append(gatewayOpts, runtime.WithMetadata(gatewayCertIdentity))
+	}
+	gatewayMux := runtime.NewServeMux(gatewayOpts...)
+	// REST проксируется в gRPC-сервер через in-process соединение (bufconn), а не вызовом
+	// методов напрямую: перехватчики (тенант и т.п.) применяются и к REST-запросам.
+	gwLis := bufconn.Listen(1 << 20)
+	gwConn, err := grpc.NewClient("passthrough:///ticket-service",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return gwLis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
 	)
-	if err := ticket_service.RegisterTicketServiceHandlerServer(context.Background(), gatewayMux, grpcImpl); err != nil {
+	if err != nil {
+		return nil, fmt.Errorf("grpc-gateway conn: %w", err)
+	}
+	if err := ticket_service.RegisterTicketServiceHandler(context.Background(), gatewayMux, gwConn); err != nil {
 		return nil, fmt.Errorf("register grpc-gateway: %w", err)
 	}
 
@@ -118,7 +296,16 @@ func NewAPI(cfg *config.Config) (*API, error) {
 		httpSwagger.DeepLinking(true),
 		httpSwagger.DocExpansion("list"),
 	))
-	mux.Handle("/", gatewayMux)
+	// SSE/WebSocket для браузеров — тот же WatchTickets через внутренний gRPC-сервер,
+	// заголовки переносятся в метаданные по правилам gateway (таймауты записи handler задаёт сам).
+	mux.Handle("/api/v1/tickets/stream", handler.NewTicketStream(
+		ticket_service.NewTicketServiceClient(gwConn),
+		func(ctx context.Context, r *http.Request) (context.Context, error) {
+			return runtime.AnnotateContext(ctx, gatewayMux, r, ticket_service.TicketService_WatchTickets_FullMethodName,
+				runtime.WithHTTPPathPattern("/api/v1/tickets/stream"))
+		},
+	))
+	mux.Handle("/", streamingNoWriteTimeout(gatewayMux))
 
 	httpAddr := cfg.AppHost + ":" + cfg.HTTPPort
 	httpSrv := &http.Server{
@@ -129,12 +316,21 @@ func NewAPI(cfg *config.Config) (*API, error) {
 		WriteTimeout:      30 * time.Second,
 		IdleTimeout:       60 * time.Second,
 	}
+	if reloader != nil {
+		httpSrv.TLSConfig = reloader.ServerConfig("h2", "http/1.1")
+	}
 
 	return &API{
 		cfg:     cfg,
 		httpSrv: httpSrv,
 		grpcSrv: grpcSrv,
 		lis:     lis,
+		gwSrv:   gwSrv,
+		gwLis:   gwLis,
+		gwConn:  gwConn,
+		tls:     reloader,
+		watch:   broadcaster,
+		changes: listener,
 	}, nil
 }
 
@@ -146,18 +342,43 @@ func (a *API) Run(ctx context.Context) error {
 	if host == "0.0.0.0" {
 		host = "localhost"
 	}
-	base := "http://" + host + ":" + a.cfg.HTTPPort
+	scheme := "http"
+	if a.tls != nil {
+		scheme = "https"
+	}
+	base := scheme + "://" + host + ":" + a.cfg.HTTPPort
 	log.Printf("HTTP server listening on %s", httpAddr)
 	log.Printf("  Swagger UI:    %s/swagger", base)
 	log.Printf("  Swagger spec:  %s/swagger/openapi.json", base)
 	log.Printf("  Health:        %s/health", base)
 	log.Printf("  Ready:         %s/ready", base)
 	log.Printf("  API v1:        %s/api/v1/", base)
+	log.Printf("  Ticket stream: %s/api/v1/tickets/stream (SSE, WebSocket)", base)
 	log.Printf("gRPC server listening on %s", grpcAddr)
 	log.Printf("  gRPC endpoint: %s (reflection enabled)", grpcAddr)
 
+	if a.tls != nil {
+		log.Printf("  TLS:           client auth %s, reload every %s", a.cfg.TLSClientAuth, a.cfg.TLSReloadInterval)
+		go a.tls.Run(ctx, a.cfg.TLSReloadInterval)
+	}
+
+	if a.changes != nil {
+		go func() {
+			if err := a.changes.Run(ctx); err != nil {
+				log.Printf("changefeed: %v", err)
+			}
+		}()
+	}
+
 	go func() {
-		if err := a.httpSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if a.tls != nil {
+			// Сертификат берётся из TLSConfig (GetCertificate), файлы здесь не нужны.
+			err = a.httpSrv.ListenAndServeTLS("", "")
+		} else {
+			err = a.httpSrv.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			log.Printf("http: %v", err)
 		}
 	}()
@@ -168,12 +389,22 @@ func (a *API) Run(ctx context.Context) error {
 		}
 	}()
 
+	go func() {
+		if err := a.gwSrv.Serve(a.gwLis); err != nil {
+			log.Printf("grpc (gateway): %v", err)
+		}
+	}()
+
 	<-ctx.Done()
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	if err := a.httpSrv.Shutdown(shutdownCtx); err != nil {
 		return fmt.Errorf("http shutdown: %w", err)
 	}
+	// Потоки WatchTickets сами не завершаются: закрываем подписки, чтобы GracefulStop не ждал их.
+	a.watch.Close()
+	a.gwConn.Close()
+	a.gwSrv.GracefulStop()
 	a.grpcSrv.GracefulStop()
 	return nil
 }
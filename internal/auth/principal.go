@@ -0,0 +1,56 @@
+// Package auth — аутентифицированный вызывающий (principal) в контексте запроса.
+package auth
+
+import "context"
+
+// Области доступа API-ключей. ScopeTicketsAdmin включает все остальные.
+const (
+	ScopeTicketsCreate = "tickets:create"
+	ScopeTicketsRead   = "tickets:read"
+	ScopeTicketsAdmin  = "tickets:admin"
+)
+
+// KnownScope — scope, который можно выдать API-ключу.
+func KnownScope(s string) bool {
+	switch s {
+	case ScopeTicketsCreate, ScopeTicketsRead, ScopeTicketsAdmin:
+		return true
+	}
+	return false
+}
+
+// Principal — кто выполняет запрос. TenantID — тенант, к которому привязана учётная запись;
+// пустой — principal не ограничен тенантом (тенант берётся из x-tenant-id).
+// Scopes заданы только у principal, аутентифицированного API-ключом; nil — без ограничений по scope.
+type Principal struct {
+	ID       string
+	Role     string
+	TenantID string
+	Scopes   []string
+}
+
+// Scoped — доступ principal ограничен его Scopes.
+func (p *Principal) Scoped() bool { return p.Scopes != nil }
+
+// HasScope: у principal есть scope s (или tickets:admin).
+func (p *Principal) HasScope(s string) bool {
+	for _, have := range p.Scopes {
+		if have == s || have == ScopeTicketsAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+type ctxKey struct{}
+
+// WithPrincipal кладёт principal в контекст (вызывают перехватчики аутентификации).
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, ctxKey{}, p)
+}
+
+// FromContext возвращает principal запроса, если он аутентифицирован.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(ctxKey{}).(*Principal)
+	return p, ok && p != nil
+}
@@ -0,0 +1,435 @@
+// Package celfilter — фильтры тикетов на Common Expression Language (CEL).
+// Выражение проверяется по объявленной схеме тикета и переводится в SQL-условие
+// (ListTickets); то же выражение вычисляется над тикетом в памяти (WatchTickets).
+package celfilter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common"
+	celast "github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/common/types"
+	"github.com/psds-microservice/ticket-service/internal/model"
+)
+
+// MaxLength — предельная длина выражения.
+const MaxLength = 2048
+
+// fields — переменные схемы и их колонки в tickets.
+var fields = map[string]string{
+	"id":          "id",
+	"reference":   "reference",
+	"client_id":   "client_id",
+	"operator_id": "operator_id",
+	"status":      "status",
+	"priority":    "priority",
+	"region":      "region",
+	"session_id":  "session_id",
+	"subject":     "subject",
+	"legal_hold":  "legal_hold",
+	"created_at":  "created_at",
+	"updated_at":  "updated_at",
+	"closed_at":   "closed_at",
+}
+
+// varNow — текущее время (в SQL — NOW()).
+const varNow = "now"
+
+var env = mustEnv()
+
+func mustEnv() *cel.Env {
+	e, err := cel.NewEnv(
+		cel.Variable("id", cel.IntType),
+		cel.Variable("reference", cel.StringType),
+		cel.Variable("client_id", cel.StringType),
+		cel.Variable("operator_id", cel.StringType),
+		cel.Variable("status", cel.StringType),
+		cel.Variable("priority", cel.StringType),
+		cel.Variable("region", cel.StringType),
+		cel.Variable("session_id", cel.StringType),
+		cel.Variable("subject", cel.StringType),
+		cel.Variable("legal_hold", cel.BoolType),
+		cel.Variable("created_at", cel.TimestampType),
+		cel.Variable("updated_at", cel.TimestampType),
+		cel.Variable("closed_at", cel.NullableType(cel.TimestampType)),
+		cel.Variable(varNow, cel.TimestampType),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("celfilter: %v", err))
+	}
+	return e
+}
+
+// Error — ошибка выражения с позицией (строка и колонка с 1).
+type Error struct {
+	Line, Column int
+	Msg          string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("filter:%d:%d: %s", e.Line, e.Column, e.Msg)
+}
+
+func errorAt(loc common.Location, format string, args ...interface{}) *Error {
+	if loc == nil || loc == common.NoLocation {
+		return &Error{Line: 1, Column: 1, Msg: fmt.Sprintf(format, args...)}
+	}
+	return &Error{Line: loc.Line(), Column: loc.Column() + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Filter — проверенное выражение: SQL-условие с параметрами и программа для тикетов в памяти.
+// В JSON сериализуется как исходный текст (отпечаток фильтров page_token).
+type Filter struct {
+	Source string `json:"source"`
+
+	sql         string
+	args        []interface{}
+	usesSubject bool
+	program     cel.Program
+}
+
+// Compile проверяет выражение по схеме тикета и переводит его в SQL.
+// Ошибки — *Error с позицией первой проблемы.
+func Compile(src string) (*Filter, error) {
+	if len(src) > MaxLength {
+		return nil, &Error{Line: 1, Column: 1, Msg: fmt.Sprintf("expression is longer than %d bytes", MaxLength)}
+	}
+	checked, iss := env.Compile(src)
+	if iss != nil && iss.Err() != nil {
+		e := iss.Errors()[0]
+		return nil, errorAt(e.Location, "%s", e.Message)
+	}
+	if !checked.OutputType().IsExactType(cel.BoolType) {
+		return nil, &Error{Line: 1, Column: 1, Msg: fmt.Sprintf("expression must be bool, got %s", checked.OutputType())}
+	}
+	tr := &translator{a: checked.NativeRep()}
+	sql, err := tr.bool(tr.a.Expr())
+	if err != nil {
+		return nil, err
+	}
+	prg, perr := env.Program(checked)
+	if perr != nil {
+		return nil, &Error{Line: 1, Column: 1, Msg: perr.Error()}
+	}
+	return &Filter{Source: src, sql: sql, args: tr.args, usesSubject: tr.usesSubject, program: prg}, nil
+}
+
+// SQL — условие WHERE и его параметры.
+func (f *Filter) SQL() (string, []interface{}) {
+	return f.sql, f.args
+}
+
+// UsesSubject — выражение ссылается на subject (недоступно при шифровании полей).
+func (f *Filter) UsesSubject() bool {
+	return f.usesSubject
+}
+
+// Matches вычисляет выражение над тикетом. Ошибка вычисления (например, сравнение
+// пустого closed_at) — тикет не подходит, как NULL в SQL.
+func (f *Filter) Matches(t *model.Ticket, now time.Time) bool {
+	var closedAt interface{} = types.NullValue
+	if t.ClosedAt != nil {
+		closedAt = *t.ClosedAt
+	}
+	out, _, err := f.program.Eval(map[string]interface{}{
+		"id":          int64(t.ID),
+		"reference":   t.Reference,
+		"client_id":   t.ClientID,
+		"operator_id": t.OperatorID,
+		"status":      string(t.Status),
+		"priority":    t.Priority,
+		"region":      t.Region,
+		"session_id":  t.SessionID,
+		"subject":     t.Subject,
+		"legal_hold":  t.LegalHold,
+		"created_at":  t.CreatedAt,
+		"updated_at":  t.UpdatedAt,
+		"closed_at":   closedAt,
+		varNow:        now,
+	})
+	if err != nil {
+		return false
+	}
+	b, ok := out.(types.Bool)
+	return ok && bool(b)
+}
+
+// translator переводит поддерживаемое подмножество CEL в SQL. Имена колонок берутся только
+// из fields, значения — только параметрами.
+type translator struct {
+	a           *celast.AST
+	args        []interface{}
+	usesSubject bool
+}
+
+func (tr *translator) fail(e celast.Expr, format string, args ...interface{}) *Error {
+	return errorAt(tr.a.SourceInfo().GetStartLocation(e.ID()), format, args...)
+}
+
+func (tr *translator) param(v interface{}) string {
+	tr.args = append(tr.args, v)
+	return "?"
+}
+
+var comparisons = map[string]string{
+	"_==_": "=",
+	"_!=_": "<>",
+	"_<_":  "<",
+	"_<=_": "<=",
+	"_>_":  ">",
+	"_>=_": ">=",
+}
+
+var likePatterns = map[string]string{
+	"contains":   "%%%s%%",
+	"startsWith": "%s%%",
+	"endsWith":   "%%%s",
+}
+
+// bool — логическое выражение.
+func (tr *translator) bool(e celast.Expr) (string, error) {
+	switch e.Kind() {
+	case celast.IdentKind:
+		return tr.value(e)
+	case celast.LiteralKind:
+		if b, ok := e.AsLiteral().(types.Bool); ok {
+			if b {
+				return "TRUE", nil
+			}
+			return "FALSE", nil
+		}
+	case celast.CallKind:
+		return tr.call(e)
+	case celast.ComprehensionKind:
+		return "", tr.fail(e, "macros all, exists, exists_one, map and filter are not supported in filters")
+	}
+	return "", tr.fail(e, "unsupported expression")
+}
+
+func (tr *translator) call(e celast.Expr) (string, error) {
+	c := e.AsCall()
+	args := c.Args()
+	switch fn := c.FunctionName(); fn {
+	case "_&&_", "_||_":
+		op := " AND "
+		if fn == "_||_" {
+			op = " OR "
+		}
+		parts := make([]string, len(args))
+		for i, arg := range args {
+			p, err := tr.bool(arg)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = p
+		}
+		return "(" + strings.Join(parts, op) + ")", nil
+	case "!_":
+		p, err := tr.bool(args[0])
+		if err != nil {
+			return "", err
+		}
+		return "NOT (" + p + ")", nil
+	case "_==_", "_!=_", "_<_", "_<=_", "_>_", "_>=_":
+		return tr.compare(e, fn, args[0], args[1])
+	case "@in":
+		return tr.in(args[0], args[1])
+	case "contains", "startsWith", "endsWith":
+		if !c.IsMemberFunction() {
+			break
+		}
+		col, err := tr.column(c.Target())
+		if err != nil {
+			return "", err
+		}
+		lit, ok := stringLiteral(args[0])
+		if !ok {
+			return "", tr.fail(args[0], "%s() argument must be a string literal", fn)
+		}
+		return col + " LIKE " + tr.param(fmt.Sprintf(likePatterns[fn], EscapeLike(lit))), nil
+	}
+	return "", tr.fail(e, "function %s is not supported in filters", displayName(c.FunctionName()))
+}
+
+func (tr *translator) compare(e celast.Expr, fn string, lhs, rhs celast.Expr) (string, error) {
+	// NULL сравнивается только на равенство: IS [NOT] NULL.
+	if isNull(rhs) || isNull(lhs) {
+		other := lhs
+		if isNull(lhs) {
+			other = rhs
+		}
+		if fn != "_==_" && fn != "_!=_" {
+			return "", tr.fail(e, "null can only be compared with == or !=")
+		}
+		v, err := tr.value(other)
+		if err != nil {
+			return "", err
+		}
+		if fn == "_==_" {
+			return v + " IS NULL", nil
+		}
+		return v + " IS NOT NULL", nil
+	}
+	if fn != "_==_" && fn != "_!=_" {
+		// Порядок строк в SQL зависит от collation и расходится с CEL — сравниваются только числа и время.
+		switch tr.a.GetType(lhs.ID()).Kind() {
+		case types.StringKind, types.BoolKind:
+			return "", tr.fail(e, "ordering comparison is only supported for ids and timestamps")
+		}
+	}
+	l, err := tr.value(lhs)
+	if err != nil {
+		return "", err
+	}
+	r, err := tr.value(rhs)
+	if err != nil {
+		return "", err
+	}
+	return l + " " + comparisons[fn] + " " + r, nil
+}
+
+func (tr *translator) in(elem, list celast.Expr) (string, error) {
+	col, err := tr.column(elem)
+	if err != nil {
+		return "", err
+	}
+	if list.Kind() != celast.ListKind {
+		return "", tr.fail(list, "right side of 'in' must be a list literal")
+	}
+	elems := list.AsList().Elements()
+	if len(elems) == 0 {
+		return "FALSE", nil
+	}
+	values := make([]interface{}, len(elems))
+	for i, el := range elems {
+		v, ok := literal(el)
+		if !ok {
+			return "", tr.fail(el, "list elements must be literals")
+		}
+		values[i] = v
+	}
+	return col + " IN " + tr.param(values), nil
+}
+
+// column — поле тикета.
+func (tr *translator) column(e celast.Expr) (string, error) {
+	if e.Kind() == celast.IdentKind {
+		if col, ok := fields[e.AsIdent()]; ok {
+			if col == "subject" {
+				tr.usesSubject = true
+			}
+			return col, nil
+		}
+	}
+	return "", tr.fail(e, "expected a ticket field")
+}
+
+// value — операнд сравнения: поле, литерал, now, timestamp("…") или время ± duration("…").
+func (tr *translator) value(e celast.Expr) (string, error) {
+	switch e.Kind() {
+	case celast.IdentKind:
+		if e.AsIdent() == varNow {
+			return "NOW()", nil
+		}
+		return tr.column(e)
+	case celast.LiteralKind:
+		v, ok := literal(e)
+		if !ok {
+			return "", tr.fail(e, "unsupported literal")
+		}
+		return tr.param(v), nil
+	case celast.CallKind:
+		c := e.AsCall()
+		args := c.Args()
+		switch c.FunctionName() {
+		case "timestamp":
+			s, ok := stringLiteral(args[0])
+			if !ok {
+				return "", tr.fail(args[0], "timestamp() argument must be a string literal")
+			}
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return "", tr.fail(args[0], "invalid timestamp %q: must be RFC 3339", s)
+			}
+			return tr.param(t), nil
+		case "_+_", "_-_":
+			if tr.a.GetType(args[0].ID()).Kind() != types.TimestampKind {
+				break
+			}
+			d, err := tr.duration(args[1])
+			if err != nil {
+				return "", err
+			}
+			t, err := tr.value(args[0])
+			if err != nil {
+				return "", err
+			}
+			op := " + "
+			if c.FunctionName() == "_-_" {
+				op = " - "
+			}
+			return "(" + t + op + "CAST(" + tr.param(fmt.Sprintf("%d microseconds", d.Microseconds())) + " AS interval))", nil
+		}
+		return "", tr.fail(e, "function %s is not supported in filters", displayName(c.FunctionName()))
+	}
+	return "", tr.fail(e, "unsupported expression")
+}
+
+func (tr *translator) duration(e celast.Expr) (time.Duration, error) {
+	if e.Kind() == celast.CallKind && e.AsCall().FunctionName() == "duration" {
+		if s, ok := stringLiteral(e.AsCall().Args()[0]); ok {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return 0, tr.fail(e, "invalid duration %q", s)
+			}
+			return d, nil
+		}
+	}
+	return 0, tr.fail(e, "expected duration(\"…\") with a string literal, e.g. duration(\"48h\")")
+}
+
+func literal(e celast.Expr) (interface{}, bool) {
+	if e.Kind() != celast.LiteralKind {
+		return nil, false
+	}
+	switch v := e.AsLiteral().(type) {
+	case types.String:
+		return string(v), true
+	case types.Int:
+		return int64(v), true
+	case types.Bool:
+		return bool(v), true
+	}
+	return nil, false
+}
+
+func stringLiteral(e celast.Expr) (string, bool) {
+	v, ok := literal(e)
+	s, isString := v.(string)
+	return s, ok && isString
+}
+
+func isNull(e celast.Expr) bool {
+	if e.Kind() != celast.LiteralKind {
+		return false
+	}
+	_, ok := e.AsLiteral().(types.Null)
+	return ok
+}
+
+// displayName — оператор CEL в записи пользователя (_+_ → +).
+func displayName(fn string) string {
+	if strings.HasPrefix(fn, "_") && strings.HasSuffix(fn, "_") && len(fn) > 2 {
+		return strings.Trim(fn, "_")
+	}
+	return fn
+}
+
+// EscapeLike экранирует спецсимволы LIKE, чтобы подстрока искалась буквально
+// (выражения CEL и ListFilter.SubjectContains).
+func EscapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
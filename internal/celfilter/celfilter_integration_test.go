@@ -0,0 +1,87 @@
+//go:build integration
+
+package celfilter_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/psds-microservice/ticket-service/internal/celfilter"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+	"github.com/psds-microservice/ticket-service/internal/testdb"
+)
+
+// TestSQLMatchesParity проверяет, что SQL-условие (ListTickets) и Matches (WatchTickets)
+// отбирают одни и те же тикеты.
+func TestSQLMatchesParity(t *testing.T) {
+	db := testdb.Open(t)
+	ctx := tenant.WithID(context.Background(), "acme")
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	at := func(d time.Duration) *time.Time { v := now.Add(d); return &v }
+
+	seed := []model.Ticket{
+		{Status: model.TicketStatusOpen, Priority: "high", Region: "eu", ClientID: "client-1", Subject: "VPN 50%_off drops", CreatedAt: now.Add(-72 * time.Hour)},
+		{Status: model.TicketStatusInProgress, Priority: "urgent", Region: "eu", ClientID: "client-1", OperatorID: "op-1", Subject: "vpn slow", CreatedAt: now.Add(-3 * time.Hour)},
+		{Status: model.TicketStatusClosed, Priority: "low", Region: "us", ClientID: "client-2", OperatorID: "op-2", Subject: `C:\tmp is full`, LegalHold: true, CreatedAt: now.Add(-2 * time.Hour), ClosedAt: at(-time.Hour)},
+		{Status: model.TicketStatusMerged, ClientID: "client-3", CreatedAt: now.Add(-10 * time.Minute), ClosedAt: at(-5 * time.Minute)},
+	}
+	for i := range seed {
+		seed[i].Reference = fmt.Sprintf("T-%d", i+1)
+		seed[i].SessionID = fmt.Sprintf("session-%d", i+1)
+		if err := db.WithContext(ctx).Create(&seed[i]).Error; err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+	var tickets []model.Ticket
+	if err := db.WithContext(ctx).Order("id").Find(&tickets).Error; err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	for _, src := range []string{
+		`true`,
+		`status == "open"`,
+		`status in ["open", "in_progress"] && region == "eu"`,
+		`status in []`,
+		`!legal_hold || priority in ["high", "urgent"]`,
+		`closed_at == null`,
+		`closed_at != null && closed_at > created_at + duration("30m")`,
+		`closed_at < now`,
+		`!(closed_at < now)`,
+		`closed_at < now || region == "eu"`,
+		`created_at < now - duration("48h")`,
+		`created_at >= timestamp("2000-01-01T00:00:00Z") && updated_at <= now`,
+		`subject.contains("50%_off")`,
+		`subject.contains("50%")`,
+		`subject.contains("VPN")`,
+		`subject.startsWith("C:\\tmp")`,
+		`subject.endsWith("drops")`,
+		`operator_id == ""`,
+		`priority != "low"`,
+		`reference.startsWith("T-") && id > 1`,
+	} {
+		t.Run(src, func(t *testing.T) {
+			f, err := celfilter.Compile(src)
+			if err != nil {
+				t.Fatalf("Compile: %v", err)
+			}
+			sql, args := f.SQL()
+			var fromSQL []uint64
+			if err := db.WithContext(ctx).Model(&model.Ticket{}).Where(sql, args...).Order("id").Pluck("id", &fromSQL).Error; err != nil {
+				t.Fatalf("query %s: %v", sql, err)
+			}
+			var fromMatches []uint64
+			for i := range tickets {
+				if f.Matches(&tickets[i], time.Now()) {
+					fromMatches = append(fromMatches, tickets[i].ID)
+				}
+			}
+			if !slices.Equal(fromSQL, fromMatches) {
+				t.Errorf("SQL %s selects %v, Matches selects %v", sql, fromSQL, fromMatches)
+			}
+		})
+	}
+}
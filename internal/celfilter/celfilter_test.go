@@ -0,0 +1,184 @@
+package celfilter
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/psds-microservice/ticket-service/internal/model"
+)
+
+func TestCompileSQL(t *testing.T) {
+	tests := []struct {
+		src      string
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{`status == "open"`, "status = ?", []interface{}{"open"}},
+		{`status != "closed" && legal_hold`, "(status <> ? AND legal_hold)", []interface{}{"closed"}},
+		{`!legal_hold || priority in ["high", "urgent"]`, "(NOT (legal_hold) OR priority IN ?)", []interface{}{[]interface{}{"high", "urgent"}}},
+		{`status in []`, "FALSE", nil},
+		{`true`, "TRUE", nil},
+		{`false || legal_hold`, "(FALSE OR legal_hold)", nil},
+		{`closed_at == null`, "closed_at IS NULL", nil},
+		{`null != closed_at`, "closed_at IS NOT NULL", nil},
+		{`id > 10 && id <= 20`, "(id > ? AND id <= ?)", []interface{}{int64(10), int64(20)}},
+		{`subject.contains("50%_off")`, "subject LIKE ?", []interface{}{`%50\%\_off%`}},
+		{`reference.startsWith("EU-")`, "reference LIKE ?", []interface{}{"EU-%"}},
+		{`client_id.endsWith("\\x")`, "client_id LIKE ?", []interface{}{`%\\x`}},
+		{`created_at >= timestamp("2026-01-02T03:04:05Z")`, "created_at >= ?", []interface{}{time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}},
+		{`updated_at < now - duration("1h30m")`, "updated_at < (NOW() - CAST(? AS interval))", []interface{}{"5400000000 microseconds"}},
+		{`closed_at > created_at + duration("90s")`, "closed_at > (created_at + CAST(? AS interval))", []interface{}{"90000000 microseconds"}},
+		{`now < created_at`, "NOW() < created_at", nil},
+		{
+			`status in ["open", "in_progress"] && region == "eu" && created_at < now - duration("48h")`,
+			"((status IN ? AND region = ?) AND created_at < (NOW() - CAST(? AS interval)))",
+			[]interface{}{[]interface{}{"open", "in_progress"}, "eu", "172800000000 microseconds"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			f, err := Compile(tt.src)
+			if err != nil {
+				t.Fatalf("Compile: %v", err)
+			}
+			sql, args := f.SQL()
+			if sql != tt.wantSQL {
+				t.Errorf("SQL = %q, want %q", sql, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %#v, want %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		src          string
+		line, column int
+		msg          string
+	}{
+		{`region < "b"`, 1, 8, "ordering comparison is only supported"},
+		{`legal_hold > false`, 1, 12, "ordering comparison is only supported"},
+		{`closed_at < null`, 1, 11, "null can only be compared with == or !="},
+		{`status in ["open", region]`, 1, 20, "list elements must be literals"},
+		{`status in tags`, 1, 11, "undeclared reference to 'tags'"},
+		{`unknown == 1`, 1, 1, "undeclared reference to 'unknown'"},
+		{`[1, 2].exists(x, x == id)`, 1, 14, "macros all, exists"},
+		{`size(subject) > 3`, 1, 5, "function size is not supported"},
+		{`status == `, 1, 11, "Syntax error"},
+		{`id`, 1, 1, "expression must be bool"},
+		{"status == \"open\" &&\n  region.matches(\"e.*\")", 2, 17, "function matches is not supported"},
+		{`subject.contains(region)`, 1, 18, "contains() argument must be a string literal"},
+		{`created_at < timestamp("yesterday")`, 1, 24, "must be RFC 3339"},
+		{`created_at < now - duration("2d")`, 1, 28, `invalid duration "2d"`},
+		{`created_at < now - duration(region)`, 1, 28, "with a string literal"},
+		{strings.Repeat(" ", MaxLength) + "true", 1, 1, "longer than"},
+	}
+	for _, tt := range tests {
+		name := tt.src
+		if len(name) > 40 {
+			name = name[:40]
+		}
+		t.Run(name, func(t *testing.T) {
+			_, err := Compile(tt.src)
+			var fe *Error
+			if !errors.As(err, &fe) {
+				t.Fatalf("Compile error = %v, want *Error", err)
+			}
+			if fe.Line != tt.line || fe.Column != tt.column {
+				t.Errorf("position = %d:%d, want %d:%d (%v)", fe.Line, fe.Column, tt.line, tt.column, err)
+			}
+			if !strings.Contains(fe.Msg, tt.msg) {
+				t.Errorf("message = %q, want it to contain %q", fe.Msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestUsesSubject(t *testing.T) {
+	for src, want := range map[string]bool{
+		`subject.contains("vpn")`:                  true,
+		`status == "open" || subject == "x"`:       true,
+		`status == "open" && reference != "EU-1"`:  false,
+		`client_id.startsWith("c") && !legal_hold`: false,
+	} {
+		f, err := Compile(src)
+		if err != nil {
+			t.Fatalf("Compile(%q): %v", src, err)
+		}
+		if f.UsesSubject() != want {
+			t.Errorf("UsesSubject(%q) = %t, want %t", src, f.UsesSubject(), want)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	closedAt := now.Add(-time.Hour)
+	open := &model.Ticket{
+		ID: 15, Reference: "EU-2026-000015", ClientID: "client-1", Status: model.TicketStatusOpen,
+		Priority: "high", Region: "eu", Subject: "VPN 50%_off drops",
+		CreatedAt: now.Add(-72 * time.Hour), UpdatedAt: now.Add(-30 * time.Minute),
+	}
+	closed := &model.Ticket{
+		ID: 30, Reference: "US-2026-000030", ClientID: "client-2", Status: model.TicketStatusClosed,
+		Priority: "low", Region: "us", Subject: "Invoice", LegalHold: true,
+		CreatedAt: now.Add(-2 * time.Hour), UpdatedAt: closedAt, ClosedAt: &closedAt,
+	}
+	tests := []struct {
+		src          string
+		open, closed bool
+	}{
+		{`status == "open"`, true, false},
+		{`status != "closed" && legal_hold`, false, false},
+		{`!legal_hold || priority in ["high", "urgent"]`, true, false},
+		{`status in []`, false, false},
+		{`closed_at == null`, true, false},
+		{`closed_at != null`, false, true},
+		{`id > 10 && id <= 20`, true, false},
+		{`subject.contains("50%_off")`, true, false},
+		{`subject.contains("50%")`, true, false},
+		{`reference.startsWith("EU-")`, true, false},
+		{`client_id.endsWith("-2")`, false, true},
+		{`created_at >= timestamp("2026-10-17T00:00:00Z")`, false, true},
+		{`updated_at < now - duration("1h30m")`, false, false},
+		{`created_at < now - duration("48h")`, true, false},
+		{`closed_at > created_at + duration("30m")`, false, true},
+		// closed_at пустой: сравнение — ошибка, как NULL в SQL, тикет не подходит.
+		{`closed_at < now`, false, true},
+		{`!(closed_at < now)`, false, false},
+		{`closed_at < now || region == "eu"`, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			f, err := Compile(tt.src)
+			if err != nil {
+				t.Fatalf("Compile: %v", err)
+			}
+			if got := f.Matches(open, now); got != tt.open {
+				t.Errorf("Matches(open) = %t, want %t", got, tt.open)
+			}
+			if got := f.Matches(closed, now); got != tt.closed {
+				t.Errorf("Matches(closed) = %t, want %t", got, tt.closed)
+			}
+		})
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	for in, want := range map[string]string{
+		"plain":  "plain",
+		"50%":    `50\%`,
+		"a_b":    `a\_b`,
+		`c:\tmp`: `c:\\tmp`,
+		`%_\`:    `\%\_\\`,
+	} {
+		if got := EscapeLike(in); got != want {
+			t.Errorf("EscapeLike(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Package changefeed — лента изменений тикетов между репликами через Postgres LISTEN/NOTIFY.
+// После коммита реплика публикует ID изменённого тикета через pg_notify, а Listener на каждой
+// реплике перечитывает тикет и раздаёт его локальным подписчикам (WatchTickets и т.п.).
+// Kafka для этого не нужна: лента работает при любой конфигурации KAFKA_BROKERS.
+package changefeed
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/lib/pq"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+	"gorm.io/gorm"
+)
+
+// Channel — канал LISTEN/NOTIFY с изменениями тикетов.
+const Channel = "ticket_changes"
+
+// Change — уведомление об изменении: только идентификаторы, сам тикет получатель читает из БД
+// (payload pg_notify ограничен 8000 байт, а subject/notes не должны уходить из таблицы).
+type Change struct {
+	// Replica — кто изменил тикет; своя реплика уведомление пропускает (подписчики уже получили событие).
+	Replica  string `json:"replica"`
+	Event    string `json:"event"`
+	TenantID string `json:"tenant_id"`
+	TicketID uint64 `json:"ticket_id"`
+}
+
+// NewReplicaID — идентификатор процесса для отсечения собственных уведомлений.
+func NewReplicaID() string {
+	host, _ := os.Hostname()
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(b))
+}
+
+// Notifier публикует изменения в Channel. Методы безопасны для nil (no-op).
+type Notifier struct {
+	db      *gorm.DB
+	replica string
+}
+
+func NewNotifier(db *gorm.DB, replica string) *Notifier {
+	return &Notifier{db: db, replica: replica}
+}
+
+// Notify отправляет уведомление об изменении тикета. Вызывать после коммита изменения:
+// pg_notify выполняется в собственной транзакции. Ошибка только логируется (best-effort,
+// реплики догонят пропуск по updated_at при переподключении).
+func (n *Notifier) Notify(ctx context.Context, event string, t *model.Ticket) {
+	if n == nil || t == nil {
+		return
+	}
+	body, err := json.Marshal(Change{Replica: n.replica, Event: event, TenantID: t.TenantID, TicketID: t.ID})
+	if err != nil {
+		log.Printf("changefeed: marshal: %v", err)
+		return
+	}
+	if err := n.db.WithContext(tenant.WithSystem(ctx)).Exec("SELECT pg_notify(?, ?)", Channel, string(body)).Error; err != nil {
+		log.Printf("changefeed: notify ticket %d: %v", t.ID, err)
+	}
+}
+
+// NotifyAll — Notify для нескольких тикетов одним запросом.
+func (n *Notifier) NotifyAll(ctx context.Context, event string, tickets []model.Ticket) {
+	if n == nil || len(tickets) == 0 {
+		return
+	}
+	bodies := make(pq.StringArray, 0, len(tickets))
+	for i := range tickets {
+		body, err := json.Marshal(Change{Replica: n.replica, Event: event, TenantID: tickets[i].TenantID, TicketID: tickets[i].ID})
+		if err != nil {
+			log.Printf("changefeed: marshal: %v", err)
+			continue
+		}
+		bodies = append(bodies, string(body))
+	}
+	if err := n.db.WithContext(tenant.WithSystem(ctx)).
+		Exec("SELECT pg_notify(?, body) FROM unnest(?::text[]) AS body", Channel, bodies).Error; err != nil {
+		log.Printf("changefeed: notify %d tickets: %v", len(bodies), err)
+	}
+}
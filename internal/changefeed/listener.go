@@ -0,0 +1,169 @@
+package changefeed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/psds-microservice/ticket-service/internal/errs"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/internal/service"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+)
+
+const (
+	// catchUpOverlap — запас при догоняющем чтении: транзакция, начатая раньше, могла
+	// зафиксироваться позже (updated_at — время начала изменения, а не коммита).
+	catchUpOverlap = 10 * time.Second
+	catchUpBatch   = 500
+	// pingInterval — проверка LISTEN-соединения: без трафика обрыв иначе не заметить.
+	pingInterval = time.Minute
+)
+
+// Source — чтение тикетов для уведомлений и догоняющего чтения (реализация — service.TicketService).
+type Source interface {
+	GetByIDWithDeleted(ctx context.Context, id uint64) (*model.Ticket, error)
+	ChangedSince(ctx context.Context, since time.Time, afterID uint64, limit int) ([]model.Ticket, error)
+}
+
+// Handler получает изменённый тикет. event — как в Kafka (ticket.created, ticket.updated, ...).
+type Handler func(event string, t *model.Ticket)
+
+// Listener слушает Channel и раздаёт изменения других реплик зарегистрированным Handler.
+type Listener struct {
+	dsn     string
+	replica string
+	source  Source
+
+	mu       sync.Mutex
+	handlers []Handler
+	// since — позиция догоняющего чтения: последнее изменение, о котором узнали подписчики.
+	since time.Time
+}
+
+// NewListener: dsn — строка подключения lib/pq (LISTEN требует прямого соединения, без
+// транзакционного пулера), replica — тот же ID, что у Notifier этого процесса.
+func NewListener(dsn, replica string, source Source) *Listener {
+	return &Listener{dsn: dsn, replica: replica, source: source}
+}
+
+// Handle регистрирует получателя изменений (вызывать до Run).
+func (l *Listener) Handle(h Handler) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.handlers = append(l.handlers, h)
+}
+
+func (l *Listener) dispatch(event string, t *model.Ticket) {
+	l.mu.Lock()
+	handlers := l.handlers
+	if at := service.ChangedAt(t); at.After(l.since) {
+		l.since = at
+	}
+	l.mu.Unlock()
+	for _, h := range handlers {
+		h(event, t)
+	}
+}
+
+// Run слушает канал до отмены ctx. После переподключения изменения за время обрыва
+// дочитываются по updated_at (уведомления, отправленные без слушателя, Postgres не хранит).
+func (l *Listener) Run(ctx context.Context) error {
+	l.mu.Lock()
+	l.since = time.Now().Add(-catchUpOverlap)
+	l.mu.Unlock()
+
+	pl := pq.NewListener(l.dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("changefeed: listener: %v", err)
+		}
+	})
+	defer pl.Close()
+	if err := pl.Listen(Channel); err != nil {
+		return err
+	}
+	log.Printf("changefeed: listening on %q as replica %s", Channel, l.replica)
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-pl.Notify:
+			if n == nil {
+				// lib/pq присылает nil после восстановления соединения.
+				l.catchUp(ctx)
+				continue
+			}
+			l.handleNotification(ctx, n.Extra)
+		case <-ticker.C:
+			go func() {
+				if err := pl.Ping(); err != nil {
+					log.Printf("changefeed: ping: %v", err)
+				}
+			}()
+		}
+	}
+}
+
+func (l *Listener) handleNotification(ctx context.Context, payload string) {
+	var c Change
+	if err := json.Unmarshal([]byte(payload), &c); err != nil {
+		log.Printf("changefeed: bad payload %q: %v", payload, err)
+		return
+	}
+	if c.Replica == l.replica {
+		return
+	}
+	t, err := l.source.GetByIDWithDeleted(tenant.WithID(ctx, c.TenantID), c.TicketID)
+	if errors.Is(err, errs.ErrTicketNotFound) {
+		// Удалён безвозвратно (retention): подписчикам хватает ID и тенанта.
+		t, err = &model.Ticket{ID: c.TicketID, TenantID: c.TenantID}, nil
+	}
+	if err != nil {
+		log.Printf("changefeed: load ticket %d: %v", c.TicketID, err)
+		return
+	}
+	l.dispatch(c.Event, t)
+}
+
+// catchUp раздаёт тикеты, изменённые с последнего известного изменения (минус запас).
+// Изменения своей реплики здесь не отличить от чужих — подписчики получают их повторно.
+func (l *Listener) catchUp(ctx context.Context) {
+	l.mu.Lock()
+	from := l.since.Add(-catchUpOverlap)
+	l.mu.Unlock()
+	since, afterID := from, uint64(0)
+	sysCtx := tenant.WithSystem(ctx)
+	total := 0
+	for {
+		tickets, err := l.source.ChangedSince(sysCtx, since, afterID, catchUpBatch)
+		if err != nil {
+			log.Printf("changefeed: catch-up: %v", err)
+			return
+		}
+		for i := range tickets {
+			t := &tickets[i]
+			event := "ticket.updated"
+			switch {
+			case t.DeletedAt.Valid:
+				event = "ticket.deleted"
+			case t.CreatedAt.After(from):
+				event = "ticket.created"
+			}
+			l.dispatch(event, t)
+		}
+		total += len(tickets)
+		if len(tickets) < catchUpBatch {
+			break
+		}
+		last := &tickets[len(tickets)-1]
+		since, afterID = service.ChangedAt(last), last.ID
+	}
+	log.Printf("changefeed: reconnected, caught up %d changed tickets", total)
+}
@@ -1,13 +1,21 @@
 package config
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net/url"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/psds-microservice/ticket-service/internal/ratelimit"
+	"github.com/psds-microservice/ticket-service/internal/redact"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+	"github.com/psds-microservice/ticket-service/internal/tlsconfig"
 )
 
 type Config struct {
@@ -24,6 +32,65 @@ type Config struct {
 	// KafkaTopicTicket — топик для событий тикетов (по умолчанию psds.ticket.events).
 	KafkaTopicTicket string
 
+	// SessionDedupPolicy — повторный CreateTicket для сессии с незакрытым тикетом:
+	// reject (по умолчанию), return_existing или allow.
+	SessionDedupPolicy string
+
+	// TicketRefPrefixes — префиксы номеров тикетов по регионам (TICKET_REF_PREFIXES="eu=EU,us=US").
+	TicketRefPrefixes map[string]string
+	// TicketRefDefaultPrefix — префикс для остальных регионов (по умолчанию TK).
+	TicketRefDefaultPrefix string
+
+	// DefaultTenantID — тенант запросов без x-tenant-id и без тенанта у principal.
+	// Пустое значение (DEFAULT_TENANT_ID=) — x-tenant-id обязателен.
+	DefaultTenantID string
+
+	// EncryptionKeyfile — файл мастер-ключей для шифрования subject/notes (пусто — шифрование выключено).
+	EncryptionKeyfile string
+
+	// RedactKafkaMode и RedactSearchMode — режим редактирования PII (off, mask, hash, drop)
+	// в subject/notes событий Kafka и документов search-service.
+	RedactKafkaMode  string
+	RedactSearchMode string
+	// RedactDetectors — включённые детекторы (card, email, phone, iban); пусто — все.
+	RedactDetectors []string
+	// RedactHashKey — ключ HMAC для режима hash.
+	RedactHashKey string
+
+	// RateLimitDefault — лимит вызовов по умолчанию "rate:burst" на вызывающего и на client_id
+	// для каждого метода ("off" — без ограничений).
+	RateLimitDefault string
+	// RateLimitMethods — лимиты отдельных методов: "CreateTicket=1:10,ListTickets=20:40".
+	RateLimitMethods string
+	// TicketCreateDailyQuota — сколько тикетов клиент (аутентифицированный principal, иначе client_id
+	// запроса) может создать за сутки UTC (0 — без квоты).
+	TicketCreateDailyQuota int
+	// RateLimitStore — memory (у каждой реплики своё состояние) или postgres (общее).
+	RateLimitStore string
+
+	// TLSCertFile/TLSKeyFile — сертификат сервера для gRPC и HTTP (пусто — без TLS).
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSClientCAFile — CA для проверки клиентских сертификатов (mTLS).
+	TLSClientCAFile string
+	// TLSClientAuth — none, optional или require.
+	TLSClientAuth string
+	// TLSPrincipalFromCert — идентичность клиентского сертификата (SAN/CN) становится principal.
+	TLSPrincipalFromCert bool
+	// TLSReloadInterval — как часто проверять, не изменились ли файлы сертификатов.
+	TLSReloadInterval time.Duration
+
+	// ListDefaultLimit/ListMaxLimit — размер страницы ListTickets без limit и его максимум.
+	ListDefaultLimit int
+	ListMaxLimit     int
+	// ListPageTokenKey — ключ HMAC для page_token ListTickets; одинаковый на всех репликах
+	// (пусто — случайный ключ процесса).
+	ListPageTokenKey string
+
+	// ChangeFeedEnabled — обмен изменениями тикетов между репликами через LISTEN/NOTIFY
+	// (WatchTickets видит изменения, сделанные на других репликах и в CLI).
+	ChangeFeedEnabled bool
+
 	DB struct {
 		Host     string
 		Port     string
@@ -47,7 +114,65 @@ func Load() (*Config, error) {
 		LogLevel:         getEnv("LOG_LEVEL", "info"),
 		SearchServiceURL: getEnv("SEARCH_SERVICE_URL", ""),
 		KafkaTopicTicket: getEnv("KAFKA_TOPIC_TICKET", "psds.ticket.events"),
+
+		SessionDedupPolicy: getEnv("TICKET_SESSION_DEDUP_POLICY", "reject"),
+
+		TicketRefDefaultPrefix: strings.ToUpper(getEnv("TICKET_REF_DEFAULT_PREFIX", "TK")),
+
+		DefaultTenantID: lookupEnv("DEFAULT_TENANT_ID", tenant.DefaultID),
+
+		EncryptionKeyfile: getEnv("ENCRYPTION_KEYFILE", ""),
+
+		RateLimitDefault: getEnv("RATE_LIMIT_DEFAULT", "50:100"),
+		RateLimitMethods: getEnv("RATE_LIMIT_METHODS", "CreateTicket=2:20"),
+		RateLimitStore:   getEnv("RATE_LIMIT_STORE", "memory"),
+
+		TLSCertFile:          getEnv("TLS_CERT_FILE", ""),
+		TLSKeyFile:           getEnv("TLS_KEY_FILE", ""),
+		TLSClientCAFile:      getEnv("TLS_CLIENT_CA_FILE", ""),
+		TLSClientAuth:        getEnv("TLS_CLIENT_AUTH", "none"),
+		TLSPrincipalFromCert: getEnv("TLS_PRINCIPAL_FROM_CERT", "false") == "true",
+
+		ChangeFeedEnabled: getEnv("CHANGEFEED_ENABLED", "true") == "true",
+
+		ListPageTokenKey: getEnv("LIST_PAGE_TOKEN_KEY", ""),
+
+		RedactKafkaMode:  getEnv("REDACT_KAFKA_MODE", string(redact.ModeMask)),
+		RedactSearchMode: getEnv("REDACT_SEARCH_MODE", string(redact.ModeMask)),
+		RedactHashKey:    getEnv("REDACT_HASH_KEY", ""),
+	}
+	cfg.TicketRefPrefixes = parseKeyValues(getEnv("TICKET_REF_PREFIXES", ""), strings.ToUpper)
+	for _, d := range strings.Split(getEnv("REDACT_DETECTORS", ""), ",") {
+		if d = strings.TrimSpace(d); d != "" {
+			cfg.RedactDetectors = append(cfg.RedactDetectors, d)
+		}
+	}
+	if v := getEnv("TICKET_CREATE_DAILY_QUOTA", ""); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("config: TICKET_CREATE_DAILY_QUOTA: %w", err)
+		}
+		cfg.TicketCreateDailyQuota = n
 	}
+	for _, v := range []struct {
+		name string
+		dst  *int
+		def  string
+	}{
+		{"LIST_DEFAULT_LIMIT", &cfg.ListDefaultLimit, "50"},
+		{"LIST_MAX_LIMIT", &cfg.ListMaxLimit, "500"},
+	} {
+		n, err := strconv.Atoi(getEnv(v.name, v.def))
+		if err != nil {
+			return nil, fmt.Errorf("config: %s: %w", v.name, err)
+		}
+		*v.dst = n
+	}
+	reload, err := time.ParseDuration(getEnv("TLS_RELOAD_INTERVAL", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("config: TLS_RELOAD_INTERVAL: %w", err)
+	}
+	cfg.TLSReloadInterval = reload
 	if brokers := getEnv("KAFKA_BROKERS", ""); brokers != "" {
 		for _, s := range strings.Split(brokers, ",") {
 			if t := strings.TrimSpace(s); t != "" {
@@ -71,9 +196,110 @@ func (c *Config) Validate() error {
 	if c.AppEnv == "production" && c.DB.Password == "" {
 		return errors.New("config: in production DB_PASSWORD is required")
 	}
+	switch c.SessionDedupPolicy {
+	case "reject", "return_existing", "allow":
+	default:
+		return fmt.Errorf("config: TICKET_SESSION_DEDUP_POLICY must be reject, return_existing or allow, got %q", c.SessionDedupPolicy)
+	}
+	if !refPrefixRe.MatchString(c.TicketRefDefaultPrefix) {
+		return fmt.Errorf("config: TICKET_REF_DEFAULT_PREFIX must match %s, got %q", refPrefixRe, c.TicketRefDefaultPrefix)
+	}
+	for region, prefix := range c.TicketRefPrefixes {
+		if !refPrefixRe.MatchString(prefix) {
+			return fmt.Errorf("config: TICKET_REF_PREFIXES: prefix for %q must match %s, got %q", region, refPrefixRe, prefix)
+		}
+	}
+	if c.DefaultTenantID != "" && !tenant.Valid(c.DefaultTenantID) {
+		return fmt.Errorf("config: DEFAULT_TENANT_ID: invalid tenant id %q", c.DefaultTenantID)
+	}
+	for env, mode := range map[string]string{"REDACT_KAFKA_MODE": c.RedactKafkaMode, "REDACT_SEARCH_MODE": c.RedactSearchMode} {
+		m, err := redact.ParseMode(mode)
+		if err != nil {
+			return fmt.Errorf("config: %s: %w", env, err)
+		}
+		if m == redact.ModeHash && c.AppEnv == "production" && c.RedactHashKey == "" {
+			return fmt.Errorf("config: in production %s=hash requires REDACT_HASH_KEY", env)
+		}
+	}
+	if _, err := c.KafkaRedactor(); err != nil {
+		return fmt.Errorf("config: REDACT_DETECTORS: %w", err)
+	}
+	if _, err := c.RateLimitConfig(); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	if c.TicketCreateDailyQuota < 0 {
+		return fmt.Errorf("config: TICKET_CREATE_DAILY_QUOTA must be >= 0, got %d", c.TicketCreateDailyQuota)
+	}
+	switch c.RateLimitStore {
+	case "memory", "postgres":
+	default:
+		return fmt.Errorf("config: RATE_LIMIT_STORE must be memory or postgres, got %q", c.RateLimitStore)
+	}
+	if c.ListDefaultLimit <= 0 || c.ListMaxLimit < c.ListDefaultLimit {
+		return fmt.Errorf("config: need 0 < LIST_DEFAULT_LIMIT <= LIST_MAX_LIMIT, got %d and %d", c.ListDefaultLimit, c.ListMaxLimit)
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return errors.New("config: TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	clientAuth, err := tlsconfig.ParseClientAuth(c.TLSClientAuth)
+	if err != nil {
+		return fmt.Errorf("config: TLS_CLIENT_AUTH: %w", err)
+	}
+	if clientAuth != tls.NoClientCert && (!c.TLSEnabled() || c.TLSClientCAFile == "") {
+		return errors.New("config: TLS_CLIENT_AUTH requires TLS_CERT_FILE, TLS_KEY_FILE and TLS_CLIENT_CA_FILE")
+	}
+	if c.TLSPrincipalFromCert && clientAuth == tls.NoClientCert {
+		return errors.New("config: TLS_PRINCIPAL_FROM_CERT requires TLS_CLIENT_AUTH optional or require")
+	}
+	if c.TLSReloadInterval <= 0 {
+		return fmt.Errorf("config: TLS_RELOAD_INTERVAL must be positive, got %s", c.TLSReloadInterval)
+	}
 	return nil
 }
 
+// RateLimitConfig собирает лимиты из RATE_LIMIT_DEFAULT, RATE_LIMIT_METHODS и TICKET_CREATE_DAILY_QUOTA.
+func (c *Config) RateLimitConfig() (ratelimit.Config, error) {
+	out := ratelimit.Config{Methods: make(map[string]ratelimit.Limit), DailyCreateQuota: c.TicketCreateDailyQuota}
+	var err error
+	if out.Default, err = ratelimit.ParseLimit(c.RateLimitDefault); err != nil {
+		return out, fmt.Errorf("RATE_LIMIT_DEFAULT: %w", err)
+	}
+	for _, pair := range strings.Split(c.RateLimitMethods, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		method, limit, ok := strings.Cut(pair, "=")
+		if method = strings.TrimSpace(method); !ok || method == "" {
+			return out, fmt.Errorf("RATE_LIMIT_METHODS: %q: expected Method=rate:burst", pair)
+		}
+		if out.Methods[method], err = ratelimit.ParseLimit(limit); err != nil {
+			return out, fmt.Errorf("RATE_LIMIT_METHODS: %s: %w", method, err)
+		}
+	}
+	return out, nil
+}
+
+// KafkaRedactor — редактор PII для событий Kafka (nil при REDACT_KAFKA_MODE=off).
+func (c *Config) KafkaRedactor() (*redact.Redactor, error) {
+	return c.redactor(c.RedactKafkaMode)
+}
+
+// SearchRedactor — редактор PII для документов search-service (nil при REDACT_SEARCH_MODE=off).
+func (c *Config) SearchRedactor() (*redact.Redactor, error) {
+	return c.redactor(c.RedactSearchMode)
+}
+
+func (c *Config) redactor(mode string) (*redact.Redactor, error) {
+	m, err := redact.ParseMode(mode)
+	if err != nil {
+		return nil, err
+	}
+	return redact.New(redact.Config{Mode: m, Detectors: c.RedactDetectors, HashKey: c.RedactHashKey})
+}
+
+// TLSEnabled — listener'ы gRPC и HTTP работают по TLS.
+func (c *Config) TLSEnabled() bool { return c.TLSCertFile != "" }
+
 func (c *Config) DSN() string {
 	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		c.DB.Host, c.DB.Port, c.DB.User, c.DB.Password, c.DB.Database, c.DB.SSLMode)
@@ -89,6 +315,23 @@ func (c *Config) Addr() string {
 	return c.AppHost + ":" + c.HTTPPort
 }
 
+// refPrefixRe — допустимый префикс номера тикета.
+var refPrefixRe = regexp.MustCompile(`^[A-Z0-9]{1,8}$`)
+
+// parseKeyValues разбирает "k1=v1,k2=v2" в map; ключи приводятся к нижнему регистру, значения — через norm.
+func parseKeyValues(s string, norm func(string) string) map[string]string {
+	out := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		k, v = strings.ToLower(strings.TrimSpace(k)), strings.TrimSpace(v)
+		if !ok || k == "" || v == "" {
+			continue
+		}
+		out[k] = norm(v)
+	}
+	return out
+}
+
 func firstEnv(keysAndDef ...string) string {
 	if len(keysAndDef) == 0 {
 		return ""
@@ -102,6 +345,14 @@ func firstEnv(keysAndDef ...string) string {
 	return def
 }
 
+// lookupEnv как getEnv, но явно заданная пустая переменная возвращает пустую строку.
+func lookupEnv(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return strings.TrimSpace(v)
+	}
+	return def
+}
+
 func getEnv(key, def string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
@@ -1,10 +1,67 @@
 package database
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
+// dialector — postgres-диалект, который при переводе ошибок сохраняет исходную *pgconn.PgError:
+// по gorm.ErrDuplicatedKey не понять, какое ограничение нарушено (см. ViolatedConstraint).
+type dialector struct {
+	*postgres.Dialector
+}
+
+func (d dialector) Translate(err error) error {
+	translated := d.Dialector.Translate(err)
+	var pgErr *pgconn.PgError
+	if translated != err && errors.As(err, &pgErr) {
+		return fmt.Errorf("%w: %w", translated, err)
+	}
+	return translated
+}
+
+// ViolatedConstraint — имя ограничения или уникального индекса из ошибки Postgres ("" — не нарушение ограничения).
+func ViolatedConstraint(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.ConstraintName
+	}
+	return ""
+}
+
 func Open(dsn string) (*gorm.DB, error) {
-	return gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// TranslateError: ошибки Postgres (unique_violation и др.) приходят как gorm.ErrDuplicatedKey и т.п.,
+	// исходная ошибка остаётся в цепочке (ViolatedConstraint).
+	db, err := gorm.Open(dialector{postgres.Open(dsn).(*postgres.Dialector)}, &gorm.Config{
+		TranslateError: true,
+		// ParameterizedQueries: в логах SQL без значений параметров — subject/notes не попадают в лог.
+		Logger: logger.New(log.New(os.Stdout, "\r\n", log.LstdFlags), logger.Config{
+			SlowThreshold:             200 * time.Millisecond,
+			LogLevel:                  logger.Warn,
+			IgnoreRecordNotFoundError: false,
+			ParameterizedQueries:      true,
+			Colorful:                  true,
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Плагины тенантов подключаются всегда: запрос без тенанта в контексте не выполнится,
+	// а RLS в Postgres (migrate verify) не покажет чужие строки даже в raw SQL.
+	if err := db.Use(tenant.Plugin{}); err != nil {
+		return nil, err
+	}
+	if err := db.Use(tenant.RLS{}); err != nil {
+		return nil, err
+	}
+	return db, nil
 }
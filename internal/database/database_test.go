@@ -0,0 +1,29 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestTranslateKeepsConstraint(t *testing.T) {
+	d := dialector{&postgres.Dialector{}}
+	err := d.Translate(&pgconn.PgError{Code: "23505", ConstraintName: "ux_tickets_open_session"})
+	if !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Errorf("Translate(unique_violation) = %v, want gorm.ErrDuplicatedKey", err)
+	}
+	if got := ViolatedConstraint(err); got != "ux_tickets_open_session" {
+		t.Errorf("ViolatedConstraint = %q, want ux_tickets_open_session", got)
+	}
+
+	other := &pgconn.PgError{Code: "42P01"}
+	if err := d.Translate(other); err != other {
+		t.Errorf("Translate(undefined_table) = %v, want the error unchanged", err)
+	}
+	if got := ViolatedConstraint(errors.New("boom")); got != "" {
+		t.Errorf("ViolatedConstraint(non-Postgres error) = %q, want empty", got)
+	}
+}
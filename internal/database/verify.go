@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// RLSTables — таблицы с политикой tenant_isolation (миграция 000013).
+var RLSTables = []string{"tickets", "ticket_sessions", "ticket_links", "ticket_audit_log", "ticket_reference_counters"}
+
+// VerifyRLS проверяет, что row-level security действительно изолирует тенантов для роли
+// из databaseURL: RLS включён и принудителен на всех таблицах, политика на месте, роль не
+// обходит RLS, а запрос без app.tenant_id не видит ни одной строки.
+func VerifyRLS(ctx context.Context, databaseURL string) error {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return fmt.Errorf("open: %w", err)
+	}
+	defer db.Close()
+
+	var problems []string
+	var role string
+	var bypasses bool
+	if err := db.QueryRowContext(ctx,
+		"SELECT current_user, rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user").Scan(&role, &bypasses); err != nil {
+		return fmt.Errorf("check role: %w", err)
+	}
+	if bypasses {
+		problems = append(problems, fmt.Sprintf("role %q is superuser or has BYPASSRLS: policies do not apply to it", role))
+	}
+
+	for _, table := range RLSTables {
+		var enabled, forced bool
+		err := db.QueryRowContext(ctx,
+			"SELECT relrowsecurity, relforcerowsecurity FROM pg_class WHERE oid = to_regclass($1)", table).Scan(&enabled, &forced)
+		if errors.Is(err, sql.ErrNoRows) {
+			problems = append(problems, fmt.Sprintf("%s: table not found", table))
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("check %s: %w", table, err)
+		}
+		if !enabled || !forced {
+			problems = append(problems, fmt.Sprintf("%s: row level security enabled=%t forced=%t", table, enabled, forced))
+		}
+		var policies int
+		if err := db.QueryRowContext(ctx,
+			"SELECT COUNT(*) FROM pg_policies WHERE schemaname = current_schema() AND tablename = $1 AND policyname = 'tenant_isolation'",
+			table).Scan(&policies); err != nil {
+			return fmt.Errorf("check %s policy: %w", table, err)
+		}
+		if policies == 0 {
+			problems = append(problems, fmt.Sprintf("%s: policy tenant_isolation is missing", table))
+		}
+	}
+
+	// Проба: транзакция без тенанта не должна видеть строк.
+	if len(problems) == 0 {
+		leaks, err := probeWithoutTenant(ctx, db)
+		if err != nil {
+			return fmt.Errorf("probe: %w", err)
+		}
+		problems = append(problems, leaks...)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("row level security is not effective:\n  %s", strings.Join(problems, "\n  "))
+	}
+	log.Printf("verify: row level security active on %d tables for role %q", len(RLSTables), role)
+	return nil
+}
+
+func probeWithoutTenant(ctx context.Context, db *sql.DB) ([]string, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, "SELECT set_config('app.tenant_id', '', true), set_config('app.bypass_rls', 'off', true)"); err != nil {
+		return nil, err
+	}
+	var leaks []string
+	for _, table := range RLSTables {
+		var n int64
+		// Имя таблицы из фиксированного списка RLSTables.
+		if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table).Scan(&n); err != nil {
+			return nil, fmt.Errorf("%s: %w", table, err)
+		}
+		if n > 0 {
+			leaks = append(leaks, fmt.Sprintf("%s: %d rows visible without a tenant", table, n))
+		}
+	}
+	return leaks, nil
+}
@@ -0,0 +1,169 @@
+// Package encryption — шифрование полей на уровне приложения (envelope encryption):
+// значение шифруется data key (AES-256-GCM), data key оборачивается мастер-ключом KeyProvider.
+package encryption
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Формат зашифрованного значения: enc:v1:<id мастер-ключа>:<обёрнутый data key>:<nonce||ciphertext>.
+const valuePrefix = "enc:v1:"
+
+const (
+	// dataKeyTTL и dataKeyMaxUses ограничивают жизнь data key для новых значений:
+	// реже ходим в KeyProvider (KMS) и не исчерпываем пространство случайных nonce.
+	dataKeyTTL     = time.Hour
+	dataKeyMaxUses = 1 << 20
+	// unwrapCacheSize — сколько расшифрованных data keys держим в памяти.
+	unwrapCacheSize = 1024
+)
+
+var b64 = base64.RawStdEncoding
+
+type dataKey struct {
+	keyID   string
+	wrapped string
+	aead    cipher.AEAD
+	created time.Time
+	uses    int
+}
+
+// Cipher шифрует и расшифровывает строковые значения. Значения без префикса enc:v1:
+// считаются открытым текстом (строки, записанные до включения шифрования) и возвращаются как есть.
+type Cipher struct {
+	keys KeyProvider
+
+	mu        sync.Mutex
+	current   *dataKey
+	unwrapped map[string]cipher.AEAD
+}
+
+func NewCipher(keys KeyProvider) *Cipher {
+	return &Cipher{keys: keys, unwrapped: make(map[string]cipher.AEAD)}
+}
+
+// IsEncrypted сообщает, зашифровано ли значение.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, valuePrefix)
+}
+
+// Encrypt шифрует значение. Пустая строка остаётся пустой.
+func (c *Cipher) Encrypt(ctx context.Context, plaintext string) (string, error) {
+	if plaintext == "" {
+		return plaintext, nil
+	}
+	dk, err := c.dataKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	payload, err := seal(dk.aead, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return valuePrefix + dk.keyID + ":" + dk.wrapped + ":" + b64.EncodeToString(payload), nil
+}
+
+// Decrypt расшифровывает значение; открытый текст возвращается как есть.
+func (c *Cipher) Decrypt(ctx context.Context, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	keyID, wrapped, payload, err := parseValue(value)
+	if err != nil {
+		return "", err
+	}
+	aead, err := c.unwrap(ctx, keyID, wrapped)
+	if err != nil {
+		return "", err
+	}
+	raw, err := b64.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("encryption: malformed value: %w", err)
+	}
+	plaintext, err := open(aead, raw)
+	if err != nil {
+		return "", fmt.Errorf("encryption: decrypt with master key %q: %w", keyID, err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation: значение хранится открытым текстом или зашифровано не основным мастер-ключом.
+func (c *Cipher) NeedsRotation(value string) bool {
+	if value == "" {
+		return false
+	}
+	if !IsEncrypted(value) {
+		return true
+	}
+	keyID, _, _, err := parseValue(value)
+	return err != nil || keyID != c.keys.PrimaryKeyID()
+}
+
+func parseValue(value string) (keyID, wrapped, payload string, err error) {
+	parts := strings.Split(strings.TrimPrefix(value, valuePrefix), ":")
+	if len(parts) != 3 {
+		return "", "", "", errors.New("encryption: malformed value")
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+// dataKey возвращает текущий data key, при необходимости создавая новый под основным мастер-ключом.
+func (c *Cipher) dataKey(ctx context.Context) (*dataKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	primary := c.keys.PrimaryKeyID()
+	if dk := c.current; dk != nil && dk.keyID == primary && dk.uses < dataKeyMaxUses && time.Since(dk.created) < dataKeyTTL {
+		dk.uses++
+		return dk, nil
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	wrapped, err := c.keys.WrapKey(ctx, primary, key)
+	if err != nil {
+		return nil, fmt.Errorf("encryption: wrap data key: %w", err)
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	c.current = &dataKey{keyID: primary, wrapped: b64.EncodeToString(wrapped), aead: aead, created: time.Now(), uses: 1}
+	return c.current, nil
+}
+
+func (c *Cipher) unwrap(ctx context.Context, keyID, wrapped string) (cipher.AEAD, error) {
+	cacheKey := keyID + ":" + wrapped
+	c.mu.Lock()
+	aead, ok := c.unwrapped[cacheKey]
+	c.mu.Unlock()
+	if ok {
+		return aead, nil
+	}
+	raw, err := b64.DecodeString(wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("encryption: malformed data key: %w", err)
+	}
+	key, err := c.keys.UnwrapKey(ctx, keyID, raw)
+	if err != nil {
+		return nil, fmt.Errorf("encryption: unwrap data key: %w", err)
+	}
+	if aead, err = newGCM(key); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if len(c.unwrapped) >= unwrapCacheSize {
+		c.unwrapped = make(map[string]cipher.AEAD)
+	}
+	c.unwrapped[cacheKey] = aead
+	c.mu.Unlock()
+	return aead, nil
+}
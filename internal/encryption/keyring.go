@@ -0,0 +1,163 @@
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// KeyProvider оборачивает data keys мастер-ключом. Реализации: LocalKeyring (ключи из файла)
+// или обёртка над KMS — мастер-ключ при этом не покидает KMS.
+type KeyProvider interface {
+	// PrimaryKeyID — мастер-ключ, которым оборачиваются новые data keys.
+	PrimaryKeyID() string
+	WrapKey(ctx context.Context, keyID string, dek []byte) ([]byte, error)
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// Keyfile — формат файла мастер-ключей:
+//
+//	{"primary": "k2", "keys": {"k1": "<base64, 32 байта>", "k2": "<base64, 32 байта>"}}
+//
+// Старые ключи остаются в файле, пока `ticket-service keys rotate` не перешифрует данные.
+type Keyfile struct {
+	Primary string            `json:"primary"`
+	Keys    map[string]string `json:"keys"`
+}
+
+// LocalKeyring — мастер-ключи AES-256 из локального файла.
+type LocalKeyring struct {
+	primary string
+	keys    map[string]cipher.AEAD
+}
+
+// ReadKeyfile читает и проверяет файл мастер-ключей.
+func ReadKeyfile(path string) (*Keyfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read keyfile: %w", err)
+	}
+	var kf Keyfile
+	if err := json.Unmarshal(data, &kf); err != nil {
+		return nil, fmt.Errorf("parse keyfile: %w", err)
+	}
+	if _, err := NewLocalKeyring(&kf); err != nil {
+		return nil, err
+	}
+	return &kf, nil
+}
+
+// WriteKeyfile сохраняет файл мастер-ключей с правами 0600.
+func WriteKeyfile(path string, kf *Keyfile) error {
+	data, err := json.MarshalIndent(kf, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// AddKey генерирует новый мастер-ключ и делает его основным.
+func (kf *Keyfile) AddKey(keyID string) error {
+	if err := validKeyID(keyID); err != nil {
+		return err
+	}
+	if _, ok := kf.Keys[keyID]; ok {
+		return fmt.Errorf("key %q already exists", keyID)
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	if kf.Keys == nil {
+		kf.Keys = make(map[string]string)
+	}
+	kf.Keys[keyID] = base64.StdEncoding.EncodeToString(key)
+	kf.Primary = keyID
+	return nil
+}
+
+func validKeyID(keyID string) error {
+	if keyID == "" || strings.ContainsAny(keyID, ": \t\n") {
+		return fmt.Errorf("invalid key id %q: must be non-empty and contain no ':' or spaces", keyID)
+	}
+	return nil
+}
+
+// NewLocalKeyring создаёт keyring из разобранного файла ключей.
+func NewLocalKeyring(kf *Keyfile) (*LocalKeyring, error) {
+	if len(kf.Keys) == 0 {
+		return nil, errors.New("keyfile: no keys")
+	}
+	if _, ok := kf.Keys[kf.Primary]; !ok {
+		return nil, fmt.Errorf("keyfile: primary key %q not found", kf.Primary)
+	}
+	kr := &LocalKeyring{primary: kf.Primary, keys: make(map[string]cipher.AEAD, len(kf.Keys))}
+	for id, b64 := range kf.Keys {
+		if err := validKeyID(id); err != nil {
+			return nil, fmt.Errorf("keyfile: %w", err)
+		}
+		key, err := base64.StdEncoding.DecodeString(b64)
+		if err != nil {
+			return nil, fmt.Errorf("keyfile: key %q: %w", id, err)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("keyfile: key %q must be 32 bytes, got %d", id, len(key))
+		}
+		aead, err := newGCM(key)
+		if err != nil {
+			return nil, err
+		}
+		kr.keys[id] = aead
+	}
+	return kr, nil
+}
+
+func (k *LocalKeyring) PrimaryKeyID() string { return k.primary }
+
+func (k *LocalKeyring) WrapKey(_ context.Context, keyID string, dek []byte) ([]byte, error) {
+	aead, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("master key %q not found", keyID)
+	}
+	return seal(aead, dek)
+}
+
+func (k *LocalKeyring) UnwrapKey(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	aead, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("master key %q not found", keyID)
+	}
+	return open(aead, wrapped)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal шифрует AES-GCM; результат — nonce || ciphertext.
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, data []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ct := data[:aead.NonceSize()], data[aead.NonceSize():]
+	return aead.Open(nil, nonce, ct, nil)
+}
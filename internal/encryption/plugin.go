@@ -0,0 +1,159 @@
+package encryption
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// tagEncrypted помечает строковые поля модели, которые хранятся зашифрованными: `encrypted:"true"`.
+const tagEncrypted = "encrypted"
+
+// Plugin — GORM-плагин: шифрует помеченные поля перед INSERT/UPDATE и расшифровывает
+// после чтения. Raw/Exec-запросы плагин не видят — такие запросы не должны писать эти поля.
+type Plugin struct {
+	cipher *Cipher
+}
+
+func NewPlugin(c *Cipher) *Plugin { return &Plugin{cipher: c} }
+
+func (p *Plugin) Name() string { return "encryption" }
+
+func (p *Plugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().Before("gorm:create").Register("encryption:before_create", p.encrypt); err != nil {
+		return err
+	}
+	if err := cb.Create().After("gorm:create").Register("encryption:after_create", p.decrypt); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("encryption:before_update", p.encrypt); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Register("encryption:after_update", p.decrypt); err != nil {
+		return err
+	}
+	return cb.Query().After("gorm:query").Register("encryption:after_query", p.decrypt)
+}
+
+// Register подключает шифрование по файлу мастер-ключей. Пустой путь — шифрование выключено.
+func Register(db *gorm.DB, keyfile string) (*Cipher, error) {
+	if keyfile == "" {
+		return nil, nil
+	}
+	kf, err := ReadKeyfile(keyfile)
+	if err != nil {
+		return nil, err
+	}
+	keyring, err := NewLocalKeyring(kf)
+	if err != nil {
+		return nil, err
+	}
+	c := NewCipher(keyring)
+	if err := db.Use(NewPlugin(c)); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func encryptedFields(s *schema.Schema) []*schema.Field {
+	var out []*schema.Field
+	for _, f := range s.Fields {
+		if f.Tag.Get(tagEncrypted) == "true" && f.FieldType.Kind() == reflect.String {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func (p *Plugin) encrypt(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	p.apply(db, p.cipher.Encrypt)
+	// Updates(map[string]interface{}{...}): шифруем значения в копии map, исходную не трогаем.
+	if m, ok := db.Statement.Dest.(map[string]interface{}); ok && db.Statement.Schema != nil {
+		out := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			out[k] = v
+			f := db.Statement.Schema.LookUpField(k)
+			s, isString := v.(string)
+			if f == nil || !isString || f.Tag.Get(tagEncrypted) != "true" {
+				continue
+			}
+			enc, err := p.cipher.Encrypt(db.Statement.Context, s)
+			if err != nil {
+				_ = db.AddError(err)
+				return
+			}
+			out[k] = enc
+		}
+		db.Statement.Dest = out
+	}
+}
+
+// decrypt выполняется и после ошибки запроса: before_create/before_update уже зашифровали поля
+// модели вызывающего, и без расшифровки в ней остался бы шифртекст. Открытый текст Decrypt
+// возвращает как есть, поэтому частично зашифрованная или не заполненная модель не мешает.
+func (p *Plugin) decrypt(db *gorm.DB) {
+	p.apply(db, p.cipher.Decrypt)
+}
+
+// apply применяет fn к помеченным полям модели (Statement.ReflectValue) и к Dest,
+// если это отдельная структура той же модели (Updates(Ticket{...})).
+func (p *Plugin) apply(db *gorm.DB, fn func(context.Context, string) (string, error)) {
+	stmt := db.Statement
+	if stmt.Schema == nil {
+		return
+	}
+	fields := encryptedFields(stmt.Schema)
+	if len(fields) == 0 {
+		return
+	}
+	values := []reflect.Value{stmt.ReflectValue}
+	if stmt.Dest != nil && stmt.Dest != stmt.Model {
+		if dv := reflect.Indirect(reflect.ValueOf(stmt.Dest)); dv.IsValid() && dv.Type() == stmt.Schema.ModelType {
+			values = append(values, dv)
+		}
+	}
+	for _, rv := range values {
+		if err := transform(stmt.Context, rv, fields, fn); err != nil {
+			_ = db.AddError(err)
+			return
+		}
+	}
+}
+
+func transform(ctx context.Context, rv reflect.Value, fields []*schema.Field, fn func(context.Context, string) (string, error)) error {
+	rv = reflect.Indirect(rv)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := transform(ctx, rv.Index(i), fields, fn); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		// Find в чужую структуру при Model(&Ticket{}) — не трогаем.
+		if !rv.CanAddr() || rv.Type() != fields[0].Schema.ModelType {
+			return nil
+		}
+		for _, f := range fields {
+			v, zero := f.ValueOf(ctx, rv)
+			if zero {
+				continue
+			}
+			out, err := fn(ctx, v.(string))
+			if err != nil {
+				return fmt.Errorf("%s: %w", f.Name, err)
+			}
+			if err := f.Set(ctx, rv, out); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package encryption
+
+import (
+	"errors"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type secretRecord struct {
+	ID     uint64
+	Secret string `encrypted:"true"`
+}
+
+// newTestDB — DryRun-соединение (SQL не выполняется) с плагином шифрования и коллбэком,
+// который проваливает INSERT и UPDATE после шифрования полей.
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	kf := &Keyfile{}
+	if err := kf.AddKey("k1"); err != nil {
+		t.Fatal(err)
+	}
+	keyring, err := NewLocalKeyring(kf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Use(NewPlugin(NewCipher(keyring))); err != nil {
+		t.Fatal(err)
+	}
+	fail := func(db *gorm.DB) { _ = db.AddError(errors.New("write failed")) }
+	if err := db.Callback().Create().After("encryption:before_create").Before("gorm:create").Register("test:fail_create", fail); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Callback().Update().After("encryption:before_update").Before("gorm:update").Register("test:fail_update", fail); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestFailedWriteLeavesPlaintext(t *testing.T) {
+	db := newTestDB(t)
+
+	rec := secretRecord{Secret: "card 4111"}
+	if err := db.Create(&rec).Error; err == nil {
+		t.Fatal("Create: want the injected error")
+	}
+	if rec.Secret != "card 4111" {
+		t.Errorf("after failed Create Secret = %q, want plaintext", rec.Secret)
+	}
+
+	rec = secretRecord{ID: 1, Secret: "card 4111"}
+	if err := db.Save(&rec).Error; err == nil {
+		t.Fatal("Save: want the injected error")
+	}
+	if rec.Secret != "card 4111" {
+		t.Errorf("after failed Save Secret = %q, want plaintext", rec.Secret)
+	}
+}
@@ -1,7 +1,50 @@
 package errs
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
-	ErrTicketNotFound = errors.New("ticket not found")
+	ErrTicketNotFound      = errors.New("ticket not found")
+	ErrTicketAlreadyMerged = errors.New("ticket is already merged into another ticket")
+	ErrTicketNotClosed     = errors.New("ticket must be closed or merged")
+	ErrTicketNotRemoved    = errors.New("ticket is neither deleted nor archived")
+	ErrTicketOnLegalHold   = errors.New("ticket is on legal hold")
+	ErrTicketNotOnHold     = errors.New("ticket is not on legal hold")
+	// ErrMergeClientMismatch — слияние тикетов разных клиентов: заметки одного клиента попали бы
+	// в тикет другого, и удаление данных клиента (EraseClientData) до них бы не дошло.
+	ErrMergeClientMismatch = errors.New("tickets of different clients cannot be merged")
+
+	ErrInvalidLinkType    = errors.New("invalid link type: must be 'parent', 'child', 'blocks', 'relates_to' or 'duplicate_of'")
+	ErrSelfLink           = errors.New("ticket cannot be linked to itself")
+	ErrLinkCycle          = errors.New("link would create a cycle")
+	ErrLinkExists         = errors.New("tickets are already linked")
+	ErrParentExists       = errors.New("ticket already has a parent")
+	ErrTicketLinkNotFound = errors.New("ticket link not found")
+
+	ErrInvalidFilter = errors.New("invalid list filter")
+	// ErrSubjectFilterUnavailable — subject хранится зашифрованным, поиск подстроки в БД невозможен.
+	ErrSubjectFilterUnavailable = errors.New("subject filter is unavailable while subject encryption is enabled")
+	// ErrSearchUnavailable — subject и notes хранятся зашифрованными, текстовые индексы по ним бесполезны.
+	ErrSearchUnavailable = errors.New("text search is unavailable while subject/notes encryption is enabled")
+	// ErrSLAUnavailable — у тикетов нет срока SLA (политики SLA не заведены), сортировать по нему нечем.
+	ErrSLAUnavailable = errors.New("order_by sla_due is not supported: tickets have no SLA due time")
 )
+
+// ErrOpenSessionTicketExists — у сессии уже есть незакрытый тикет (политика дедупликации reject).
+var ErrOpenSessionTicketExists = errors.New("session already has an open ticket")
+
+// OpenSessionTicketError несёт ID конфликтующего тикета; errors.Is(err, ErrOpenSessionTicketExists) == true.
+type OpenSessionTicketError struct {
+	SessionID string
+	TicketID  uint64
+}
+
+func (e *OpenSessionTicketError) Error() string {
+	return fmt.Sprintf("session %s already has an open ticket %d", e.SessionID, e.TicketID)
+}
+
+func (e *OpenSessionTicketError) Is(target error) bool {
+	return target == ErrOpenSessionTicketExists
+}
@@ -0,0 +1,105 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/psds-microservice/ticket-service/internal/apikey"
+	"github.com/psds-microservice/ticket-service/internal/auth"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// apiKeyScheme — схема заголовка authorization: "ApiKey tsk_...".
+const apiKeyScheme = "apikey"
+
+// APIKeyAuthenticator проверяет API-ключ (реализация — apikey.Store).
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, raw string) (*model.APIKey, error)
+}
+
+// readMethods — методы, которым достаточно tickets:read. CreateTicket требует tickets:create,
+// все остальные (в том числе новые) — tickets:admin.
+var readMethods = map[string]bool{
+	"GetTicket":            true,
+	"GetTicketByReference": true,
+	"ListTickets":          true,
+	"ListLinkedTickets":    true,
+	"GetTicketsBySession":  true,
+	"WatchTickets":         true,
+	"SearchTickets":        true,
+	"FindSimilarTickets":   true,
+	"BatchGetTickets":      true,
+}
+
+// requiredScope — scope API-ключа, нужный для метода TicketService.
+func requiredScope(fullMethod string) string {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	switch {
+	case name == "CreateTicket":
+		return auth.ScopeTicketsCreate
+	case readMethods[name]:
+		return auth.ScopeTicketsRead
+	default:
+		return auth.ScopeTicketsAdmin
+	}
+}
+
+// apiKeyFromMetadata возвращает ключ из "authorization: ApiKey <key>" (ok=false — заголовка с этой схемой нет).
+func apiKeyFromMetadata(ctx context.Context) (string, bool) {
+	scheme, key, found := strings.Cut(getMetadata(ctx, "authorization"), " ")
+	if !found || strings.ToLower(scheme) != apiKeyScheme {
+		return "", false
+	}
+	return strings.TrimSpace(key), true
+}
+
+func authenticateAPIKey(ctx context.Context, keys APIKeyAuthenticator, fullMethod string) (context.Context, error) {
+	raw, ok := apiKeyFromMetadata(ctx)
+	if !ok {
+		return ctx, nil
+	}
+	k, err := keys.Authenticate(ctx, raw)
+	if errors.Is(err, apikey.ErrInvalid) {
+		return nil, status.Error(codes.Unauthenticated, "invalid api key")
+	}
+	if err != nil {
+		log.Printf("grpc: authenticate api key: %v", err)
+		return nil, status.Error(codes.Unavailable, "api key check failed")
+	}
+	p := &auth.Principal{ID: "apikey:" + k.Name, Role: RoleService, TenantID: k.TenantID, Scopes: k.Scopes}
+	if isTicketMethod(fullMethod) {
+		if scope := requiredScope(fullMethod); !p.HasScope(scope) {
+			return nil, status.Errorf(codes.PermissionDenied, "api key lacks scope %s", scope)
+		}
+	}
+	return auth.WithPrincipal(ctx, p), nil
+}
+
+// APIKeyUnaryInterceptor аутентифицирует вызывающего по заголовку authorization: ApiKey <key>
+// и проверяет scope ключа для метода. Запросы без такого заголовка пропускаются как есть.
+// Ставится перед перехватчиками тенанта и rate limiting (тенант ключа ограничивает запрос).
+func APIKeyUnaryInterceptor(keys APIKeyAuthenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticateAPIKey(ctx, keys, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// APIKeyStreamInterceptor — то же для потоковых RPC.
+func APIKeyStreamInterceptor(keys APIKeyAuthenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticateAPIKey(ss.Context(), keys, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}
+}
@@ -0,0 +1,173 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/psds-microservice/ticket-service/internal/celfilter"
+	"github.com/psds-microservice/ticket-service/internal/errs"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/internal/service"
+	"github.com/psds-microservice/ticket-service/pkg/gen/ticket_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// batchGetMaxIDs — предел ids в BatchGetTickets.
+	batchGetMaxIDs = 100
+	// bulkUpdateMaxTickets — предел тикетов в BulkUpdateTickets (по ids или по filter).
+	bulkUpdateMaxTickets = 500
+)
+
+// uniqueIDs проверяет ID и убирает повторы, сохраняя порядок.
+func uniqueIDs(ids []int64, max int) ([]uint64, error) {
+	if len(ids) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "ids is required")
+	}
+	seen := make(map[int64]bool, len(ids))
+	out := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "ids must be greater than 0, got %d", id)
+		}
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, uint64(id))
+		}
+	}
+	if len(out) > max {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d ids per request, got %d", max, len(out))
+	}
+	return out, nil
+}
+
+func (s *Server) BatchGetTickets(ctx context.Context, req *ticket_service.BatchGetTicketsRequest) (*ticket_service.BatchGetTicketsResponse, error) {
+	if req.GetIncludeDeleted() && !callerIsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "include_deleted requires admin role")
+	}
+	ids, err := uniqueIDs(req.GetIds(), batchGetMaxIDs)
+	if err != nil {
+		return nil, err
+	}
+	tickets, err := s.Ticket.GetByIDs(ctx, ids, req.GetIncludeDeleted())
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	resp := &ticket_service.BatchGetTicketsResponse{Tickets: make([]*ticket_service.Ticket, len(tickets))}
+	found := make(map[uint64]bool, len(tickets))
+	for i := range tickets {
+		resp.Tickets[i] = toProtoTicket(&tickets[i])
+		found[tickets[i].ID] = true
+	}
+	for _, id := range ids {
+		if !found[id] {
+			resp.MissingIds = append(resp.MissingIds, int64(id))
+		}
+	}
+	return resp, nil
+}
+
+// bulkTargets — тикеты BulkUpdateTickets в порядке ответа; missing — ID из запроса, которых нет.
+func (s *Server) bulkTargets(ctx context.Context, req *ticket_service.BulkUpdateTicketsRequest) (tickets []model.Ticket, missing map[uint64]bool, order []uint64, err error) {
+	switch {
+	case len(req.GetIds()) > 0 && req.GetFilter() != "":
+		return nil, nil, nil, status.Error(codes.InvalidArgument, "ids and filter are mutually exclusive")
+	case req.GetFilter() != "":
+		expr, err := celfilter.Compile(req.GetFilter())
+		if err != nil {
+			return nil, nil, nil, filterError(err)
+		}
+		page, err := s.Ticket.List(ctx, service.ListFilter{Expr: expr}, service.ListOptions{
+			Limit: bulkUpdateMaxTickets,
+			Total: service.TotalNone,
+		})
+		if err != nil {
+			return nil, nil, nil, s.mapError(err)
+		}
+		if page.Next != nil {
+			return nil, nil, nil, status.Errorf(codes.InvalidArgument, "filter matches more than %d tickets; narrow it down", bulkUpdateMaxTickets)
+		}
+		order = make([]uint64, len(page.Items))
+		for i := range page.Items {
+			order[i] = page.Items[i].ID
+		}
+		return page.Items, nil, order, nil
+	}
+	if len(req.GetIds()) == 0 {
+		return nil, nil, nil, status.Error(codes.InvalidArgument, "ids or filter is required")
+	}
+	order, err = uniqueIDs(req.GetIds(), bulkUpdateMaxTickets)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	tickets, err = s.Ticket.GetByIDs(ctx, order, false)
+	if err != nil {
+		return nil, nil, nil, s.mapError(err)
+	}
+	missing = make(map[uint64]bool, len(order))
+	for _, id := range order {
+		missing[id] = true
+	}
+	for i := range tickets {
+		delete(missing, tickets[i].ID)
+	}
+	return tickets, missing, order, nil
+}
+
+func (s *Server) BulkUpdateTickets(ctx context.Context, req *ticket_service.BulkUpdateTicketsRequest) (*ticket_service.BulkUpdateTicketsResponse, error) {
+	changes, err := ticketChanges(req)
+	if err != nil {
+		return nil, err
+	}
+	tickets, missing, order, err := s.bulkTargets(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[uint64]*ticket_service.BulkUpdateResult, len(order))
+	fail := func(id uint64, err error) {
+		st := status.Convert(err)
+		results[id] = &ticket_service.BulkUpdateResult{Id: int64(id), Code: int32(st.Code()), Message: st.Message()}
+	}
+	for id := range missing {
+		fail(id, s.mapError(errs.ErrTicketNotFound))
+	}
+	// Права проверяются по каждому тикету, как в UpdateTicket.
+	allowed := make([]uint64, 0, len(tickets))
+	for i := range tickets {
+		if err := authorizeTicketWrite(ctx, &tickets[i]); err != nil {
+			fail(tickets[i].ID, err)
+			continue
+		}
+		allowed = append(allowed, tickets[i].ID)
+	}
+
+	cascade := req.GetCascadeCloseChildren() && model.TicketStatus(req.GetStatus()) == model.TicketStatusClosed
+	updates, err := s.Ticket.UpdateMany(ctx, allowed, changes, cascade)
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	var changed []model.Ticket
+	for _, u := range updates {
+		if u.Err != nil {
+			fail(u.ID, s.mapError(u.Err))
+			continue
+		}
+		results[u.ID] = &ticket_service.BulkUpdateResult{Id: int64(u.ID), Ticket: toProtoTicket(u.Ticket)}
+		changed = append(changed, *u.Ticket)
+		changed = append(changed, u.Children...)
+	}
+	s.publishEvents("ticket.updated", changed)
+
+	resp := &ticket_service.BulkUpdateTicketsResponse{Results: make([]*ticket_service.BulkUpdateResult, 0, len(order))}
+	for _, id := range order {
+		r := results[id]
+		if r.Ticket != nil {
+			resp.Updated++
+		} else {
+			resp.Failed++
+		}
+		resp.Results = append(resp.Results, r)
+	}
+	return resp, nil
+}
@@ -0,0 +1,84 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/pkg/gen/ticket_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toProtoAuditEntry(e *model.AuditEntry) *ticket_service.AuditEntry {
+	out := &ticket_service.AuditEntry{
+		Id:        int64(e.ID),
+		ClientId:  e.ClientID,
+		Actor:     e.Actor,
+		Action:    e.Action,
+		Details:   string(e.Details),
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+	if e.TicketID != nil {
+		out.TicketId = int64(*e.TicketID)
+	}
+	return out
+}
+
+// auditActor — кто выполняет привилегированную операцию (для журнала аудита).
+func auditActor(ctx context.Context) string {
+	if id := getMetadata(ctx, "x-caller-id"); id != "" {
+		return id
+	}
+	return "unknown"
+}
+
+func (s *Server) ExportClientData(ctx context.Context, req *ticket_service.ExportClientDataRequest) (*ticket_service.ExportClientDataResponse, error) {
+	if req.GetClientId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "client_id is required")
+	}
+	if !callerIsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "client data export requires admin role")
+	}
+	export, err := s.Ticket.ExportClientData(ctx, req.GetClientId(), auditActor(ctx))
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	out := &ticket_service.ExportClientDataResponse{
+		ClientId:   export.ClientID,
+		ExportedAt: timestamppb.New(export.ExportedAt),
+		Tickets:    make([]*ticket_service.Ticket, len(export.Tickets)),
+		Links:      make([]*ticket_service.TicketLink, len(export.Links)),
+		History:    make([]*ticket_service.AuditEntry, len(export.History)),
+	}
+	for i := range export.Tickets {
+		out.Tickets[i] = toProtoTicket(&export.Tickets[i])
+	}
+	for i := range export.Links {
+		out.Links[i] = toProtoTicketLink(&export.Links[i])
+	}
+	for i := range export.History {
+		out.History[i] = toProtoAuditEntry(&export.History[i])
+	}
+	return out, nil
+}
+
+// EraseClientData обезличивает тикеты клиента; ticket.erased — сигнал search-service удалить документ.
+func (s *Server) EraseClientData(ctx context.Context, req *ticket_service.EraseClientDataRequest) (*ticket_service.EraseClientDataResponse, error) {
+	if req.GetClientId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "client_id is required")
+	}
+	if !callerIsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "client data erasure requires admin role")
+	}
+	erased, err := s.Ticket.EraseClientData(ctx, req.GetClientId(), auditActor(ctx))
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	ids := make([]int64, len(erased))
+	for i := range erased {
+		ids[i] = int64(erased[i].ID)
+		s.publishEvent("ticket.erased", &erased[i])
+	}
+	return &ticket_service.EraseClientDataResponse{ErasedTicketIds: ids}, nil
+}
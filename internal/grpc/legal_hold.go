@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/psds-microservice/ticket-service/pkg/gen/ticket_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) PlaceLegalHold(ctx context.Context, req *ticket_service.PlaceLegalHoldRequest) (*ticket_service.Ticket, error) {
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "id must be greater than 0")
+	}
+	if req.GetReason() == "" || req.GetCaseId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason and case_id are required")
+	}
+	if !callerIsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "legal hold requires admin role")
+	}
+	ticket, err := s.Ticket.PlaceLegalHold(ctx, uint64(req.GetId()), req.GetReason(), req.GetCaseId(), auditActor(ctx))
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	if !ticket.DeletedAt.Valid {
+		s.publishEvent("ticket.updated", ticket)
+	}
+	return toProtoTicket(ticket), nil
+}
+
+func (s *Server) ReleaseLegalHold(ctx context.Context, req *ticket_service.ReleaseLegalHoldRequest) (*ticket_service.Ticket, error) {
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "id must be greater than 0")
+	}
+	if req.GetReason() == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+	if !callerIsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "legal hold requires admin role")
+	}
+	ticket, err := s.Ticket.ReleaseLegalHold(ctx, uint64(req.GetId()), req.GetReason(), auditActor(ctx))
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	if !ticket.DeletedAt.Valid {
+		s.publishEvent("ticket.updated", ticket)
+	}
+	return toProtoTicket(ticket), nil
+}
@@ -0,0 +1,64 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/psds-microservice/ticket-service/pkg/gen/ticket_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// DeleteTicket мягко удаляет тикет; ticket.deleted — сигнал search-service удалить документ.
+func (s *Server) DeleteTicket(ctx context.Context, req *ticket_service.DeleteTicketRequest) (*emptypb.Empty, error) {
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "id must be greater than 0")
+	}
+	if !callerIsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "deleting tickets requires admin role")
+	}
+	ticket, err := s.Ticket.Delete(ctx, uint64(req.GetId()))
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	s.publishEvent("ticket.deleted", ticket)
+	return &emptypb.Empty{}, nil
+}
+
+// RestoreTicket возвращает удалённый или архивный тикет; ticket.restored — сигнал переиндексировать документ.
+func (s *Server) RestoreTicket(ctx context.Context, req *ticket_service.RestoreTicketRequest) (*ticket_service.Ticket, error) {
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "id must be greater than 0")
+	}
+	if !callerIsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "restoring tickets requires admin role")
+	}
+	ticket, err := s.Ticket.Restore(ctx, uint64(req.GetId()))
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	s.publishEvent("ticket.restored", ticket)
+	return toProtoTicket(ticket), nil
+}
+
+func (s *Server) ArchiveTicket(ctx context.Context, req *ticket_service.ArchiveTicketRequest) (*ticket_service.Ticket, error) {
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "id must be greater than 0")
+	}
+	// Архивный тикет тоже находится: повторная архивация — no-op.
+	ticket, err := s.Ticket.GetByIDWithArchived(ctx, uint64(req.GetId()))
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	if !callerIsAdmin(ctx) {
+		if err := authorizeTicketWrite(ctx, ticket); err != nil {
+			return nil, err
+		}
+	}
+	ticket, err = s.Ticket.Archive(ctx, uint64(req.GetId()))
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	s.publishEvent("ticket.archived", ticket)
+	return toProtoTicket(ticket), nil
+}
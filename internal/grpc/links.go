@@ -0,0 +1,85 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/pkg/gen/ticket_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toProtoTicketLink(l *model.TicketLink) *ticket_service.TicketLink {
+	if l == nil {
+		return nil
+	}
+	out := &ticket_service.TicketLink{
+		TicketId:       int64(l.TicketID),
+		LinkedTicketId: int64(l.LinkedTicketID),
+		LinkType:       string(l.LinkType),
+	}
+	if !l.CreatedAt.IsZero() {
+		out.CreatedAt = timestamppb.New(l.CreatedAt)
+	}
+	return out
+}
+
+// authorizeLink: связывать можно только тикеты, которые caller вправе изменять.
+func (s *Server) authorizeLink(ctx context.Context, ticketID, linkedID int64) error {
+	if ticketID <= 0 || linkedID <= 0 {
+		return status.Error(codes.InvalidArgument, "ticket_id and linked_ticket_id must be greater than 0")
+	}
+	for _, id := range []int64{ticketID, linkedID} {
+		t, err := s.Ticket.GetByID(ctx, uint64(id))
+		if err != nil {
+			return s.mapError(err)
+		}
+		if err := authorizeTicketWrite(ctx, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) LinkTickets(ctx context.Context, req *ticket_service.LinkTicketsRequest) (*ticket_service.TicketLink, error) {
+	if req.GetLinkType() == "" {
+		return nil, status.Error(codes.InvalidArgument, "link_type is required")
+	}
+	if err := s.authorizeLink(ctx, req.GetTicketId(), req.GetLinkedTicketId()); err != nil {
+		return nil, err
+	}
+	link, err := s.Ticket.LinkTickets(ctx, uint64(req.GetTicketId()), uint64(req.GetLinkedTicketId()), model.TicketLinkType(req.GetLinkType()))
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	return toProtoTicketLink(link), nil
+}
+
+func (s *Server) UnlinkTickets(ctx context.Context, req *ticket_service.UnlinkTicketsRequest) (*ticket_service.UnlinkTicketsResponse, error) {
+	if req.GetLinkType() == "" {
+		return nil, status.Error(codes.InvalidArgument, "link_type is required")
+	}
+	if err := s.authorizeLink(ctx, req.GetTicketId(), req.GetLinkedTicketId()); err != nil {
+		return nil, err
+	}
+	if err := s.Ticket.UnlinkTickets(ctx, uint64(req.GetTicketId()), uint64(req.GetLinkedTicketId()), model.TicketLinkType(req.GetLinkType())); err != nil {
+		return nil, s.mapError(err)
+	}
+	return &ticket_service.UnlinkTicketsResponse{}, nil
+}
+
+func (s *Server) ListLinkedTickets(ctx context.Context, req *ticket_service.ListLinkedTicketsRequest) (*ticket_service.ListLinkedTicketsResponse, error) {
+	if req.GetTicketId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "ticket_id must be greater than 0")
+	}
+	links, err := s.Ticket.ListLinks(ctx, uint64(req.GetTicketId()), model.TicketLinkType(req.GetLinkType()))
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	out := make([]*ticket_service.TicketLink, len(links))
+	for i := range links {
+		out[i] = toProtoTicketLink(&links[i])
+	}
+	return &ticket_service.ListLinkedTicketsResponse{Links: out}, nil
+}
@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/psds-microservice/ticket-service/pkg/gen/ticket_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MergeTickets вливает source-тикеты в target. Для каждого источника уходит ticket.merged
+// (search-service удаляет документ по ticket_id), для target — ticket.updated.
+func (s *Server) MergeTickets(ctx context.Context, req *ticket_service.MergeTicketsRequest) (*ticket_service.MergeTicketsResponse, error) {
+	if req.GetTargetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "target_id must be greater than 0")
+	}
+	if len(req.GetSourceIds()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "source_ids is required")
+	}
+	seen := map[int64]bool{req.GetTargetId(): true}
+	sourceIDs := make([]uint64, 0, len(req.GetSourceIds()))
+	for _, id := range req.GetSourceIds() {
+		if id <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "source_ids must be greater than 0")
+		}
+		if seen[id] {
+			return nil, status.Errorf(codes.InvalidArgument, "ticket %d is listed more than once or is the target", id)
+		}
+		seen[id] = true
+		sourceIDs = append(sourceIDs, uint64(id))
+	}
+	// Permission check: caller должен иметь право изменять каждый из тикетов.
+	for id := range seen {
+		ticket, err := s.Ticket.GetByID(ctx, uint64(id))
+		if err != nil {
+			return nil, s.mapError(err)
+		}
+		if err := authorizeTicketWrite(ctx, ticket); err != nil {
+			return nil, err
+		}
+	}
+
+	target, merged, err := s.Ticket.Merge(ctx, uint64(req.GetTargetId()), sourceIDs)
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	mergedIDs := make([]int64, len(merged))
+	for i := range merged {
+		mergedIDs[i] = int64(merged[i].ID)
+		s.publishEvent("ticket.merged", &merged[i])
+	}
+	s.publishEvent("ticket.updated", target)
+	return &ticket_service.MergeTicketsResponse{
+		Target:          toProtoTicket(target),
+		MergedTicketIds: mergedIDs,
+	}, nil
+}
@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/psds-microservice/ticket-service/internal/service"
+)
+
+// pageTokenVersion — формат токена; смена формата делает старые токены недействительными.
+const pageTokenVersion = 2
+
+// cursorSize — версия, время, ранг и id курсора.
+const cursorSize = 1 + 8 + 8 + 8
+
+var errBadPageToken = errors.New("invalid or expired page_token")
+
+// PageTokens подписывает курсоры ListTickets (HMAC-SHA256): клиент не может подделать позицию
+// или применить токен к другим фильтрам. Токен непрозрачный: версия, ключ сортировки, id и отпечаток фильтров.
+type PageTokens struct {
+	key []byte
+}
+
+func NewPageTokens(key []byte) *PageTokens {
+	return &PageTokens{key: key}
+}
+
+// filterFingerprint — отпечаток тенанта, фильтров и сортировки запроса: токен действует только
+// с теми же (токен одного тенанта не подходит другому, даже с тем же ключом подписи).
+func filterFingerprint(tenantID string, filter service.ListFilter, order service.ListOrder, includeDeleted, includeArchived bool) []byte {
+	// ListFilter — структура: json.Marshal даёт стабильный порядок полей.
+	b, _ := json.Marshal(filter)
+	b = fmt.Appendf(b, ";tenant=%q;order=%s:%t;deleted=%t;archived=%t", tenantID, order.Field, order.Desc, includeDeleted, includeArchived)
+	sum := sha256.Sum256(b)
+	return sum[:8]
+}
+
+func (p *PageTokens) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write(payload)
+	return mac.Sum(nil)[:16]
+}
+
+// Encode — токен следующей страницы после курсора c.
+func (p *PageTokens) Encode(c *service.ListCursor, fingerprint []byte) string {
+	payload := make([]byte, 0, cursorSize+len(fingerprint))
+	payload = append(payload, pageTokenVersion)
+	payload = binary.BigEndian.AppendUint64(payload, uint64(c.Time.UnixNano()))
+	payload = binary.BigEndian.AppendUint64(payload, uint64(int64(c.Rank)))
+	payload = binary.BigEndian.AppendUint64(payload, c.ID)
+	payload = append(payload, fingerprint...)
+	return base64.RawURLEncoding.EncodeToString(append(payload, p.sign(payload)...))
+}
+
+// Decode проверяет подпись и фильтры токена и возвращает курсор.
+func (p *PageTokens) Decode(token string, fingerprint []byte) (*service.ListCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) != cursorSize+len(fingerprint)+16 || raw[0] != pageTokenVersion {
+		return nil, errBadPageToken
+	}
+	payload, sig := raw[:len(raw)-16], raw[len(raw)-16:]
+	if !hmac.Equal(sig, p.sign(payload)) {
+		return nil, errBadPageToken
+	}
+	if !hmac.Equal(payload[cursorSize:], fingerprint) {
+		return nil, errors.New("page_token was issued for different filters")
+	}
+	return &service.ListCursor{
+		Time: time.Unix(0, int64(binary.BigEndian.Uint64(payload[1:9]))),
+		Rank: int(int64(binary.BigEndian.Uint64(payload[9:17]))),
+		ID:   binary.BigEndian.Uint64(payload[17:25]),
+	}, nil
+}
@@ -0,0 +1,86 @@
+package grpc
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/internal/service"
+)
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	tokens := NewPageTokens([]byte("secret"))
+	fp := filterFingerprint("acme", service.ListFilter{ClientID: "c1"}, service.DefaultListOrder, false, false)
+	for _, c := range []*service.ListCursor{
+		{Time: time.Date(2026, 10, 18, 12, 30, 0, 123456789, time.UTC), ID: 42},
+		{Rank: 4, ID: 7},
+		{Time: time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC), Rank: -1, ID: 1<<63 + 5},
+	} {
+		got, err := tokens.Decode(tokens.Encode(c, fp), fp)
+		if err != nil {
+			t.Fatalf("Decode(Encode(%+v)): %v", c, err)
+		}
+		// У курсора сортировки по priority времени нет (нулевое время в UnixNano не помещается).
+		if !c.Time.IsZero() && !got.Time.Equal(c.Time) || got.Rank != c.Rank || got.ID != c.ID {
+			t.Errorf("round trip = %+v, want %+v", got, c)
+		}
+	}
+}
+
+func TestPageTokenRejectsTampering(t *testing.T) {
+	tokens := NewPageTokens([]byte("secret"))
+	fp := filterFingerprint("acme", service.ListFilter{}, service.DefaultListOrder, false, false)
+	token := tokens.Encode(&service.ListCursor{Time: time.Unix(1700000000, 0), ID: 42}, fp)
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+	tests := map[string]string{
+		"not base64":  "!!!",
+		"empty":       "",
+		"truncated":   encode(raw[:len(raw)-1]),
+		"extended":    encode(append(append([]byte{}, raw...), 0)),
+		"other key":   NewPageTokens([]byte("other")).Encode(&service.ListCursor{ID: 42}, fp),
+		"old version": encode(append([]byte{pageTokenVersion - 1}, raw[1:]...)),
+	}
+	// Перевёрнутый бит в любом байте — версии, курсоре, отпечатке или подписи.
+	for i := range raw {
+		flipped := append([]byte{}, raw...)
+		flipped[i] ^= 0x01
+		tests[fmt.Sprintf("bit flip in byte %d", i)] = encode(flipped)
+	}
+	for name, bad := range tests {
+		if c, err := tokens.Decode(bad, fp); err == nil {
+			t.Errorf("%s: Decode accepted the token: %+v", name, c)
+		}
+	}
+}
+
+func TestPageTokenIsBoundToRequest(t *testing.T) {
+	tokens := NewPageTokens([]byte("secret"))
+	open := []model.TicketStatus{model.TicketStatusOpen}
+	base := filterFingerprint("acme", service.ListFilter{Statuses: open}, service.DefaultListOrder, false, false)
+	token := tokens.Encode(&service.ListCursor{Time: time.Unix(1700000000, 0), ID: 42}, base)
+
+	others := map[string][]byte{
+		"other tenant":    filterFingerprint("globex", service.ListFilter{Statuses: open}, service.DefaultListOrder, false, false),
+		"other filter":    filterFingerprint("acme", service.ListFilter{Statuses: open, ClientID: "c1"}, service.DefaultListOrder, false, false),
+		"no filter":       filterFingerprint("acme", service.ListFilter{}, service.DefaultListOrder, false, false),
+		"other order":     filterFingerprint("acme", service.ListFilter{Statuses: open}, service.ListOrder{Field: service.OrderPriority, Desc: true}, false, false),
+		"other direction": filterFingerprint("acme", service.ListFilter{Statuses: open}, service.ListOrder{Field: service.OrderCreatedAt}, false, false),
+		"with deleted":    filterFingerprint("acme", service.ListFilter{Statuses: open}, service.DefaultListOrder, true, false),
+		"with archived":   filterFingerprint("acme", service.ListFilter{Statuses: open}, service.DefaultListOrder, false, true),
+	}
+	for name, fp := range others {
+		if _, err := tokens.Decode(token, fp); err == nil {
+			t.Errorf("%s: token issued for other request parameters was accepted", name)
+		}
+	}
+	if _, err := tokens.Decode(token, filterFingerprint("acme", service.ListFilter{Statuses: open}, service.DefaultListOrder, false, false)); err != nil {
+		t.Errorf("same parameters: %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/psds-microservice/ticket-service/internal/auth"
+	"github.com/psds-microservice/ticket-service/internal/tlsconfig"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// ForwardedIdentityHeader — идентичность клиентского сертификата HTTP-запроса, которую
+// grpc-gateway передаёт во внутренний gRPC-сервер. Принимается только от gateway:
+// внешний сервер её игнорирует, а gateway не пропускает такой заголовок от клиентов.
+const ForwardedIdentityHeader = "x-forwarded-client-identity"
+
+// RoleService — роль principal, аутентифицированного клиентским сертификатом.
+const RoleService = "service"
+
+func certIdentity(ctx context.Context, trustForwarded bool) string {
+	if trustForwarded {
+		return getMetadata(ctx, ForwardedIdentityHeader)
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return ""
+	}
+	return tlsconfig.VerifiedIdentity(info.State)
+}
+
+func withCertPrincipal(ctx context.Context, trustForwarded bool) context.Context {
+	if _, ok := auth.FromContext(ctx); ok {
+		return ctx
+	}
+	if id := certIdentity(ctx, trustForwarded); id != "" {
+		return auth.WithPrincipal(ctx, &auth.Principal{ID: id, Role: RoleService})
+	}
+	return ctx
+}
+
+// CertPrincipalUnaryInterceptor делает principal из проверенного клиентского сертификата (SAN/CN).
+// trustForwarded — для внутреннего сервера за grpc-gateway: идентичность берётся из
+// ForwardedIdentityHeader. Ставится перед перехватчиками тенанта и rate limiting.
+func CertPrincipalUnaryInterceptor(trustForwarded bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withCertPrincipal(ctx, trustForwarded), req)
+	}
+}
+
+// CertPrincipalStreamInterceptor — то же для потоковых RPC.
+func CertPrincipalStreamInterceptor(trustForwarded bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: withCertPrincipal(ss.Context(), trustForwarded)})
+	}
+}
@@ -0,0 +1,182 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"net"
+	"path"
+	"time"
+
+	"github.com/psds-microservice/ticket-service/internal/auth"
+	"github.com/psds-microservice/ticket-service/internal/ratelimit"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// clientIDGetter — запросы с client_id (CreateTicket, ListTickets, GDPR-методы).
+type clientIDGetter interface {
+	GetClientId() string
+}
+
+// ForwardedPeerHeader — адрес HTTP-клиента, который grpc-gateway передаёт во внутренний
+// gRPC-сервер (там peer — in-process соединение gateway). Как и ForwardedIdentityHeader,
+// принимается только от gateway: от клиентов gateway такой заголовок не пропускает.
+const ForwardedPeerHeader = "x-forwarded-peer"
+
+// PeerHost — адрес без порта ("203.0.113.7:51234" -> "203.0.113.7").
+func PeerHost(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// peerAddr — адрес вызывающего: из соединения или, за grpc-gateway, из ForwardedPeerHeader.
+func peerAddr(ctx context.Context, trustForwarded bool) string {
+	if trustForwarded {
+		return getMetadata(ctx, ForwardedPeerHeader)
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	return PeerHost(p.Addr.String())
+}
+
+// callerKey — идентичность вызывающего для rate limiting: аутентифицированный principal,
+// иначе адрес клиента. x-caller-id не используется: его выбирает сам клиент, и новое значение
+// в каждом запросе давало бы новую корзину. Без адреса все анонимные вызовы делят одну корзину.
+func callerKey(ctx context.Context, trustForwarded bool) string {
+	if p, ok := auth.FromContext(ctx); ok && p.ID != "" {
+		return p.ID
+	}
+	if addr := peerAddr(ctx, trustForwarded); addr != "" {
+		return "peer:" + addr
+	}
+	return "anonymous"
+}
+
+// quotaSubject — чья суточная квота CreateTicket списывается: аутентифицированного principal,
+// иначе client_id из запроса. client_id выбирает вызывающий, и по нему чужая интеграция
+// могла бы израсходовать квоту клиента.
+func quotaSubject(ctx context.Context, clientID string) string {
+	if p, ok := auth.FromContext(ctx); ok && p.ID != "" {
+		return "principal:" + p.ID
+	}
+	return "client_id:" + clientID
+}
+
+// createOutcomeKey — ключ контекста, через который CreateTicket сообщает перехватчику,
+// что тикет не создан, хотя ошибки нет (возвращён существующий тикет).
+type createOutcomeKey struct{}
+
+type createOutcome struct{ notCreated bool }
+
+// ticketNotCreated: CreateTicket ответил без создания тикета — списанная квота вернётся.
+func ticketNotCreated(ctx context.Context) {
+	if o, ok := ctx.Value(createOutcomeKey{}).(*createOutcome); ok {
+		o.notCreated = true
+	}
+}
+
+// rateLimited — ResourceExhausted с RetryInfo (gateway отдаёт 429 и Retry-After)
+// и, для квоты, QuotaFailure.
+func rateLimited(msg string, retry time.Duration, quota *errdetails.QuotaFailure) error {
+	st := status.New(codes.ResourceExhausted, msg)
+	withDetails, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retry.Round(time.Millisecond))})
+	if err != nil {
+		return st.Err()
+	}
+	if quota != nil {
+		if withQuota, err := withDetails.WithDetails(quota); err == nil {
+			withDetails = withQuota
+		}
+	}
+	return withDetails.Err()
+}
+
+// checkRateLimit проверяет корзины вызывающего и клиента для метода, а для CreateTicket —
+// списывает суточную квоту клиента; quotaKey — ключ списанной квоты (пустой, если не списывалась).
+// Ошибки хранилища не блокируют запрос (fail open).
+func checkRateLimit(ctx context.Context, l *ratelimit.Limiter, fullMethod string, req interface{}, trustForwarded bool) (quotaKey string, err error) {
+	method := path.Base(fullMethod)
+	tenantID, _ := tenant.FromContext(ctx)
+	keys := []string{tenantID + "|caller:" + callerKey(ctx, trustForwarded)}
+	var clientID string
+	if g, ok := req.(clientIDGetter); ok && g.GetClientId() != "" {
+		clientID = g.GetClientId()
+		keys = append(keys, tenantID+"|client:"+clientID)
+	}
+	for _, key := range keys {
+		ok, retry, err := l.Allow(ctx, method, key)
+		if err != nil {
+			log.Printf("ratelimit: %s: %v", method, err)
+			continue
+		}
+		if !ok {
+			return "", rateLimited("rate limit exceeded for "+method, retry, nil)
+		}
+	}
+	if method == "CreateTicket" && clientID != "" {
+		subject := quotaSubject(ctx, clientID)
+		quotaKey = tenantID + "|" + subject
+		ok, retry, err := l.ConsumeCreateQuota(ctx, quotaKey)
+		if err != nil {
+			log.Printf("ratelimit: create quota: %v", err)
+			return "", nil
+		}
+		if !ok {
+			return "", rateLimited("daily ticket creation quota exceeded for "+subject, retry, &errdetails.QuotaFailure{
+				Violations: []*errdetails.QuotaFailure_Violation{{
+					Subject:     subject,
+					Description: "daily CreateTicket quota exhausted; resets at 00:00 UTC",
+				}},
+			})
+		}
+	}
+	return quotaKey, nil
+}
+
+// RateLimitUnaryInterceptor ограничивает частоту вызовов TicketService. Ставится после
+// TenantUnaryInterceptor: ключи корзин включают тенант. Квота CreateTicket, списанная перед
+// вызовом, возвращается, если тикет не создан: обработчик вернул ошибку или существующий
+// тикет (SESSION_DEDUP=return_existing, см. ticketNotCreated).
+// trustForwarded — сервер за grpc-gateway: адрес клиента берётся из ForwardedPeerHeader.
+func RateLimitUnaryInterceptor(l *ratelimit.Limiter, trustForwarded bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !l.Enabled() || !isTicketMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		quotaKey, err := checkRateLimit(ctx, l, info.FullMethod, req, trustForwarded)
+		if err != nil {
+			return nil, err
+		}
+		outcome := &createOutcome{}
+		resp, err := handler(context.WithValue(ctx, createOutcomeKey{}, outcome), req)
+		if (err != nil || outcome.notCreated) && quotaKey != "" {
+			// Контекст запроса может быть уже отменён — возврат не должен от этого теряться.
+			if refundErr := l.RefundCreateQuota(context.WithoutCancel(ctx), quotaKey); refundErr != nil {
+				log.Printf("ratelimit: refund create quota: %v", refundErr)
+			}
+		}
+		return resp, err
+	}
+}
+
+// RateLimitStreamInterceptor — для потоковых RPC токен списывается при открытии потока.
+func RateLimitStreamInterceptor(l *ratelimit.Limiter, trustForwarded bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !l.Enabled() || !isTicketMethod(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		if _, err := checkRateLimit(ss.Context(), l, info.FullMethod, nil, trustForwarded); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
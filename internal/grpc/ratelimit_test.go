@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/psds-microservice/ticket-service/internal/auth"
+	"github.com/psds-microservice/ticket-service/internal/ratelimit"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+	"github.com/psds-microservice/ticket-service/pkg/gen/ticket_service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateQuotaRefundsWhenNothingIsCreated(t *testing.T) {
+	interceptor := RateLimitUnaryInterceptor(ratelimit.NewLimiter(ratelimit.Config{DailyCreateQuota: 1}, ratelimit.NewMemoryStore()), false)
+	info := &grpc.UnaryServerInfo{FullMethod: ticket_service.TicketService_CreateTicket_FullMethodName}
+	ctx := tenant.WithID(context.Background(), "acme")
+	req := &ticket_service.CreateTicketRequest{SessionId: "s", ClientId: "client"}
+
+	existing := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		ticketNotCreated(ctx)
+		return &ticket_service.Ticket{}, nil
+	}
+	failed := func(context.Context, interface{}) (interface{}, error) {
+		return nil, status.Error(codes.InvalidArgument, "bad")
+	}
+	created := func(context.Context, interface{}) (interface{}, error) { return &ticket_service.Ticket{}, nil }
+
+	for i, handler := range []grpc.UnaryHandler{existing, existing, failed, created} {
+		if _, err := interceptor(ctx, req, info, handler); err != nil && i != 2 {
+			t.Fatalf("call %d: %v", i+1, err)
+		}
+	}
+	_, err := interceptor(ctx, req, info, created)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("create over quota: err = %v, want ResourceExhausted", err)
+	}
+}
+
+func TestCreateQuotaIsKeyedOnPrincipal(t *testing.T) {
+	interceptor := RateLimitUnaryInterceptor(ratelimit.NewLimiter(ratelimit.Config{DailyCreateQuota: 1}, ratelimit.NewMemoryStore()), false)
+	info := &grpc.UnaryServerInfo{FullMethod: ticket_service.TicketService_CreateTicket_FullMethodName}
+	created := func(context.Context, interface{}) (interface{}, error) { return &ticket_service.Ticket{}, nil }
+	req := &ticket_service.CreateTicketRequest{SessionId: "s", ClientId: "victim"}
+	as := func(id string) context.Context {
+		return auth.WithPrincipal(tenant.WithID(context.Background(), "acme"), &auth.Principal{ID: id})
+	}
+
+	// Чужая интеграция с client_id жертвы расходует свою квоту, а не квоту жертвы.
+	if _, err := interceptor(as("intruder"), req, info, created); err != nil {
+		t.Fatalf("intruder create: %v", err)
+	}
+	if _, err := interceptor(as("intruder"), req, info, created); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("intruder over quota: err = %v, want ResourceExhausted", err)
+	}
+	if _, err := interceptor(as("victim"), req, info, created); err != nil {
+		t.Errorf("victim create after intruder used client_id %q: %v", req.ClientId, err)
+	}
+}
+
+func TestCallerKey(t *testing.T) {
+	ctx := context.Background()
+	if got := callerKey(auth.WithPrincipal(ctx, &auth.Principal{ID: "svc"}), false); got != "svc" {
+		t.Errorf("callerKey(principal) = %q, want svc", got)
+	}
+	if got := callerKey(ctx, false); got != "anonymous" {
+		t.Errorf("callerKey(no peer) = %q, want anonymous", got)
+	}
+	// x-caller-id выбирает клиент — ключом он не служит; адрес от gateway берётся только на его сервере.
+	md := metadata.Pairs("x-caller-id", "spoofed", ForwardedPeerHeader, "198.51.100.4")
+	forwarded := metadata.NewIncomingContext(ctx, md)
+	if got := callerKey(forwarded, true); got != "peer:198.51.100.4" {
+		t.Errorf("callerKey(gateway) = %q, want peer:198.51.100.4", got)
+	}
+	if got := callerKey(forwarded, false); got != "anonymous" {
+		t.Errorf("callerKey(forwarded header on the external server) = %q, want anonymous", got)
+	}
+	if got := PeerHost("203.0.113.7:51234"); got != "203.0.113.7" {
+		t.Errorf("PeerHost = %q, want 203.0.113.7", got)
+	}
+	if got := PeerHost("[2001:db8::1]:443"); got != "2001:db8::1" {
+		t.Errorf("PeerHost(IPv6) = %q, want 2001:db8::1", got)
+	}
+}
@@ -0,0 +1,67 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/psds-microservice/ticket-service/internal/service"
+	"github.com/psds-microservice/ticket-service/pkg/gen/ticket_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	searchDefaultLimit = 20
+	searchMaxLimit     = 100
+	// searchMaxOffset — глубже ранжированный поиск не листается: запрос уточняют.
+	searchMaxOffset = 1000
+	// searchMaxQueryLength — предельная длина query в байтах.
+	searchMaxQueryLength = 512
+)
+
+func (s *Server) SearchTickets(ctx context.Context, req *ticket_service.SearchTicketsRequest) (*ticket_service.SearchTicketsResponse, error) {
+	query := strings.TrimSpace(req.GetQuery())
+	if query == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	if len(query) > searchMaxQueryLength {
+		return nil, status.Errorf(codes.InvalidArgument, "query must not exceed %d bytes", searchMaxQueryLength)
+	}
+	if req.GetIncludeDeleted() && !callerIsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "include_deleted requires admin role")
+	}
+	if req.GetLimit() < 0 || req.GetOffset() < 0 || req.GetOffset() > searchMaxOffset {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must not be negative and offset must be between 0 and %d", searchMaxOffset)
+	}
+	filter, err := listFilter(req, req.LegalHold)
+	if err != nil {
+		return nil, err
+	}
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = searchDefaultLimit
+	}
+	if limit > searchMaxLimit {
+		limit = searchMaxLimit
+	}
+
+	hits, err := s.Ticket.Search(ctx, query, filter, service.SearchOptions{
+		Limit:           limit,
+		Offset:          int(req.GetOffset()),
+		IncludeDeleted:  req.GetIncludeDeleted(),
+		IncludeArchived: req.GetIncludeArchived(),
+	})
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	resp := &ticket_service.SearchTicketsResponse{Hits: make([]*ticket_service.SearchHit, len(hits))}
+	for i := range hits {
+		resp.Hits[i] = &ticket_service.SearchHit{
+			Ticket:         toProtoTicket(&hits[i].Ticket),
+			Rank:           float32(hits[i].Rank),
+			SubjectSnippet: hits[i].SubjectSnippet,
+			NotesSnippet:   hits[i].NotesSnippet,
+		}
+	}
+	return resp, nil
+}
@@ -2,16 +2,22 @@ package grpc
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"log"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/psds-microservice/ticket-service/internal/auth"
 	"github.com/psds-microservice/ticket-service/internal/errs"
 	"github.com/psds-microservice/ticket-service/internal/kafka"
 	"github.com/psds-microservice/ticket-service/internal/model"
 	"github.com/psds-microservice/ticket-service/internal/service"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+	"github.com/psds-microservice/ticket-service/internal/watch"
 	"github.com/psds-microservice/ticket-service/pkg/gen/ticket_service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -23,6 +29,30 @@ import (
 type Deps struct {
 	Ticket   service.TicketServicer
 	Producer kafka.TicketEventProducer
+	// SessionDedup — политика повторного CreateTicket для сессии с незакрытым тикетом.
+	SessionDedup service.SessionDedupPolicy
+	// Watch — рассылка изменений подписчикам WatchTickets (nil — WatchTickets недоступен).
+	Watch *watch.Broadcaster
+	// Changes — уведомление других реплик об изменении тикета (nil — только эта реплика).
+	Changes ChangeNotifier
+	// PageTokens — подпись курсоров ListTickets (nil — случайный ключ процесса: токены
+	// не переживают перезапуск и не подходят другим репликам).
+	PageTokens *PageTokens
+	// ListDefaultLimit/ListMaxLimit — размер страницы ListTickets без limit и его максимум.
+	ListDefaultLimit int
+	ListMaxLimit     int
+}
+
+// Значения по умолчанию для Deps.ListDefaultLimit и Deps.ListMaxLimit.
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 500
+)
+
+// ChangeNotifier — публикация изменений для других реплик (реализация — changefeed.Notifier).
+type ChangeNotifier interface {
+	Notify(ctx context.Context, event string, t *model.Ticket)
+	NotifyAll(ctx context.Context, event string, tickets []model.Ticket)
 }
 
 // Server implements ticket_service.TicketServiceServer
@@ -33,6 +63,17 @@ type Server struct {
 
 // NewServer создаёт gRPC-сервер с внедрёнными сервисами
 func NewServer(deps Deps) *Server {
+	if deps.PageTokens == nil {
+		key := make([]byte, 32)
+		_, _ = rand.Read(key)
+		deps.PageTokens = NewPageTokens(key)
+	}
+	if deps.ListDefaultLimit <= 0 {
+		deps.ListDefaultLimit = DefaultListLimit
+	}
+	if deps.ListMaxLimit <= 0 {
+		deps.ListMaxLimit = MaxListLimit
+	}
 	return &Server{Deps: deps}
 }
 
@@ -49,14 +90,53 @@ func getMetadata(ctx context.Context, key string) string {
 	return ""
 }
 
+// callerIsAdmin: роль вызывающего передаётся в метаданных x-caller-role. Для API-ключа
+// решает только его scope — заголовок роли не расширяет права ключа.
+func callerIsAdmin(ctx context.Context) bool {
+	if p, ok := auth.FromContext(ctx); ok && p.Scoped() {
+		return p.HasScope(auth.ScopeTicketsAdmin)
+	}
+	return getMetadata(ctx, "x-caller-role") == "admin"
+}
+
 func (s *Server) mapError(err error) error {
 	if err == nil {
 		return nil
 	}
 	// Обработка известных ошибок домена
-	if errors.Is(err, errs.ErrTicketNotFound) {
+	if errors.Is(err, errs.ErrTicketNotFound) || errors.Is(err, errs.ErrTicketLinkNotFound) {
 		return status.Error(codes.NotFound, err.Error())
 	}
+	if errors.Is(err, errs.ErrInvalidLinkType) || errors.Is(err, errs.ErrSelfLink) || errors.Is(err, errs.ErrInvalidFilter) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, errs.ErrLinkCycle) || errors.Is(err, errs.ErrParentExists) || errors.Is(err, errs.ErrTicketAlreadyMerged) ||
+		errors.Is(err, errs.ErrTicketNotClosed) || errors.Is(err, errs.ErrTicketNotRemoved) ||
+		errors.Is(err, errs.ErrTicketOnLegalHold) || errors.Is(err, errs.ErrTicketNotOnHold) || errors.Is(err, errs.ErrMergeClientMismatch) ||
+		errors.Is(err, errs.ErrSubjectFilterUnavailable) || errors.Is(err, errs.ErrSearchUnavailable) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, errs.ErrLinkExists) {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	if errors.Is(err, errs.ErrSLAUnavailable) {
+		return status.Error(codes.Unimplemented, err.Error())
+	}
+	var openSession *errs.OpenSessionTicketError
+	if errors.As(err, &openSession) {
+		st, detailErr := status.New(codes.AlreadyExists, err.Error()).WithDetails(&errdetails.ErrorInfo{
+			Reason: "OPEN_TICKET_EXISTS",
+			Domain: "ticket-service",
+			Metadata: map[string]string{
+				"ticket_id":  strconv.FormatUint(openSession.TicketID, 10),
+				"session_id": openSession.SessionID,
+			},
+		})
+		if detailErr != nil {
+			return status.Error(codes.AlreadyExists, err.Error())
+		}
+		return st.Err()
+	}
 	// Обработка ошибок GORM
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return status.Error(codes.NotFound, "record not found")
@@ -71,19 +151,108 @@ func (s *Server) mapError(err error) error {
 	return status.Error(codes.Internal, err.Error())
 }
 
-func ticketEventPayload(t *model.Ticket) map[string]interface{} {
+// publishEvent отправляет событие тикета подписчикам WatchTickets, другим репликам и в Kafka. Fire-and-forget: событие должно уйти
+// даже при отмене запроса, поэтому используется собственный контекст с таймаутом.
+func (s *Server) publishEvent(event string, t *model.Ticket) {
 	if t == nil {
+		return
+	}
+	s.Watch.Publish(watch.TypeOf(event), t)
+	if s.Producer == nil && s.Changes == nil {
+		return
+	}
+	payload := kafka.TicketEventPayload(t)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if s.Changes != nil {
+			s.Changes.Notify(ctx, event, t)
+		}
+		if s.Producer != nil {
+			s.Producer.ProduceTicketEvent(ctx, event, payload)
+		}
+	}()
+}
+
+// changeRequest — изменяемые поля UpdateTicketRequest и BulkUpdateTicketsRequest.
+type changeRequest interface {
+	GetSubject() string
+	GetNotes() string
+	GetStatus() string
+	GetPriority() string
+	GetRegion() string
+}
+
+// ticketChanges проверяет изменения из запроса и собирает их для service.Update.
+func ticketChanges(req changeRequest) (map[string]interface{}, error) {
+	changes := make(map[string]interface{})
+	if req.GetSubject() != "" {
+		changes["subject"] = req.GetSubject()
+	}
+	if req.GetNotes() != "" {
+		changes["notes"] = req.GetNotes()
+	}
+	if req.GetStatus() != "" {
+		// Валидация статуса
+		validStatus := model.TicketStatus(req.GetStatus())
+		if validStatus != model.TicketStatusOpen && validStatus != model.TicketStatusInProgress && validStatus != model.TicketStatusClosed {
+			return nil, status.Error(codes.InvalidArgument, "invalid status: must be 'open', 'in_progress', or 'closed'")
+		}
+		changes["status"] = req.GetStatus()
+	}
+	if req.GetPriority() != "" {
+		changes["priority"] = req.GetPriority()
+	}
+	if req.GetRegion() != "" {
+		changes["region"] = req.GetRegion()
+	}
+	if len(changes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no changes provided")
+	}
+	return changes, nil
+}
+
+// publishEvents — publishEvent для пачки тикетов: одно уведомление реплик и одна запись в Kafka.
+func (s *Server) publishEvents(event string, tickets []model.Ticket) {
+	if len(tickets) == 0 {
+		return
+	}
+	for i := range tickets {
+		s.Watch.Publish(watch.TypeOf(event), &tickets[i])
+	}
+	if s.Producer == nil && s.Changes == nil {
+		return
+	}
+	payloads := make([]map[string]interface{}, len(tickets))
+	for i := range tickets {
+		payloads[i] = kafka.TicketEventPayload(&tickets[i])
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if s.Changes != nil {
+			s.Changes.NotifyAll(ctx, event, tickets)
+		}
+		if s.Producer != nil {
+			s.Producer.ProduceTicketEvents(ctx, event, payloads)
+		}
+	}()
+}
+
+// authorizeTicketWrite: изменять тикет может только его клиент или назначенный оператор
+// (или сервис с API-ключом tickets:admin).
+func authorizeTicketWrite(ctx context.Context, t *model.Ticket) error {
+	if p, ok := auth.FromContext(ctx); ok && p.Scoped() && p.HasScope(auth.ScopeTicketsAdmin) {
 		return nil
 	}
-	return map[string]interface{}{
-		"ticket_id":   int64(t.ID),
-		"session_id":  t.SessionID,
-		"client_id":   t.ClientID,
-		"operator_id": t.OperatorID,
-		"subject":     t.Subject,
-		"notes":       t.Notes,
-		"status":      string(t.Status),
+	callerID := getMetadata(ctx, "x-caller-id")
+	if callerID == "" {
+		return status.Error(codes.PermissionDenied, "caller identity required (x-caller-id)")
 	}
+	if t.ClientID != callerID && t.OperatorID != callerID {
+		return status.Error(codes.PermissionDenied, "caller is not the ticket client or assigned operator")
+	}
+	return nil
 }
 
 func toProtoTicket(t *model.Ticket) *ticket_service.Ticket {
@@ -100,6 +269,9 @@ func toProtoTicket(t *model.Ticket) *ticket_service.Ticket {
 		Region:     t.Region,
 		Subject:    t.Subject,
 		Notes:      t.Notes,
+		SessionIds: t.SessionIDs(),
+		Reference:  t.Reference,
+		TenantId:   t.TenantID,
 	}
 	if !t.CreatedAt.IsZero() {
 		out.CreatedAt = timestamppb.New(t.CreatedAt)
@@ -110,6 +282,26 @@ func toProtoTicket(t *model.Ticket) *ticket_service.Ticket {
 	if t.ClosedAt != nil {
 		out.ClosedAt = timestamppb.New(*t.ClosedAt)
 	}
+	if t.MergedIntoID != nil {
+		out.MergedIntoId = int64(*t.MergedIntoID)
+	}
+	if t.DeletedAt.Valid {
+		out.DeletedAt = timestamppb.New(t.DeletedAt.Time)
+	}
+	if t.ArchivedAt != nil {
+		out.ArchivedAt = timestamppb.New(*t.ArchivedAt)
+	}
+	if t.ErasedAt != nil {
+		out.ErasedAt = timestamppb.New(*t.ErasedAt)
+	}
+	if t.LegalHold {
+		out.LegalHold = true
+		out.LegalHoldReason = t.LegalHoldReason
+		out.LegalHoldCaseId = t.LegalHoldCaseID
+		if t.LegalHoldAt != nil {
+			out.LegalHoldAt = timestamppb.New(*t.LegalHoldAt)
+		}
+	}
 	return out
 }
 
@@ -140,60 +332,127 @@ func (s *Server) CreateTicket(ctx context.Context, req *ticket_service.CreateTic
 		Region:     req.GetRegion(),
 		Subject:    req.GetSubject(),
 		Notes:      req.GetNotes(),
+
+		AllowDuplicateSession: s.SessionDedup == service.SessionDedupAllow,
 	}
 	if err := s.Ticket.Create(ctx, ticket); err != nil {
+		var openSession *errs.OpenSessionTicketError
+		if errors.As(err, &openSession) && s.SessionDedup == service.SessionDedupReturnExisting {
+			existing, getErr := s.Ticket.GetByID(ctx, openSession.TicketID)
+			if getErr != nil {
+				return nil, s.mapError(getErr)
+			}
+			ticketNotCreated(ctx)
+			return toProtoTicket(existing), nil
+		}
 		return nil, s.mapError(err)
 	}
-	// Fire-and-forget: событие должно уйти даже при отмене запроса, но с таймаутом
-	if s.Producer != nil {
-		payload := ticketEventPayload(ticket)
-		eventCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		go s.Producer.ProduceTicketEvent(eventCtx, "ticket.created", payload)
+	s.publishEvent("ticket.created", ticket)
+	if req.GetCheckDuplicates() {
+		s.reportDuplicates(ctx, req, ticket.ID)
 	}
 	return toProtoTicket(ticket), nil
 }
 
 func (s *Server) GetTicket(ctx context.Context, req *ticket_service.GetTicketRequest) (*ticket_service.Ticket, error) {
-	ticket, err := s.Ticket.GetByID(ctx, uint64(req.GetId()))
+	get := s.Ticket.GetByID
+	if req.GetIncludeArchived() {
+		get = s.Ticket.GetByIDWithArchived
+	}
+	if req.GetIncludeDeleted() {
+		if !callerIsAdmin(ctx) {
+			return nil, status.Error(codes.PermissionDenied, "include_deleted requires admin role")
+		}
+		get = s.Ticket.GetByIDWithDeleted
+	}
+	ticket, err := get(ctx, uint64(req.GetId()))
 	if err != nil {
 		return nil, s.mapError(err)
 	}
 	return toProtoTicket(ticket), nil
 }
 
-func (s *Server) ListTickets(ctx context.Context, req *ticket_service.ListTicketsRequest) (*ticket_service.ListTicketsResponse, error) {
-	filter := make(map[string]interface{})
-	if req.GetClientId() != "" {
-		filter["client_id = ?"] = req.GetClientId()
+func (s *Server) GetTicketByReference(ctx context.Context, req *ticket_service.GetTicketByReferenceRequest) (*ticket_service.Ticket, error) {
+	if req.GetRef() == "" {
+		return nil, status.Error(codes.InvalidArgument, "ref is required")
 	}
-	if req.GetOperatorId() != "" {
-		filter["operator_id = ?"] = req.GetOperatorId()
+	ticket, err := s.Ticket.GetByReference(ctx, req.GetRef(), req.GetIncludeArchived())
+	if err != nil {
+		return nil, s.mapError(err)
 	}
-	if req.GetStatus() != "" {
-		filter["status = ?"] = req.GetStatus()
+	return toProtoTicket(ticket), nil
+}
+
+func (s *Server) ListTickets(ctx context.Context, req *ticket_service.ListTicketsRequest) (*ticket_service.ListTicketsResponse, error) {
+	filter, err := listFilter(req, req.LegalHold)
+	if err != nil {
+		return nil, err
 	}
-	if req.GetRegion() != "" {
-		filter["region = ?"] = req.GetRegion()
+	order, err := service.ParseListOrder(req.GetOrderBy())
+	if err != nil {
+		return nil, s.mapError(err)
 	}
 
+	if req.GetIncludeDeleted() && !callerIsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "include_deleted requires admin role")
+	}
+	if req.GetLimit() < 0 || req.GetOffset() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit and offset must not be negative")
+	}
 	limit := int(req.GetLimit())
-	offset := int(req.GetOffset())
+	if limit == 0 {
+		limit = s.ListDefaultLimit
+	}
+	if limit > s.ListMaxLimit {
+		limit = s.ListMaxLimit
+	}
+	opts := service.ListOptions{
+		Limit:           limit,
+		Offset:          int(req.GetOffset()),
+		Order:           order,
+		IncludeDeleted:  req.GetIncludeDeleted(),
+		IncludeArchived: req.GetIncludeArchived(),
+	}
+	switch mode := service.TotalMode(req.GetTotalMode()); mode {
+	case "":
+		opts.Total = service.TotalExact
+	case service.TotalExact, service.TotalEstimate, service.TotalNone:
+		opts.Total = mode
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "total_mode must be exact, estimate or none, got %q", mode)
+	}
+	tenantID, _ := tenant.FromContext(ctx)
+	fingerprint := filterFingerprint(tenantID, filter, order, opts.IncludeDeleted, opts.IncludeArchived)
+	if req.GetPageToken() != "" {
+		if opts.Offset > 0 {
+			return nil, status.Error(codes.InvalidArgument, "page_token and offset are mutually exclusive")
+		}
+		after, err := s.PageTokens.Decode(req.GetPageToken(), fingerprint)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		opts.After = after
+	}
 
-	tickets, total, err := s.Ticket.List(ctx, filter, limit, offset)
+	page, err := s.Ticket.List(ctx, filter, opts)
 	if err != nil {
 		return nil, s.mapError(err)
 	}
 
-	protoTickets := make([]*ticket_service.Ticket, len(tickets))
-	for i, t := range tickets {
+	protoTickets := make([]*ticket_service.Ticket, len(page.Items))
+	for i, t := range page.Items {
 		protoTickets[i] = toProtoTicket(&t)
 	}
-
-	return &ticket_service.ListTicketsResponse{
-		Tickets: protoTickets,
-		Total:   int32(total),
-	}, nil
+	resp := &ticket_service.ListTicketsResponse{
+		Tickets:        protoTickets,
+		Total:          int32(page.Total),
+		TotalEstimated: page.TotalEstimated,
+	}
+	// Для offset-клиентов токен тоже выдаётся: с него можно продолжить без offset.
+	if page.Next != nil {
+		resp.NextPageToken = s.PageTokens.Encode(page.Next, fingerprint)
+	}
+	return resp, nil
 }
 
 func (s *Server) UpdateTicket(ctx context.Context, req *ticket_service.UpdateTicketRequest) (*ticket_service.Ticket, error) {
@@ -206,50 +465,26 @@ func (s *Server) UpdateTicket(ctx context.Context, req *ticket_service.UpdateTic
 	if err != nil {
 		return nil, s.mapError(err)
 	}
-	callerID := getMetadata(ctx, "x-caller-id")
-	if callerID == "" {
-		return nil, status.Error(codes.PermissionDenied, "caller identity required (x-caller-id)")
-	}
-	if ticket.ClientID != callerID && ticket.OperatorID != callerID {
-		return nil, status.Error(codes.PermissionDenied, "caller is not the ticket client or assigned operator")
-	}
-	changes := make(map[string]interface{})
-	if req.GetSubject() != "" {
-		changes["subject"] = req.GetSubject()
-	}
-	if req.GetNotes() != "" {
-		changes["notes"] = req.GetNotes()
-	}
-	if req.GetStatus() != "" {
-		// Валидация статуса
-		validStatus := model.TicketStatus(req.GetStatus())
-		if validStatus != model.TicketStatusOpen && validStatus != model.TicketStatusInProgress && validStatus != model.TicketStatusClosed {
-			return nil, status.Error(codes.InvalidArgument, "invalid status: must be 'open', 'in_progress', or 'closed'")
-		}
-		changes["status"] = req.GetStatus()
-	}
-	if req.GetPriority() != "" {
-		changes["priority"] = req.GetPriority()
+	if err := authorizeTicketWrite(ctx, ticket); err != nil {
+		return nil, err
 	}
-	if req.GetRegion() != "" {
-		changes["region"] = req.GetRegion()
+	changes, err := ticketChanges(req)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(changes) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "no changes provided")
+	var children []model.Ticket
+	if req.GetCascadeCloseChildren() && model.TicketStatus(req.GetStatus()) == model.TicketStatusClosed {
+		ticket, children, err = s.Ticket.UpdateCascade(ctx, uint64(req.GetId()), changes)
+	} else {
+		ticket, err = s.Ticket.Update(ctx, uint64(req.GetId()), changes)
 	}
-
-	ticket, err = s.Ticket.Update(ctx, uint64(req.GetId()), changes)
 	if err != nil {
 		return nil, s.mapError(err)
 	}
-	// Fire-and-forget: событие должно уйти даже при отмене запроса, но с таймаутом
-	if s.Producer != nil {
-		if full, _ := s.Ticket.GetByID(ctx, uint64(req.GetId())); full != nil {
-			eventCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			defer cancel()
-			go s.Producer.ProduceTicketEvent(eventCtx, "ticket.updated", ticketEventPayload(full))
-		}
+	s.publishEvent("ticket.updated", ticket)
+	for i := range children {
+		s.publishEvent("ticket.updated", &children[i])
 	}
 	return toProtoTicket(ticket), nil
 }
@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/psds-microservice/ticket-service/pkg/gen/ticket_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) AttachSession(ctx context.Context, req *ticket_service.AttachSessionRequest) (*ticket_service.Ticket, error) {
+	if req.GetTicketId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "ticket_id must be greater than 0")
+	}
+	if req.GetSessionId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+	ticket, err := s.Ticket.GetByID(ctx, uint64(req.GetTicketId()))
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	if err := authorizeTicketWrite(ctx, ticket); err != nil {
+		return nil, err
+	}
+	ticket, err = s.Ticket.AttachSession(ctx, uint64(req.GetTicketId()), req.GetSessionId())
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	s.publishEvent("ticket.updated", ticket)
+	return toProtoTicket(ticket), nil
+}
+
+func (s *Server) GetTicketsBySession(ctx context.Context, req *ticket_service.GetTicketsBySessionRequest) (*ticket_service.ListTicketsResponse, error) {
+	if req.GetSessionId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+	tickets, err := s.Ticket.ListBySession(ctx, req.GetSessionId())
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	protoTickets := make([]*ticket_service.Ticket, len(tickets))
+	for i := range tickets {
+		protoTickets[i] = toProtoTicket(&tickets[i])
+	}
+	return &ticket_service.ListTicketsResponse{
+		Tickets: protoTickets,
+		Total:   int32(len(tickets)),
+	}, nil
+}
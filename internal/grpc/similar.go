@@ -0,0 +1,110 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/psds-microservice/ticket-service/internal/service"
+	"github.com/psds-microservice/ticket-service/pkg/gen/ticket_service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	similarDefaultLimit = 10
+	similarMaxLimit     = 50
+	similarMaxWindow    = 365
+	// similarMaxTextLength — предельная длина subject + notes в байтах.
+	similarMaxTextLength = 8192
+	// createDuplicatesLimit — сколько похожих тикетов CreateTicket возвращает в метаданных.
+	createDuplicatesLimit = 5
+	// mdSimilarTicketIDs — ID похожих тикетов в ответе CreateTicket (check_duplicates).
+	mdSimilarTicketIDs = "x-similar-ticket-ids"
+)
+
+func (s *Server) FindSimilarTickets(ctx context.Context, req *ticket_service.FindSimilarTicketsRequest) (*ticket_service.FindSimilarTicketsResponse, error) {
+	if strings.TrimSpace(req.GetSubject()+req.GetNotes()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "subject or notes is required")
+	}
+	if len(req.GetSubject())+len(req.GetNotes()) > similarMaxTextLength {
+		return nil, status.Errorf(codes.InvalidArgument, "subject and notes must not exceed %d bytes together", similarMaxTextLength)
+	}
+	if req.GetWindowDays() < 0 || req.GetWindowDays() > similarMaxWindow {
+		return nil, status.Errorf(codes.InvalidArgument, "window_days must be between 0 and %d", similarMaxWindow)
+	}
+	if m := req.GetMinSimilarity(); m != 0 && (m < service.SimilarityIndexThreshold || m > 1) {
+		return nil, status.Errorf(codes.InvalidArgument, "min_similarity must be between %.1f and 1", service.SimilarityIndexThreshold)
+	}
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+	window := service.DefaultSimilarWindow
+	if req.GetWindowDays() > 0 {
+		window = time.Duration(req.GetWindowDays()) * 24 * time.Hour
+	}
+	minSimilarity := service.DefaultMinSimilarity
+	if req.GetMinSimilarity() != 0 {
+		minSimilarity = float64(req.GetMinSimilarity())
+	}
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = similarDefaultLimit
+	}
+	if limit > similarMaxLimit {
+		limit = similarMaxLimit
+	}
+
+	similar, err := s.Ticket.FindSimilar(ctx, service.SimilarQuery{
+		Subject:       req.GetSubject(),
+		Notes:         req.GetNotes(),
+		ClientID:      req.GetClientId(),
+		Since:         time.Now().Add(-window),
+		MinSimilarity: minSimilarity,
+		Limit:         limit,
+		IncludeClosed: req.GetIncludeClosed(),
+	})
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	resp := &ticket_service.FindSimilarTicketsResponse{Tickets: make([]*ticket_service.SimilarTicket, len(similar))}
+	for i := range similar {
+		resp.Tickets[i] = &ticket_service.SimilarTicket{
+			Ticket:     toProtoTicket(&similar[i].Ticket),
+			Similarity: float32(similar[i].Similarity),
+		}
+	}
+	return resp, nil
+}
+
+// reportDuplicates добавляет в заголовки ответа CreateTicket ID похожих открытых тикетов клиента.
+// Best-effort: ошибка поиска не мешает созданию тикета.
+func (s *Server) reportDuplicates(ctx context.Context, req *ticket_service.CreateTicketRequest, ticketID uint64) {
+	similar, err := s.Ticket.FindSimilar(ctx, service.SimilarQuery{
+		Subject:       req.GetSubject(),
+		Notes:         req.GetNotes(),
+		ClientID:      req.GetClientId(),
+		Since:         time.Now().Add(-service.DefaultSimilarWindow),
+		MinSimilarity: service.DefaultMinSimilarity,
+		Limit:         createDuplicatesLimit,
+		ExcludeID:     ticketID,
+	})
+	if err != nil {
+		log.Printf("grpc: check duplicates of ticket %d: %v", ticketID, err)
+		return
+	}
+	if len(similar) == 0 {
+		return
+	}
+	ids := make([]string, len(similar))
+	for i := range similar {
+		ids[i] = strconv.FormatUint(similar[i].Ticket.ID, 10)
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(mdSimilarTicketIDs, strings.Join(ids, ","))); err != nil {
+		log.Printf("grpc: set %s header: %v", mdSimilarTicketIDs, err)
+	}
+}
@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/psds-microservice/ticket-service/internal/auth"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+	"github.com/psds-microservice/ticket-service/pkg/gen/ticket_service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tenantHeader — метаданные с тенантом запроса (REST: заголовок X-Tenant-Id).
+const tenantHeader = "x-tenant-id"
+
+// resolveTenant: тенант аутентифицированного principal, иначе x-tenant-id, иначе defaultTenant.
+// x-tenant-id, расходящийся с тенантом principal, — отказ: чужой тенант недоступен.
+func resolveTenant(ctx context.Context, defaultTenant string) (string, error) {
+	header := getMetadata(ctx, tenantHeader)
+	if p, ok := auth.FromContext(ctx); ok && p.TenantID != "" {
+		if header != "" && header != p.TenantID {
+			return "", status.Error(codes.PermissionDenied, "x-tenant-id does not match the caller's tenant")
+		}
+		return p.TenantID, nil
+	}
+	id := header
+	if id == "" {
+		id = defaultTenant
+	}
+	if id == "" {
+		return "", status.Error(codes.Unauthenticated, "tenant required (x-tenant-id)")
+	}
+	if !tenant.Valid(id) {
+		return "", status.Errorf(codes.InvalidArgument, "invalid tenant id %q", id)
+	}
+	return id, nil
+}
+
+// isTicketMethod: тенант нужен только методам TicketService (reflection и т.п. — без него).
+func isTicketMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+ticket_service.TicketService_ServiceDesc.ServiceName+"/")
+}
+
+// TenantUnaryInterceptor кладёт тенант запроса в контекст; сервисный слой ограничивает им все запросы.
+func TenantUnaryInterceptor(defaultTenant string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !isTicketMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		id, err := resolveTenant(ctx, defaultTenant)
+		if err != nil {
+			return nil, err
+		}
+		return handler(tenant.WithID(ctx, id), req)
+	}
+}
+
+// TenantStreamInterceptor — то же для потоковых RPC.
+func TenantStreamInterceptor(defaultTenant string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !isTicketMethod(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		id, err := resolveTenant(ss.Context(), defaultTenant)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: tenant.WithID(ss.Context(), id)})
+	}
+}
+
+// wrappedStream подменяет контекст потока (перехватчики кладут в него тенант, principal).
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *wrappedStream) Context() context.Context { return s.ctx }
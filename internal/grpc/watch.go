@@ -0,0 +1,207 @@
+package grpc
+
+import (
+	"errors"
+
+	"github.com/psds-microservice/ticket-service/internal/celfilter"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/internal/service"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+	"github.com/psds-microservice/ticket-service/internal/watch"
+	"github.com/psds-microservice/ticket-service/pkg/gen/ticket_service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// watchSnapshotLimit — размер снимка WatchTickets по умолчанию и максимальный.
+const watchSnapshotLimit = 1000
+
+// Типы сообщений снимка (события — watch.Type*).
+const (
+	eventSnapshot    = "snapshot"
+	eventSnapshotEnd = "snapshot_end"
+)
+
+// filterRequest — общие фильтры ListTicketsRequest и WatchTicketsRequest.
+type filterRequest interface {
+	GetClientId() string
+	GetOperatorId() string
+	GetStatus() string
+	GetRegion() string
+	GetStatuses() []string
+	GetPriorities() []string
+	GetSessionId() string
+	GetUnassigned() bool
+	GetCreatedFrom() *timestamppb.Timestamp
+	GetCreatedTo() *timestamppb.Timestamp
+	GetUpdatedFrom() *timestamppb.Timestamp
+	GetUpdatedTo() *timestamppb.Timestamp
+	GetClosedFrom() *timestamppb.Timestamp
+	GetClosedTo() *timestamppb.Timestamp
+	GetSubjectContains() string
+	GetFilter() string
+}
+
+// validListStatuses — статусы, по которым можно фильтровать.
+var validListStatuses = map[model.TicketStatus]bool{
+	model.TicketStatusOpen:       true,
+	model.TicketStatusInProgress: true,
+	model.TicketStatusClosed:     true,
+	model.TicketStatusMerged:     true,
+}
+
+func timeRange(from, to *timestamppb.Timestamp) service.TimeRange {
+	var r service.TimeRange
+	if from != nil {
+		t := from.AsTime()
+		r.From = &t
+	}
+	if to != nil {
+		t := to.AsTime()
+		r.To = &t
+	}
+	return r
+}
+
+// listFilter собирает service.ListFilter из запроса и проверяет значения.
+func listFilter(req filterRequest, legalHold *bool) (service.ListFilter, error) {
+	f := service.ListFilter{
+		ClientID:        req.GetClientId(),
+		OperatorID:      req.GetOperatorId(),
+		Region:          req.GetRegion(),
+		Priorities:      req.GetPriorities(),
+		LegalHold:       legalHold,
+		SessionID:       req.GetSessionId(),
+		Unassigned:      req.GetUnassigned(),
+		Created:         timeRange(req.GetCreatedFrom(), req.GetCreatedTo()),
+		Updated:         timeRange(req.GetUpdatedFrom(), req.GetUpdatedTo()),
+		Closed:          timeRange(req.GetClosedFrom(), req.GetClosedTo()),
+		SubjectContains: req.GetSubjectContains(),
+	}
+	statuses := req.GetStatuses()
+	if req.GetStatus() != "" {
+		statuses = append([]string{req.GetStatus()}, statuses...)
+	}
+	for _, st := range statuses {
+		if !validListStatuses[model.TicketStatus(st)] {
+			return f, status.Errorf(codes.InvalidArgument, "invalid status %q: must be 'open', 'in_progress', 'closed' or 'merged'", st)
+		}
+		f.Statuses = append(f.Statuses, model.TicketStatus(st))
+	}
+	if req.GetFilter() != "" {
+		expr, err := celfilter.Compile(req.GetFilter())
+		if err != nil {
+			return f, filterError(err)
+		}
+		f.Expr = expr
+	}
+	if err := f.Validate(); err != nil {
+		return f, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return f, nil
+}
+
+// filterError — INVALID_ARGUMENT с позицией ошибки в выражении filter (BadRequest).
+func filterError(err error) error {
+	st, detailErr := status.New(codes.InvalidArgument, err.Error()).WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "filter", Description: err.Error()}},
+	})
+	if detailErr != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return st.Err()
+}
+
+// visible — тикет виден с учётом include_deleted/include_archived (как строка в выдаче service.List).
+func visible(t *model.Ticket, includeDeleted, includeArchived bool) bool {
+	return (includeDeleted || !t.DeletedAt.Valid) && (includeArchived || t.ArchivedAt == nil)
+}
+
+func (s *Server) WatchTickets(req *ticket_service.WatchTicketsRequest, stream ticket_service.TicketService_WatchTicketsServer) error {
+	ctx := stream.Context()
+	if s.Watch == nil {
+		return status.Error(codes.Unimplemented, "ticket watch is not enabled")
+	}
+	if req.GetIncludeDeleted() && !callerIsAdmin(ctx) {
+		return status.Error(codes.PermissionDenied, "include_deleted requires admin role")
+	}
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "tenant required (x-tenant-id)")
+	}
+	filter, err := listFilter(req, req.LegalHold)
+	if err != nil {
+		return err
+	}
+	includeDeleted, includeArchived := req.GetIncludeDeleted(), req.GetIncludeArchived()
+	limit := int(req.GetSnapshotLimit())
+	if limit <= 0 || limit > watchSnapshotLimit {
+		limit = watchSnapshotLimit
+	}
+
+	// Подписка — до чтения снимка: изменения, сделанные во время чтения, не теряются
+	// (придут событием после снимка).
+	sub, resumed := s.Watch.Subscribe(req.GetResumeToken())
+	defer sub.Close()
+	// Заголовки сразу: клиент (SSE/WebSocket-прокси) узнаёт, что поток принят, не дожидаясь
+	// первого события возобновлённого потока.
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+	// sent — тикеты, уже отправленные в этот поток: их изменения приходят, даже если тикет
+	// перестал подходить под фильтр.
+	sent := make(map[uint64]bool)
+	if !resumed {
+		page, err := s.Ticket.List(ctx, filter, service.ListOptions{
+			Limit:           limit,
+			Total:           service.TotalNone,
+			IncludeDeleted:  includeDeleted,
+			IncludeArchived: includeArchived,
+		})
+		if err != nil {
+			return s.mapError(err)
+		}
+		tickets := page.Items
+		for i := range tickets {
+			if err := stream.Send(&ticket_service.TicketEvent{Type: eventSnapshot, Ticket: toProtoTicket(&tickets[i])}); err != nil {
+				return err
+			}
+			sent[tickets[i].ID] = true
+		}
+		if err := stream.Send(&ticket_service.TicketEvent{Type: eventSnapshotEnd, ResumeToken: s.Watch.Token(sub.Seq())}); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-sub.Events():
+			if !ok {
+				if errors.Is(sub.Err(), watch.ErrSlowSubscriber) {
+					return status.Error(codes.Aborted, "watch stream fell behind; reconnect with the last resume_token")
+				}
+				return status.Error(codes.Unavailable, "server is shutting down; reconnect with the last resume_token")
+			}
+			t := &ev.Ticket
+			if t.TenantID != tenantID {
+				continue
+			}
+			// Удаление видно и без include_deleted: клиент убирает тикет из выборки.
+			check := *t
+			if ev.Type == watch.TypeDeleted {
+				check.DeletedAt.Valid = false
+			}
+			if !(filter.Matches(&check) && visible(&check, includeDeleted, includeArchived)) && !sent[t.ID] {
+				continue
+			}
+			sent[t.ID] = true
+			if err := stream.Send(&ticket_service.TicketEvent{Type: ev.Type, Ticket: toProtoTicket(t), ResumeToken: s.Watch.Token(ev.Seq)}); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"github.com/psds-microservice/ticket-service/pkg/gen/ticket_service"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// streamHeartbeat — пауза без событий, после которой отправляется heartbeat
+	// (прокси и балансировщики не закрывают «молчащее» соединение).
+	streamHeartbeat = 15 * time.Second
+	// streamWriteTimeout — клиент, не принявший сообщение за это время, отключается.
+	streamWriteTimeout = 10 * time.Second
+	// streamBuffer — сообщения, ждущие отправки клиенту. Переполнение — клиент не успевает
+	// читать и отключается, не задерживая поток WatchTickets и остальных подписчиков.
+	streamBuffer = 64
+	// sseRetry — через сколько миллисекунд EventSource переподключается после обрыва.
+	sseRetry = 2000
+)
+
+var errSlowClient = errors.New("client is too slow to receive ticket events")
+
+// eventJSON — сообщения в том же JSON, что и ответы REST (grpc-gateway).
+var eventJSON = protojson.MarshalOptions{EmitUnpopulated: true}
+
+// Annotator переносит заголовки HTTP-запроса (авторизация, тенант, вызывающий) в исходящие
+// gRPC-метаданные — так же, как grpc-gateway для REST.
+type Annotator func(ctx context.Context, r *http.Request) (context.Context, error)
+
+// TicketStream — GET /api/v1/tickets/stream для браузеров: Server-Sent Events, а с заголовком
+// Upgrade: websocket — WebSocket. Это клиент WatchTickets внутреннего gRPC-сервера, поэтому
+// фильтры (query-параметры WatchTicketsRequest), аутентификация, тенант и rate limiting — те же, что у REST.
+// Возобновление: Last-Event-ID (SSE) или параметр resume_token.
+type TicketStream struct {
+	client   ticket_service.TicketServiceClient
+	annotate Annotator
+}
+
+func NewTicketStream(client ticket_service.TicketServiceClient, annotate Annotator) *TicketStream {
+	return &TicketStream{client: client, annotate: annotate}
+}
+
+func (s *TicketStream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		websocket.Handler(func(ws *websocket.Conn) { s.serveWebSocket(ws, r) }).ServeHTTP(w, r)
+		return
+	}
+	s.serveSSE(w, r)
+}
+
+// watchRequest разбирает query-параметры так же, как grpc-gateway.
+func watchRequest(r *http.Request) (*ticket_service.WatchTicketsRequest, error) {
+	req := &ticket_service.WatchTicketsRequest{}
+	if err := runtime.PopulateQueryParameters(req, r.URL.Query(), utilities.NewDoubleArray(nil)); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		req.ResumeToken = id
+	}
+	return req, nil
+}
+
+// open начинает WatchTickets и ждёт заголовков ответа: ошибки авторизации и фильтров
+// возвращаются до того, как клиенту отправлен ответ.
+func (s *TicketStream) open(ctx context.Context, r *http.Request) (ticket_service.TicketService_WatchTicketsClient, error) {
+	req, err := watchRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = s.annotate(ctx, r)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	stream, err := s.client.WatchTickets(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if md, err := stream.Header(); err != nil || md == nil {
+		// Поток завершился без заголовков — статус ошибки отдаёт Recv.
+		if _, err := stream.Recv(); err != nil {
+			return nil, err
+		}
+		return nil, status.Error(codes.Internal, "watch stream closed without headers")
+	}
+	return stream, nil
+}
+
+// pump читает поток в буфер ограниченного размера. Канал закрывается по окончании потока;
+// причина — в *errp (errSlowClient, если буфер переполнен).
+func pump(cancel context.CancelFunc, stream ticket_service.TicketService_WatchTicketsClient, errp *error) <-chan *ticket_service.TicketEvent {
+	ch := make(chan *ticket_service.TicketEvent, streamBuffer)
+	go func() {
+		defer close(ch)
+		for {
+			ev, err := stream.Recv()
+			if err != nil {
+				*errp = err
+				return
+			}
+			select {
+			case ch <- ev:
+			default:
+				*errp = errSlowClient
+				cancel()
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// streamError — JSON ошибки в формате ответов grpc-gateway.
+func streamError(err error) ([]byte, int) {
+	if errors.Is(err, errSlowClient) {
+		err = status.Error(codes.Aborted, err.Error())
+	}
+	st := status.Convert(err)
+	body, _ := json.Marshal(map[string]interface{}{"code": int(st.Code()), "message": st.Message()})
+	return body, runtime.HTTPStatusFromCode(st.Code())
+}
+
+func (s *TicketStream) serveSSE(w http.ResponseWriter, r *http.Request) {
+	rc := http.NewResponseController(w)
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	stream, err := s.open(ctx, r)
+	if err != nil {
+		body, code := streamError(err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		w.Write(body)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	write := func(format string, args ...interface{}) error {
+		_ = rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+	if write("retry: %d\n\n", sseRetry) != nil {
+		return
+	}
+	var streamErr error
+	events := pump(cancel, stream, &streamErr)
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-heartbeat.C:
+			if write(": heartbeat\n\n") != nil {
+				return
+			}
+		case ev, ok := <-events:
+			if !ok {
+				if r.Context().Err() == nil {
+					// EventSource переподключится сам и передаст Last-Event-ID.
+					body, _ := streamError(streamErr)
+					_ = write("event: error\ndata: %s\n\n", body)
+				}
+				return
+			}
+			data, err := eventJSON.Marshal(ev)
+			if err != nil {
+				log.Printf("stream: marshal event: %v", err)
+				continue
+			}
+			id := ""
+			if ev.GetResumeToken() != "" {
+				id = "id: " + ev.GetResumeToken() + "\n"
+			}
+			if write("%sevent: %s\ndata: %s\n\n", id, ev.GetType(), data) != nil {
+				return
+			}
+			heartbeat.Reset(streamHeartbeat)
+		}
+	}
+}
+
+func (s *TicketStream) serveWebSocket(ws *websocket.Conn, r *http.Request) {
+	defer ws.Close()
+	// Таймауты http.Server остаются на захваченном соединении — снимаем их.
+	_ = ws.SetDeadline(time.Time{})
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	write := func(frame byte, msg []byte) error {
+		_ = ws.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		ws.PayloadType = frame
+		_, err := ws.Write(msg)
+		return err
+	}
+	stream, err := s.open(ctx, r)
+	if err != nil {
+		body, _ := streamError(err)
+		_ = write(websocket.TextFrame, body)
+		return
+	}
+	// Входящие кадры только читаются: так обрабатываются ping/close от клиента и замечается отключение.
+	go func() {
+		defer cancel()
+		var discard []byte
+		for websocket.Message.Receive(ws, &discard) == nil {
+		}
+	}()
+
+	var streamErr error
+	events := pump(cancel, stream, &streamErr)
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-heartbeat.C:
+			if write(websocket.PingFrame, nil) != nil {
+				return
+			}
+		case ev, ok := <-events:
+			if !ok {
+				if ctx.Err() == nil || errors.Is(streamErr, errSlowClient) {
+					body, _ := streamError(streamErr)
+					_ = write(websocket.TextFrame, body)
+				}
+				return
+			}
+			data, err := eventJSON.Marshal(ev)
+			if err != nil {
+				log.Printf("stream: marshal event: %v", err)
+				continue
+			}
+			if write(websocket.TextFrame, data) != nil {
+				return
+			}
+			heartbeat.Reset(streamHeartbeat)
+		}
+	}
+}
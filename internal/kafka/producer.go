@@ -7,27 +7,36 @@ import (
 	"strings"
 	"time"
 
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/internal/redact"
 	"github.com/segmentio/kafka-go"
 )
 
 // TicketEventProducer — интерфейс для отправки событий тикета в Kafka (для подмены моком в тестах).
 type TicketEventProducer interface {
 	ProduceTicketEvent(ctx context.Context, event string, payload map[string]interface{})
+	ProduceTicketEvents(ctx context.Context, event string, payloads []map[string]interface{})
 }
 
 // Producer пишет события тикетов в топик Kafka (best-effort, не блокирует API).
 type Producer struct {
-	writer *kafka.Writer
-	topic  string
+	writer   *kafka.Writer
+	topic    string
+	redactor *redact.Redactor
 }
 
+// redactedFields — текстовые поля payload, из которых вычищаются персональные данные.
+var redactedFields = []string{"subject", "notes"}
+
 // NewProducer создаёт продюсер. Если brokers пустой или topic пустой — методы no-op.
-func NewProducer(brokers []string, topic string) *Producer {
+// redactor (может быть nil) применяется к subject и notes каждого события.
+func NewProducer(brokers []string, topic string, redactor *redact.Redactor) *Producer {
 	if len(brokers) == 0 || topic == "" {
 		return &Producer{}
 	}
 	return &Producer{
-		topic: topic,
+		topic:    topic,
+		redactor: redactor,
 		writer: &kafka.Writer{
 			Addr:         kafka.TCP(brokers...),
 			Topic:        topic,
@@ -37,23 +46,66 @@ func NewProducer(brokers []string, topic string) *Producer {
 	}
 }
 
-// ProduceTicketEvent отправляет событие тикета в топик. payload: ticket_id, session_id, client_id, operator_id, subject, notes, status.
+// TicketEventPayload — payload события тикета: ticket_id, tenant_id, reference, session_id, session_ids,
+// client_id, operator_id, subject, notes, status и merged_into_ticket_id для влитых тикетов.
+func TicketEventPayload(t *model.Ticket) map[string]interface{} {
+	if t == nil {
+		return nil
+	}
+	payload := map[string]interface{}{
+		"ticket_id":   int64(t.ID),
+		"tenant_id":   t.TenantID,
+		"reference":   t.Reference,
+		"session_id":  t.SessionID,
+		"session_ids": t.SessionIDs(),
+		"client_id":   t.ClientID,
+		"operator_id": t.OperatorID,
+		"subject":     t.Subject,
+		"notes":       t.Notes,
+		"status":      string(t.Status),
+	}
+	if t.MergedIntoID != nil {
+		payload["merged_into_ticket_id"] = int64(*t.MergedIntoID)
+	}
+	return payload
+}
+
+// ProduceTicketEvent отправляет событие тикета в топик (payload — см. TicketEventPayload).
 func (p *Producer) ProduceTicketEvent(ctx context.Context, event string, payload map[string]interface{}) {
-	if p.writer == nil {
+	p.ProduceTicketEvents(ctx, event, []map[string]interface{}{payload})
+}
+
+// ProduceTicketEvents отправляет одно событие для нескольких тикетов одной записью в Kafka.
+func (p *Producer) ProduceTicketEvents(ctx context.Context, event string, payloads []map[string]interface{}) {
+	if p.writer == nil || len(payloads) == 0 {
 		return
 	}
+	msgs := make([]kafka.Message, 0, len(payloads))
+	for _, payload := range payloads {
+		body, err := p.message(event, payload)
+		if err != nil {
+			log.Printf("kafka: marshal ticket event: %v", err)
+			continue
+		}
+		msgs = append(msgs, kafka.Message{Value: body})
+	}
+	if err := p.writer.WriteMessages(ctx, msgs...); err != nil {
+		log.Printf("kafka: write %d ticket events: %v", len(msgs), err)
+	}
+}
+
+// message — JSON события с вычищенными персональными данными.
+func (p *Producer) message(event string, payload map[string]interface{}) ([]byte, error) {
 	msg := map[string]interface{}{"event": event}
 	for k, v := range payload {
 		msg[k] = v
 	}
-	body, err := json.Marshal(msg)
-	if err != nil {
-		log.Printf("kafka: marshal ticket event: %v", err)
-		return
-	}
-	if err := p.writer.WriteMessages(ctx, kafka.Message{Value: body}); err != nil {
-		log.Printf("kafka: write ticket event: %v", err)
+	for _, k := range redactedFields {
+		if v, ok := msg[k].(string); ok {
+			msg[k] = p.redactor.Redact(v)
+		}
 	}
+	return json.Marshal(msg)
 }
 
 // Close закрывает writer.
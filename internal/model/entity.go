@@ -1,6 +1,13 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
 
 type TicketStatus string
 
@@ -8,20 +15,154 @@ const (
 	TicketStatusOpen       TicketStatus = "open"
 	TicketStatusInProgress TicketStatus = "in_progress"
 	TicketStatusClosed     TicketStatus = "closed"
+	// TicketStatusMerged — тикет влит в другой (MergedIntoID); выставляется только MergeTickets.
+	TicketStatusMerged TicketStatus = "merged"
 )
 
 type Ticket struct {
-	ID         uint64       `gorm:"primaryKey" json:"id"`
+	ID uint64 `gorm:"primaryKey" json:"id"`
+	// TenantID — бренд-владелец; проставляется и проверяется автоматически (internal/tenant).
+	TenantID string `gorm:"type:varchar(64);not null;uniqueIndex:ux_tickets_tenant_reference,priority:1" json:"tenant_id"`
+	// Reference — человекочитаемый номер вида EU-2026-000123 (выдаётся при создании).
+	Reference  string       `gorm:"type:varchar(32);not null;uniqueIndex:ux_tickets_tenant_reference,priority:2" json:"reference"`
 	SessionID  string       `gorm:"index;not null" json:"session_id"`
 	ClientID   string       `gorm:"index;not null" json:"client_id"`
 	OperatorID string       `gorm:"index" json:"operator_id,omitempty"`
 	Status     TicketStatus `gorm:"type:varchar(32);index;not null" json:"status"`
 	Priority   string       `gorm:"type:varchar(32);index" json:"priority,omitempty"`
 	Region     string       `gorm:"type:varchar(64);index" json:"region,omitempty"`
-	Subject    string       `gorm:"type:varchar(255)" json:"subject,omitempty"`
-	Notes      string       `gorm:"type:text" json:"notes,omitempty"`
+	// Subject и Notes хранятся зашифрованными, если задан ENCRYPTION_KEYFILE (см. internal/encryption).
+	Subject string `gorm:"type:text" encrypted:"true" json:"subject,omitempty"`
+	Notes   string `gorm:"type:text" encrypted:"true" json:"notes,omitempty"`
+
+	MergedIntoID *uint64 `gorm:"index" json:"merged_into_id,omitempty"`
+	// AllowDuplicateSession — тикет создан при политике allow и не учитывается в ux_tickets_open_session.
+	AllowDuplicateSession bool `gorm:"not null;default:false" json:"-"`
+
+	// Sessions — все сессии тикета; SessionID остаётся исходной сессией (обратная совместимость).
+	Sessions []TicketSession `gorm:"foreignKey:TicketID" json:"sessions,omitempty"`
 
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	ClosedAt  *time.Time `json:"closed_at,omitempty"`
+	// DeletedAt — мягкое удаление: GORM исключает такие строки из запросов (Unscoped — чтобы увидеть).
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	// ArchivedAt — архивный тикет не попадает в ListTickets по умолчанию.
+	ArchivedAt *time.Time `gorm:"index" json:"archived_at,omitempty"`
+	// ErasedAt — персональный текст (subject, notes) обезличен по запросу клиента.
+	ErasedAt *time.Time `json:"erased_at,omitempty"`
+	// LegalHold — тикет заморожен (судебное разбирательство): изменения, удаление, архивация,
+	// слияние, связи, привязка сессий и политики хранения запрещены до снятия.
+	LegalHold       bool       `gorm:"not null;default:false" json:"legal_hold,omitempty"`
+	LegalHoldReason string     `gorm:"type:text" json:"legal_hold_reason,omitempty"`
+	LegalHoldCaseID string     `gorm:"type:varchar(128)" json:"legal_hold_case_id,omitempty"`
+	LegalHoldBy     string     `gorm:"type:varchar(128)" json:"legal_hold_by,omitempty"`
+	LegalHoldAt     *time.Time `json:"legal_hold_at,omitempty"`
+}
+
+// SessionIDs возвращает ID всех привязанных сессий (Sessions должны быть загружены).
+func (t *Ticket) SessionIDs() []string {
+	ids := make([]string, len(t.Sessions))
+	for i, s := range t.Sessions {
+		ids[i] = s.SessionID
+	}
+	return ids
+}
+
+// String не выводит subject и notes: тикет в логах (%v, %+v) не раскрывает персональный текст.
+func (t Ticket) String() string {
+	return fmt.Sprintf("Ticket{ID:%d TenantID:%s Reference:%s Status:%s Region:%s}", t.ID, t.TenantID, t.Reference, t.Status, t.Region)
+}
+
+// GoString — то же для %#v.
+func (t Ticket) GoString() string { return t.String() }
+
+// TicketSession — привязка сессии (чат, видео) к тикету.
+type TicketSession struct {
+	TicketID   uint64    `gorm:"primaryKey" json:"ticket_id"`
+	TenantID   string    `gorm:"type:varchar(64);not null" json:"-"`
+	SessionID  string    `gorm:"primaryKey;type:varchar(64);index" json:"session_id"`
+	AttachedAt time.Time `gorm:"autoCreateTime" json:"attached_at"`
+}
+
+type TicketLinkType string
+
+const (
+	TicketLinkParent      TicketLinkType = "parent"
+	TicketLinkChild       TicketLinkType = "child"
+	TicketLinkBlocks      TicketLinkType = "blocks"
+	TicketLinkRelatesTo   TicketLinkType = "relates_to"
+	TicketLinkDuplicateOf TicketLinkType = "duplicate_of"
+)
+
+// TicketLink — связь "TicketID <LinkType> LinkedTicketID" (например, "7 parent 10": 7 — родитель 10).
+// child не хранится: он нормализуется в parent с перестановкой тикетов.
+type TicketLink struct {
+	ID             uint64         `gorm:"primaryKey" json:"id"`
+	TenantID       string         `gorm:"type:varchar(64);not null" json:"-"`
+	TicketID       uint64         `gorm:"index;not null" json:"ticket_id"`
+	LinkedTicketID uint64         `gorm:"index;not null" json:"linked_ticket_id"`
+	LinkType       TicketLinkType `gorm:"type:varchar(32);not null" json:"link_type"`
+	CreatedAt      time.Time      `json:"created_at"`
 }
+
+// AuditEntry — запись журнала аудита (GDPR, retention, legal hold и т.п.).
+type AuditEntry struct {
+	ID uint64 `gorm:"primaryKey" json:"id"`
+	// TenantID пустой у служебных записей без тенанта (например, итог retention.run).
+	TenantID string          `gorm:"type:varchar(64);not null;index" json:"tenant_id,omitempty"`
+	TicketID *uint64         `gorm:"index" json:"ticket_id,omitempty"`
+	ClientID string          `gorm:"type:varchar(64);index" json:"client_id,omitempty"`
+	Actor    string          `gorm:"type:varchar(128);not null" json:"actor"`
+	Action   string          `gorm:"type:varchar(64);not null" json:"action"`
+	Details  json.RawMessage `gorm:"type:jsonb" json:"details,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (AuditEntry) TableName() string { return "ticket_audit_log" }
+
+// ClientDataExport — выгрузка данных клиента по запросу на доступ (GDPR).
+type ClientDataExport struct {
+	ClientID   string       `json:"client_id"`
+	ExportedAt time.Time    `json:"exported_at"`
+	Tickets    []Ticket     `json:"tickets"`
+	Links      []TicketLink `json:"links"`
+	History    []AuditEntry `json:"history"`
+}
+
+type RetentionAction string
+
+const (
+	RetentionPurge     RetentionAction = "purge"
+	RetentionAnonymise RetentionAction = "anonymise"
+)
+
+// RetentionPolicy — сколько хранить тикеты региона в статусе Status. Region == "" — для остальных регионов.
+type RetentionPolicy struct {
+	ID         uint64          `gorm:"primaryKey" json:"id"`
+	Region     string          `gorm:"type:varchar(64);not null;default:''" json:"region"`
+	Status     TicketStatus    `gorm:"type:varchar(32);not null" json:"status"`
+	RetainDays int             `gorm:"not null" json:"retain_days"`
+	Action     RetentionAction `gorm:"type:varchar(16);not null" json:"action"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// APIKey — ключ сервиса для вызовов без пользовательского JWT. Хранится только SHA-256 ключа;
+// Prefix — начало ключа, чтобы узнать его в списке. TenantID пустой — ключ не привязан к тенанту.
+type APIKey struct {
+	ID         uint64         `gorm:"primaryKey" json:"id"`
+	TenantID   string         `gorm:"type:varchar(64);not null;default:'';index:ix_api_keys_tenant_id" json:"tenant_id,omitempty"`
+	Name       string         `gorm:"type:varchar(128);not null" json:"name"`
+	Prefix     string         `gorm:"type:varchar(16);not null" json:"prefix"`
+	KeyHash    string         `gorm:"type:char(64);not null;uniqueIndex:ux_api_keys_key_hash" json:"-"`
+	Scopes     pq.StringArray `gorm:"type:text[];not null" json:"scopes"`
+	ExpiresAt  time.Time      `gorm:"not null" json:"expires_at"`
+	RevokedAt  *time.Time     `json:"revoked_at,omitempty"`
+	LastUsedAt *time.Time     `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+func (APIKey) TableName() string { return "api_keys" }
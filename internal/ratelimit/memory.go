@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// idleBucketTTL — корзины, к которым не обращались дольше, удаляются при очистке.
+const idleBucketTTL = 10 * time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// MemoryStore — состояние в памяти процесса: у каждой реплики свои корзины и квоты.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	quotas    map[string]int
+	quotaDay  time.Time
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket), quotas: make(map[string]int), now: time.Now}
+}
+
+func (s *MemoryStore) Allow(_ context.Context, key string, l Limit) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.sweep(now)
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.Burst), updated: now}
+		s.buckets[key] = b
+	}
+	b.tokens += now.Sub(b.updated).Seconds() * l.Rate
+	if b.tokens > float64(l.Burst) {
+		b.tokens = float64(l.Burst)
+	}
+	b.updated = now
+	if b.tokens < 1 {
+		return false, l.retryAfter(b.tokens), nil
+	}
+	b.tokens--
+	return true, 0, nil
+}
+
+func (s *MemoryStore) Consume(_ context.Context, key string, day time.Time, max int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !day.Equal(s.quotaDay) {
+		s.quotas = make(map[string]int)
+		s.quotaDay = day
+	}
+	if s.quotas[key] >= max {
+		return false, nil
+	}
+	s.quotas[key]++
+	return true, nil
+}
+
+func (s *MemoryStore) Refund(_ context.Context, key string, day time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if day.Equal(s.quotaDay) && s.quotas[key] > 0 {
+		s.quotas[key]--
+	}
+	return nil
+}
+
+// sweep раз в минуту удаляет простаивающие корзины. Вызывать под mu.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if now.Sub(b.updated) > idleBucketTTL {
+			delete(s.buckets, key)
+		}
+	}
+}
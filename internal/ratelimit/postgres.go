@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// takeTokenSQL пополняет корзину за прошедшее время и забирает токен одним оператором,
+// поэтому реплики не расходятся. Строка не возвращается, если токена нет.
+const takeTokenSQL = `
+INSERT INTO rate_limit_buckets AS b (key, tokens, updated_at) VALUES (@key, @burst - 1, NOW())
+ON CONFLICT (key) DO UPDATE
+SET tokens = LEAST(@burst, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at) * @rate) - 1,
+    updated_at = NOW()
+WHERE LEAST(@burst, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at) * @rate) >= 1
+RETURNING tokens`
+
+// availableTokensSQL — сколько токенов в корзине сейчас (для retry-after при отказе).
+const availableTokensSQL = `
+SELECT LEAST(@burst, tokens + EXTRACT(EPOCH FROM NOW() - updated_at) * @rate)
+FROM rate_limit_buckets WHERE key = @key`
+
+const consumeQuotaSQL = `
+INSERT INTO ticket_create_quota AS q (key, day, used) VALUES (@key, @day, 1)
+ON CONFLICT (key, day) DO UPDATE SET used = q.used + 1
+WHERE q.used < @max
+RETURNING used`
+
+const refundQuotaSQL = `UPDATE ticket_create_quota SET used = used - 1 WHERE key = @key AND day = @day AND used > 0`
+
+// PostgresStore — общее для всех реплик состояние в таблицах rate_limit_buckets и
+// ticket_create_quota (миграция 000014). Каждая проверка — запрос к БД.
+// Raw читается через Find, а не Scan: Scan вне транзакции запрещён плагином tenant.RLS.
+type PostgresStore struct {
+	db *gorm.DB
+
+	mu          sync.Mutex
+	lastCleanup time.Time
+}
+
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Allow(ctx context.Context, key string, l Limit) (bool, time.Duration, error) {
+	args := map[string]interface{}{"key": key, "rate": l.Rate, "burst": l.Burst}
+	var left []float64
+	if err := s.db.WithContext(ctx).Raw(takeTokenSQL, args).Find(&left).Error; err != nil {
+		return false, 0, err
+	}
+	if len(left) > 0 {
+		return true, 0, nil
+	}
+	var available float64
+	if err := s.db.WithContext(ctx).Raw(availableTokensSQL, args).Find(&available).Error; err != nil {
+		return false, 0, err
+	}
+	return false, l.retryAfter(available), nil
+}
+
+func (s *PostgresStore) Consume(ctx context.Context, key string, day time.Time, max int) (bool, error) {
+	s.cleanup(ctx, day)
+	var used []int
+	err := s.db.WithContext(ctx).Raw(consumeQuotaSQL, map[string]interface{}{"key": key, "day": day, "max": max}).Find(&used).Error
+	if err != nil {
+		return false, err
+	}
+	return len(used) > 0, nil
+}
+
+func (s *PostgresStore) Refund(ctx context.Context, key string, day time.Time) error {
+	return s.db.WithContext(ctx).Exec(refundQuotaSQL, map[string]interface{}{"key": key, "day": day}).Error
+}
+
+// cleanup раз в час удаляет счётчики прошлых суток и давно не использованные корзины.
+func (s *PostgresStore) cleanup(ctx context.Context, day time.Time) {
+	s.mu.Lock()
+	if time.Since(s.lastCleanup) < time.Hour {
+		s.mu.Unlock()
+		return
+	}
+	s.lastCleanup = time.Now()
+	s.mu.Unlock()
+	db := s.db.WithContext(ctx)
+	db.Exec("DELETE FROM ticket_create_quota WHERE day < ?", day)
+	db.Exec("DELETE FROM rate_limit_buckets WHERE updated_at < NOW() - INTERVAL '1 day'")
+}
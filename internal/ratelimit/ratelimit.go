@@ -0,0 +1,145 @@
+// Package ratelimit — ограничение частоты вызовов (token bucket) и суточные квоты.
+// Состояние хранится в памяти процесса или, для точности между репликами, в Postgres.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit — token bucket: Rate токенов в секунду, не больше Burst накопленных.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Enabled — лимит задан (нулевой Limit означает «без ограничений»).
+func (l Limit) Enabled() bool { return l.Rate > 0 && l.Burst > 0 }
+
+// retryAfter — через сколько в корзине с available токенами появится целый токен.
+func (l Limit) retryAfter(available float64) time.Duration {
+	if available >= 1 {
+		return 0
+	}
+	return time.Duration((1 - available) / l.Rate * float64(time.Second))
+}
+
+// ParseLimit разбирает "rate:burst", например "5:10" (5 в секунду, всплеск до 10) или "0.5:3".
+// Пустая строка и "off" — без ограничений.
+func ParseLimit(s string) (Limit, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "off" {
+		return Limit{}, nil
+	}
+	rate, burst, ok := strings.Cut(s, ":")
+	if !ok {
+		return Limit{}, fmt.Errorf("ratelimit: %q: expected rate:burst", s)
+	}
+	r, err := strconv.ParseFloat(strings.TrimSpace(rate), 64)
+	if err != nil || r <= 0 {
+		return Limit{}, fmt.Errorf("ratelimit: %q: rate must be a positive number", s)
+	}
+	b, err := strconv.Atoi(strings.TrimSpace(burst))
+	if err != nil || b <= 0 {
+		return Limit{}, fmt.Errorf("ratelimit: %q: burst must be a positive integer", s)
+	}
+	return Limit{Rate: r, Burst: b}, nil
+}
+
+// Store хранит состояние корзин и квот.
+type Store interface {
+	// Allow забирает токен из корзины key. При отказе возвращает время до появления токена.
+	Allow(ctx context.Context, key string, l Limit) (bool, time.Duration, error)
+	// Consume увеличивает счётчик квоты key за сутки day (UTC), если он меньше max.
+	Consume(ctx context.Context, key string, day time.Time, max int) (bool, error)
+	// Refund уменьшает счётчик квоты key за сутки day, если он больше нуля.
+	Refund(ctx context.Context, key string, day time.Time) error
+}
+
+// Config — лимиты по RPC и суточная квота CreateTicket.
+type Config struct {
+	// Default — лимит для методов без своего.
+	Default Limit
+	// Methods — лимиты по имени метода (CreateTicket, ListTickets, ...).
+	Methods map[string]Limit
+	// DailyCreateQuota — сколько тикетов клиент может создать за сутки UTC (0 — без квоты).
+	DailyCreateQuota int
+}
+
+// Limiter применяет Config поверх Store.
+type Limiter struct {
+	cfg   Config
+	store Store
+	now   func() time.Time
+}
+
+func NewLimiter(cfg Config, store Store) *Limiter {
+	return &Limiter{cfg: cfg, store: store, now: time.Now}
+}
+
+// Enabled — задан хотя бы один лимит или квота.
+func (l *Limiter) Enabled() bool {
+	if l == nil {
+		return false
+	}
+	if l.cfg.Default.Enabled() || l.cfg.DailyCreateQuota > 0 {
+		return true
+	}
+	for _, m := range l.cfg.Methods {
+		if m.Enabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// MethodLimit — лимит метода (или лимит по умолчанию).
+func (l *Limiter) MethodLimit(method string) Limit {
+	if m, ok := l.cfg.Methods[method]; ok {
+		return m
+	}
+	return l.cfg.Default
+}
+
+// Allow проверяет корзину key по лимиту метода.
+func (l *Limiter) Allow(ctx context.Context, method, key string) (bool, time.Duration, error) {
+	limit := l.MethodLimit(method)
+	if !limit.Enabled() {
+		return true, 0, nil
+	}
+	return l.store.Allow(ctx, method+"|"+key, limit)
+}
+
+// ConsumeCreateQuota списывает единицу суточной квоты CreateTicket клиента key.
+// При отказе возвращает время до начала следующих суток UTC.
+func (l *Limiter) ConsumeCreateQuota(ctx context.Context, key string) (bool, time.Duration, error) {
+	if l.cfg.DailyCreateQuota <= 0 {
+		return true, 0, nil
+	}
+	now := l.now().UTC()
+	day := utcDay(now)
+	ok, err := l.store.Consume(ctx, key, day, l.cfg.DailyCreateQuota)
+	if err != nil || ok {
+		return ok, 0, err
+	}
+	return false, day.AddDate(0, 0, 1).Sub(now), nil
+}
+
+// RefundCreateQuota возвращает единицу квоты, списанную ConsumeCreateQuota, если тикет не создан.
+// Возврат относится к текущим суткам UTC: списание до полуночи после неё не возвращается.
+func (l *Limiter) RefundCreateQuota(ctx context.Context, key string) error {
+	if l.cfg.DailyCreateQuota <= 0 {
+		return nil
+	}
+	return l.store.Refund(ctx, key, utcDay(l.now().UTC()))
+}
+
+func utcDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// DailyCreateQuota — настроенная суточная квота.
+func (l *Limiter) DailyCreateQuota() int { return l.cfg.DailyCreateQuota }
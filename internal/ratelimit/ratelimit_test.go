@@ -0,0 +1,169 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// clock — управляемое время для MemoryStore и Limiter.
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time          { return c.t }
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestLimiter(cfg Config, c *clock) *Limiter {
+	store := NewMemoryStore()
+	store.now = c.now
+	l := NewLimiter(cfg, store)
+	l.now = c.now
+	return l
+}
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Limit
+		wantErr bool
+	}{
+		{"", Limit{}, false},
+		{"off", Limit{}, false},
+		{"5:10", Limit{Rate: 5, Burst: 10}, false},
+		{" 0.5 : 3 ", Limit{Rate: 0.5, Burst: 3}, false},
+		{"5", Limit{}, true},
+		{"0:10", Limit{}, true},
+		{"5:0", Limit{}, true},
+		{"x:1", Limit{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLimit(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseLimit(%q) = %+v, %v; want %+v, error %t", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestLimiterBucketRefillAndRetryAfter(t *testing.T) {
+	c := &clock{t: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)}
+	l := newTestLimiter(Config{Methods: map[string]Limit{"CreateTicket": {Rate: 2, Burst: 2}}}, c)
+	ctx := context.Background()
+
+	allow := func() (bool, time.Duration) {
+		t.Helper()
+		ok, retry, err := l.Allow(ctx, "CreateTicket", "caller")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ok, retry
+	}
+	for i := 0; i < 2; i++ {
+		if ok, _ := allow(); !ok {
+			t.Fatalf("call %d within burst was rejected", i+1)
+		}
+	}
+	if ok, retry := allow(); ok || retry != 500*time.Millisecond {
+		t.Errorf("call over burst = %t, retry %v; want rejected with retry 500ms", ok, retry)
+	}
+	c.advance(250 * time.Millisecond)
+	if ok, retry := allow(); ok || retry != 250*time.Millisecond {
+		t.Errorf("after 250ms = %t, retry %v; want rejected with retry 250ms", ok, retry)
+	}
+	c.advance(250 * time.Millisecond)
+	if ok, _ := allow(); !ok {
+		t.Error("call after refill was rejected")
+	}
+	// Корзины разных ключей и методов независимы; метод без лимита не ограничен.
+	if ok, _, _ := l.Allow(ctx, "CreateTicket", "other"); !ok {
+		t.Error("another caller shares the bucket")
+	}
+	for i := 0; i < 10; i++ {
+		if ok, _, _ := l.Allow(ctx, "ListTickets", "caller"); !ok {
+			t.Fatal("method without a limit was rejected")
+		}
+	}
+}
+
+func TestLimiterCreateQuota(t *testing.T) {
+	c := &clock{t: time.Date(2026, 10, 18, 23, 59, 30, 0, time.UTC)}
+	l := newTestLimiter(Config{DailyCreateQuota: 2}, c)
+	ctx := context.Background()
+
+	consume := func() (bool, time.Duration) {
+		t.Helper()
+		ok, retry, err := l.ConsumeCreateQuota(ctx, "acme|client")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ok, retry
+	}
+	for i := 0; i < 2; i++ {
+		if ok, _ := consume(); !ok {
+			t.Fatalf("create %d within quota was rejected", i+1)
+		}
+	}
+	if ok, retry := consume(); ok || retry != 30*time.Second {
+		t.Errorf("create over quota = %t, retry %v; want rejected until 00:00 UTC (30s)", ok, retry)
+	}
+	if ok, _, _ := l.ConsumeCreateQuota(ctx, "acme|other"); !ok {
+		t.Error("another client shares the quota")
+	}
+
+	if err := l.RefundCreateQuota(ctx, "acme|client"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := consume(); !ok {
+		t.Error("create after refund was rejected")
+	}
+	if ok, _ := consume(); ok {
+		t.Error("refund returned more than one unit")
+	}
+
+	c.advance(30 * time.Second)
+	for i := 0; i < 2; i++ {
+		if ok, _ := consume(); !ok {
+			t.Fatalf("create %d after 00:00 UTC was rejected: quota did not reset", i+1)
+		}
+	}
+}
+
+func TestMemoryStoreRefundDoesNotGoNegative(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	day := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	if ok, _ := s.Consume(ctx, "k", day, 1); !ok {
+		t.Fatal("first consume was rejected")
+	}
+	for i := 0; i < 3; i++ {
+		if err := s.Refund(ctx, "k", day); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Возврат за другие сутки не трогает текущий счётчик.
+	_ = s.Refund(ctx, "k", day.AddDate(0, 0, -1))
+	if ok, _ := s.Consume(ctx, "k", day, 1); !ok {
+		t.Fatal("consume after refund was rejected")
+	}
+	if ok, _ := s.Consume(ctx, "k", day, 1); ok {
+		t.Error("extra refunds raised the quota above max")
+	}
+}
+
+func TestLimiterEnabled(t *testing.T) {
+	var nilLimiter *Limiter
+	tests := []struct {
+		name string
+		l    *Limiter
+		want bool
+	}{
+		{"nil", nilLimiter, false},
+		{"empty", NewLimiter(Config{}, NewMemoryStore()), false},
+		{"default", NewLimiter(Config{Default: Limit{Rate: 1, Burst: 1}}, NewMemoryStore()), true},
+		{"method", NewLimiter(Config{Methods: map[string]Limit{"GetTicket": {Rate: 1, Burst: 1}}}, NewMemoryStore()), true},
+		{"quota", NewLimiter(Config{DailyCreateQuota: 1}, NewMemoryStore()), true},
+	}
+	for _, tt := range tests {
+		if got := tt.l.Enabled(); got != tt.want {
+			t.Errorf("%s: Enabled() = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package redact
+
+import (
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// Detector находит в тексте один вид персональных данных. Validate (если задан) отсекает
+// совпадения регулярки, которые не проходят контрольную сумму.
+type Detector struct {
+	Name     string
+	re       *regexp.Regexp
+	validate func(match string) bool
+}
+
+const (
+	DetectorCard  = "card"
+	DetectorEmail = "email"
+	DetectorPhone = "phone"
+	DetectorIBAN  = "iban"
+)
+
+// Порядок важен: IBAN и карты проверяются раньше телефонов, иначе номер карты
+// распознался бы как телефон.
+var allDetectors = []Detector{
+	{
+		Name:     DetectorIBAN,
+		re:       regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]){11,30}\b`),
+		validate: validIBAN,
+	},
+	{
+		Name:     DetectorCard,
+		re:       regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		validate: validLuhn,
+	},
+	{
+		Name: DetectorEmail,
+		re:   regexp.MustCompile(`(?i)\b[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}\b`),
+	},
+	{
+		Name:     DetectorPhone,
+		re:       regexp.MustCompile(`\+?\(?\d[\d ().-]{7,}\d`),
+		validate: validPhone,
+	},
+}
+
+// DetectorNames — все поддерживаемые детекторы.
+func DetectorNames() []string {
+	names := make([]string, len(allDetectors))
+	for i, d := range allDetectors {
+		names[i] = d.Name
+	}
+	return names
+}
+
+func digits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// validLuhn — номер карты: 13–19 цифр с корректной контрольной суммой Луна.
+func validLuhn(match string) bool {
+	d := digits(match)
+	if len(d) < 13 || len(d) > 19 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < len(d); i++ {
+		n := int(d[len(d)-1-i] - '0')
+		if i%2 == 1 {
+			n *= 2
+			if n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+	}
+	return sum%10 == 0
+}
+
+// datePattern — даты (2026-10-18, 18.10.2026): вместе со временем набирают 10+ цифр.
+var datePattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}|\d{2}\.\d{2}\.\d{4}`)
+
+// validPhone — 10–15 цифр (E.164 без учёта разделителей) и либо ведущий "+", либо запись
+// группами по 1–4 цифры ("8 (912) 345-67-89"). Сплошные числа (номера заказов), номера
+// тикетов вида EU-2026-000123 (группа из 6 цифр) и даты телефонами не считаются.
+func validPhone(match string) bool {
+	n := len(digits(match))
+	if n < 10 || n > 15 {
+		return false
+	}
+	if strings.HasPrefix(match, "+") {
+		return true
+	}
+	if datePattern.MatchString(match) {
+		return false
+	}
+	groups := strings.FieldsFunc(match, func(r rune) bool { return r < '0' || r > '9' })
+	if len(groups) < 3 {
+		return false
+	}
+	for _, g := range groups {
+		if len(g) > 4 {
+			return false
+		}
+	}
+	return true
+}
+
+// validIBAN — проверка mod 97 (ISO 13616).
+func validIBAN(match string) bool {
+	s := strings.ReplaceAll(match, " ", "")
+	if len(s) < 15 || len(s) > 34 {
+		return false
+	}
+	s = s[4:] + s[:4]
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r >= 'A' && r <= 'Z':
+			b.WriteString(big.NewInt(int64(r - 'A' + 10)).String())
+		default:
+			return false
+		}
+	}
+	n, ok := new(big.Int).SetString(b.String(), 10)
+	return ok && new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
@@ -0,0 +1,179 @@
+package redact
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestValidLuhn(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"4111111111111111", true},
+		{"4111 1111 1111 1111", true},
+		{"5500-0000-0000-0004", true},
+		{"378282246310005", true}, // 15 цифр (Amex)
+		{"4111111111111112", false},
+		{"1234567890123", false},
+		{"411111111111", false},         // 12 цифр — короче карты
+		{"41111111111111111111", false}, // 20 цифр — длиннее карты
+	}
+	for _, tt := range tests {
+		if got := validLuhn(tt.in); got != tt.want {
+			t.Errorf("validLuhn(%q) = %t, want %t", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestValidIBAN(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"DE89370400440532013000", true},
+		{"DE89 3704 0044 0532 0130 00", true},
+		{"GB82 WEST 1234 5698 7654 32", true},
+		{"FR1420041010050500013M02606", true},
+		{"DE89370400440532013001", false}, // неверная контрольная сумма
+		{"DE88370400440532013000", false},
+		{"DE8937040044", false}, // короче 15 символов
+		{"DE89-3704-0044-0532-0130-00", false},
+	}
+	for _, tt := range tests {
+		if got := validIBAN(tt.in); got != tt.want {
+			t.Errorf("validIBAN(%q) = %t, want %t", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRedactDetectors(t *testing.T) {
+	r, err := New(Config{Mode: ModeMask})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, in, want string
+	}{
+		{"card", "card 4111 1111 1111 1111 declined", "card [card ****1111] declined"},
+		{"card with dashes", "5500-0000-0000-0004", "[card ****0004]"},
+		{"card failing luhn", "order 4111 1111 1111 1112", "order 4111 1111 1111 1112"},
+		{"iban", "refund to DE89 3704 0044 0532 0130 00 please", "refund to [iban] please"},
+		{"iban compact", "GB82WEST12345698765432", "[iban]"},
+		{"iban failing checksum", "DE88 3704 0044 0532 0130 00", "DE88 3704 0044 0532 0130 00"},
+		{"email", "write to Ivan.Petrov+support@mail.example.com.", "write to [email]."},
+		{"email is not a domain", "see example.com", "see example.com"},
+		{"phone", "call +7 (912) 345-67-89 after 6", "call [phone] after 6"},
+		{"phone grouped", "tel. 8 (912) 345-67-89", "tel. [phone]"},
+		{"phone dotted", "555.123.4567", "[phone]"},
+		{"bare number", "order 8912345678 shipped", "order 8912345678 shipped"},
+		{"ticket reference", "see EU-2026-000123 and TK-2026-000045", "see EU-2026-000123 and TK-2026-000045"},
+		{"timestamp", "at 2026-10-18 12:30 UTC", "at 2026-10-18 12:30 UTC"},
+		{"dotted date with time", "on 18.10.2026 12:30", "on 18.10.2026 12:30"},
+		{"short number", "room 123-45-67", "room 123-45-67"},
+		{"date", "since 2026-01-15", "since 2026-01-15"},
+		{"several", "a@b.io, +44 20 7946 0958", "[email], [phone]"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Redact(tt.in); got != tt.want {
+				t.Errorf("Redact(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactModes(t *testing.T) {
+	const in = "card 4111 1111 1111 1111, mail a@b.io"
+	hashRe := regexp.MustCompile(`^card \[card:[0-9a-f]{16}\], mail \[email:[0-9a-f]{16}\]$`)
+	tests := []struct {
+		mode  Mode
+		check func(t *testing.T, got string)
+	}{
+		{ModeMask, func(t *testing.T, got string) {
+			if want := "card [card ****1111], mail [email]"; got != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		}},
+		{ModeHash, func(t *testing.T, got string) {
+			if !hashRe.MatchString(got) {
+				t.Errorf("got %q, want values replaced with [kind:hmac]", got)
+			}
+		}},
+		{ModeDrop, func(t *testing.T, got string) {
+			if want := "card , mail "; got != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		}},
+		{ModeOff, func(t *testing.T, got string) {
+			if got != in {
+				t.Errorf("got %q, want text unchanged", got)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			r, err := New(Config{Mode: tt.mode, HashKey: "k"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, r.Redact(in))
+		})
+	}
+}
+
+func TestRedactHashIsStable(t *testing.T) {
+	r1, _ := New(Config{Mode: ModeHash, HashKey: "k1"})
+	r2, _ := New(Config{Mode: ModeHash, HashKey: "k2"})
+	// Хэш не зависит от форматирования значения, но зависит от ключа.
+	a, b := r1.Redact("4111 1111 1111 1111"), r1.Redact("4111-1111-1111-1111")
+	if a != b {
+		t.Errorf("same card, different formatting: %q != %q", a, b)
+	}
+	if r1.Redact("Ann@Example.com") != r1.Redact("ann@example.com") {
+		t.Error("email hash depends on case")
+	}
+	if c := r2.Redact("4111 1111 1111 1111"); c == a {
+		t.Errorf("different keys give the same hash %q", c)
+	}
+}
+
+func TestNewDetectors(t *testing.T) {
+	r, err := New(Config{Mode: ModeMask, Detectors: []string{" Email "}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := "a@b.io +7 912 345 67 89"
+	if got, want := r.Redact(in), "[email] +7 912 345 67 89"; got != want {
+		t.Errorf("Redact with email only = %q, want %q", got, want)
+	}
+
+	if _, err := New(Config{Detectors: []string{"passport"}}); err == nil || !strings.Contains(err.Error(), "passport") {
+		t.Errorf("New with unknown detector: err = %v", err)
+	}
+	if _, err := New(Config{Mode: "blur"}); err == nil {
+		t.Error("New with unknown mode: want error")
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Mode
+		wantErr bool
+	}{
+		{"", ModeMask, false},
+		{" HASH ", ModeHash, false},
+		{"drop", ModeDrop, false},
+		{"off", ModeOff, false},
+		{"blur", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMode(tt.in)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("ParseMode(%q) = %q, %v; want %q, error %t", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Package redact вычищает персональные данные (карты, email, телефоны, IBAN) из текста,
+// который уходит во внешние приёмники: события Kafka и индекс search-service.
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Mode — что делать с найденным значением.
+type Mode string
+
+const (
+	// ModeOff — не редактировать.
+	ModeOff Mode = "off"
+	// ModeMask — заменить меткой вида [email]; у карт остаются последние 4 цифры.
+	ModeMask Mode = "mask"
+	// ModeHash — заменить HMAC-SHA256 значения: приёмник может сопоставлять, но не прочитать.
+	ModeHash Mode = "hash"
+	// ModeDrop — удалить значение из текста.
+	ModeDrop Mode = "drop"
+)
+
+// ParseMode проверяет режим (пустая строка — ModeMask).
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(strings.ToLower(strings.TrimSpace(s))); m {
+	case "":
+		return ModeMask, nil
+	case ModeOff, ModeMask, ModeHash, ModeDrop:
+		return m, nil
+	default:
+		return "", fmt.Errorf("redact: unknown mode %q (off, mask, hash, drop)", s)
+	}
+}
+
+// Config — настройка редактора для одного приёмника.
+type Config struct {
+	Mode Mode
+	// Detectors — имена детекторов; пусто — все.
+	Detectors []string
+	// HashKey — ключ HMAC для ModeHash.
+	HashKey string
+}
+
+// Redactor применяет детекторы к тексту. nil-Redactor возвращает текст без изменений.
+type Redactor struct {
+	mode      Mode
+	detectors []Detector
+	hashKey   []byte
+}
+
+// New создаёт редактор; для ModeOff возвращает nil.
+func New(cfg Config) (*Redactor, error) {
+	if cfg.Mode == ModeOff {
+		return nil, nil
+	}
+	if _, err := ParseMode(string(cfg.Mode)); err != nil {
+		return nil, err
+	}
+	r := &Redactor{mode: cfg.Mode, hashKey: []byte(cfg.HashKey)}
+	if r.mode == "" {
+		r.mode = ModeMask
+	}
+	enabled := make(map[string]bool, len(cfg.Detectors))
+	for _, name := range cfg.Detectors {
+		enabled[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+	all := len(enabled) == 0
+	for _, d := range allDetectors {
+		if all || enabled[d.Name] {
+			r.detectors = append(r.detectors, d)
+			delete(enabled, d.Name)
+		}
+	}
+	for name := range enabled {
+		return nil, fmt.Errorf("redact: unknown detector %q (%s)", name, strings.Join(DetectorNames(), ", "))
+	}
+	return r, nil
+}
+
+// Redact возвращает текст с заменёнными персональными данными. Совпадения ищутся по
+// исходному тексту за один проход: замена одного детектора не попадает под следующий.
+func (r *Redactor) Redact(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+	type span struct {
+		start, end int
+		kind       string
+	}
+	var spans []span
+	for _, d := range r.detectors {
+	next:
+		for _, loc := range d.re.FindAllStringIndex(s, -1) {
+			if d.validate != nil && !d.validate(s[loc[0]:loc[1]]) {
+				continue
+			}
+			for _, sp := range spans {
+				if loc[0] < sp.end && sp.start < loc[1] {
+					continue next
+				}
+			}
+			spans = append(spans, span{loc[0], loc[1], d.Name})
+		}
+	}
+	if len(spans) == 0 {
+		return s
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	var b strings.Builder
+	last := 0
+	for _, sp := range spans {
+		b.WriteString(s[last:sp.start])
+		b.WriteString(r.replace(sp.kind, s[sp.start:sp.end]))
+		last = sp.end
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+func (r *Redactor) replace(kind, match string) string {
+	switch r.mode {
+	case ModeDrop:
+		return ""
+	case ModeHash:
+		mac := hmac.New(sha256.New, r.hashKey)
+		mac.Write([]byte(normalize(kind, match)))
+		return "[" + kind + ":" + hex.EncodeToString(mac.Sum(nil))[:16] + "]"
+	default:
+		if kind == DetectorCard {
+			d := digits(match)
+			return "[card ****" + d[len(d)-4:] + "]"
+		}
+		return "[" + kind + "]"
+	}
+}
+
+// normalize приводит значение к каноническому виду, чтобы хэш не зависел от форматирования.
+func normalize(kind, match string) string {
+	switch kind {
+	case DetectorCard, DetectorPhone:
+		return digits(match)
+	case DetectorIBAN:
+		return strings.ReplaceAll(match, " ", "")
+	default:
+		return strings.ToLower(match)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/internal/redact"
 )
 
 // TicketIndexer — интерфейс для индексации тикетов в search-service (для подмены моком в тестах).
@@ -20,12 +21,15 @@ type TicketIndexer interface {
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
+	redactor   *redact.Redactor
 }
 
 // NewClient возвращает клиент. Если baseURL пустой, вызовы IndexTicket — no-op.
-func NewClient(baseURL string) *Client {
+// redactor (может быть nil) вычищает персональные данные из subject и notes.
+func NewClient(baseURL string, redactor *redact.Redactor) *Client {
 	return &Client{
-		baseURL: baseURL,
+		baseURL:  baseURL,
+		redactor: redactor,
 		httpClient: &http.Client{
 			Timeout: 5 * time.Second,
 		},
@@ -35,12 +39,16 @@ func NewClient(baseURL string) *Client {
 // IndexTicketPayload — тело POST /search/index/ticket.
 type IndexTicketPayload struct {
 	TicketID   int64  `json:"ticket_id"`
+	TenantID   string `json:"tenant_id"`
+	Reference  string `json:"reference"`
 	SessionID  string `json:"session_id"`
 	ClientID   string `json:"client_id"`
 	OperatorID string `json:"operator_id"`
 	Subject    string `json:"subject"`
 	Notes      string `json:"notes"`
 	Status     string `json:"status"`
+
+	SessionIDs []string `json:"session_ids,omitempty"`
 }
 
 // IndexTicket отправляет тикет в search-service. Вызывать в goroutine после Create/Update.
@@ -50,12 +58,15 @@ func (c *Client) IndexTicket(ctx context.Context, t *model.Ticket) {
 	}
 	payload := IndexTicketPayload{
 		TicketID:   int64(t.ID),
+		TenantID:   t.TenantID,
+		Reference:  t.Reference,
 		SessionID:  t.SessionID,
 		ClientID:   t.ClientID,
 		OperatorID: t.OperatorID,
-		Subject:    t.Subject,
-		Notes:      t.Notes,
+		Subject:    c.redactor.Redact(t.Subject),
+		Notes:      c.redactor.Redact(t.Notes),
 		Status:     string(t.Status),
+		SessionIDs: t.SessionIDs(),
 	}
 	body, err := json.Marshal(payload)
 	if err != nil {
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// Действия журнала аудита.
+const (
+	AuditGDPRExport = "gdpr.export"
+	AuditGDPRErase  = "gdpr.erase"
+)
+
+// recordAudit пишет запись журнала аудита; details сериализуется в JSON.
+func recordAudit(db *gorm.DB, entry model.AuditEntry, details interface{}) error {
+	if details != nil {
+		raw, err := json.Marshal(details)
+		if err != nil {
+			return fmt.Errorf("audit details: %w", err)
+		}
+		entry.Details = raw
+	}
+	return db.Create(&entry).Error
+}
+
+// Audit пишет запись журнала аудита вне транзакции бизнес-операции (например, из CLI).
+func (s *TicketService) Audit(ctx context.Context, entry model.AuditEntry, details interface{}) error {
+	return recordAudit(s.db.WithContext(ctx), entry, details)
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/psds-microservice/ticket-service/internal/errs"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErasedSubject заменяет subject обезличенного тикета.
+const ErasedSubject = "[erased]"
+
+// ExportClientData собирает все тикеты клиента (включая удалённые), их связи и историю аудита.
+// Сама выгрузка тоже попадает в аудит.
+func (s *TicketService) ExportClientData(ctx context.Context, clientID, actor string) (*model.ClientDataExport, error) {
+	out := &model.ClientDataExport{ClientID: clientID, ExportedAt: time.Now().UTC()}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := withSessions(tx.Unscoped()).Where("client_id = ?", clientID).Order("id").Find(&out.Tickets).Error; err != nil {
+			return err
+		}
+		ids := make([]uint64, len(out.Tickets))
+		for i, t := range out.Tickets {
+			ids[i] = t.ID
+		}
+		if len(ids) > 0 {
+			if err := tx.Where("ticket_id IN ? OR linked_ticket_id IN ?", ids, ids).Order("id").Find(&out.Links).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("client_id = ? OR ticket_id IN ?", clientID, ids).Order("id").Find(&out.History).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, model.AuditEntry{ClientID: clientID, Actor: actor, Action: AuditGDPRExport},
+			map[string]interface{}{"ticket_count": len(out.Tickets)})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EraseClientData необратимо обезличивает subject и notes всех тикетов клиента (включая удалённые).
+// Статус, приоритет, регион и даты сохраняются для статистики. Возвращает обезличенные тикеты.
+func (s *TicketService) EraseClientData(ctx context.Context, clientID, actor string) ([]model.Ticket, error) {
+	var erased []model.Ticket
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uint64
+		if err := tx.Unscoped().Model(&model.Ticket{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("client_id = ?", clientID).Order("id").Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		// Legal hold обязывает сохранить данные: обезличивание блокируется до снятия.
+		var held []uint64
+		if err := tx.Unscoped().Model(&model.Ticket{}).Where("id IN ? AND legal_hold", ids).Pluck("id", &held).Error; err != nil {
+			return err
+		}
+		if len(held) > 0 {
+			return fmt.Errorf("ticket %d: %w", held[0], errs.ErrTicketOnLegalHold)
+		}
+		if len(ids) > 0 {
+			if err := tx.Unscoped().Model(&model.Ticket{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+				"subject":   ErasedSubject,
+				"notes":     "",
+				"erased_at": time.Now(),
+			}).Error; err != nil {
+				return err
+			}
+			if err := withSessions(tx.Unscoped()).Where("id IN ?", ids).Order("id").Find(&erased).Error; err != nil {
+				return err
+			}
+		}
+		return recordAudit(tx, model.AuditEntry{ClientID: clientID, Actor: actor, Action: AuditGDPRErase},
+			map[string]interface{}{"ticket_ids": ids})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return erased, nil
+}
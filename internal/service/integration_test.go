@@ -0,0 +1,32 @@
+//go:build integration
+
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/internal/service"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+	"github.com/psds-microservice/ticket-service/internal/testdb"
+)
+
+// newService — TicketService на тестовой базе под ролью без BYPASSRLS и контекст тенанта "acme".
+func newService(t *testing.T) (*service.TicketService, context.Context) {
+	t.Helper()
+	return service.NewTicketService(testdb.Open(t), service.Options{}), tenant.WithID(context.Background(), "acme")
+}
+
+// createTicket создаёт открытый тикет; поля subject, notes и т.п. задаёт mutate.
+func createTicket(t *testing.T, svc *service.TicketService, ctx context.Context, mutate func(*model.Ticket)) *model.Ticket {
+	t.Helper()
+	ticket := &model.Ticket{SessionID: "session", ClientID: "client", Status: model.TicketStatusOpen, AllowDuplicateSession: true}
+	if mutate != nil {
+		mutate(ticket)
+	}
+	if err := svc.Create(ctx, ticket); err != nil {
+		t.Fatalf("create ticket: %v", err)
+	}
+	return ticket
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/psds-microservice/ticket-service/internal/errs"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Действия журнала аудита для legal hold.
+const (
+	AuditLegalHoldPlace   = "legal_hold.place"
+	AuditLegalHoldRelease = "legal_hold.release"
+)
+
+// lockTicket читает тикет (включая мягко удалённый) с блокировкой строки до конца транзакции.
+func lockTicket(tx *gorm.DB, id uint64) (*model.Ticket, error) {
+	var t model.Ticket
+	if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).First(&t, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrTicketNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// ensureNotOnHold возвращает ErrTicketOnLegalHold, если хотя бы один из тикетов на legal hold.
+func ensureNotOnHold(tx *gorm.DB, ids ...uint64) error {
+	var held []uint64
+	if err := tx.Model(&model.Ticket{}).Where("id IN ? AND legal_hold", ids).Order("id").Pluck("id", &held).Error; err != nil {
+		return err
+	}
+	if len(held) > 0 {
+		return fmt.Errorf("ticket %d: %w", held[0], errs.ErrTicketOnLegalHold)
+	}
+	return nil
+}
+
+// PlaceLegalHold ставит тикет на legal hold. Для тикета, уже находящегося на hold,
+// обновляет причину и номер дела. Каждое изменение пишется в журнал аудита.
+func (s *TicketService) PlaceLegalHold(ctx context.Context, id uint64, reason, caseID, actor string) (*model.Ticket, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		t, err := lockTicket(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(t).Updates(map[string]interface{}{
+			"legal_hold":         true,
+			"legal_hold_reason":  reason,
+			"legal_hold_case_id": caseID,
+			"legal_hold_by":      actor,
+			"legal_hold_at":      time.Now(),
+		}).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, model.AuditEntry{TicketID: &t.ID, ClientID: t.ClientID, Actor: actor, Action: AuditLegalHoldPlace},
+			map[string]interface{}{"reason": reason, "case_id": caseID})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetByIDWithDeleted(ctx, id)
+}
+
+// ReleaseLegalHold снимает legal hold; в аудит попадают причина снятия и номер дела.
+func (s *TicketService) ReleaseLegalHold(ctx context.Context, id uint64, reason, actor string) (*model.Ticket, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		t, err := lockTicket(tx, id)
+		if err != nil {
+			return err
+		}
+		if !t.LegalHold {
+			return errs.ErrTicketNotOnHold
+		}
+		caseID := t.LegalHoldCaseID
+		if err := tx.Unscoped().Model(t).Updates(map[string]interface{}{
+			"legal_hold":         false,
+			"legal_hold_reason":  nil,
+			"legal_hold_case_id": nil,
+			"legal_hold_by":      nil,
+			"legal_hold_at":      nil,
+		}).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, model.AuditEntry{TicketID: &t.ID, ClientID: t.ClientID, Actor: actor, Action: AuditLegalHoldRelease},
+			map[string]interface{}{"reason": reason, "case_id": caseID})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetByIDWithDeleted(ctx, id)
+}
@@ -0,0 +1,46 @@
+//go:build integration
+
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/psds-microservice/ticket-service/internal/errs"
+	"github.com/psds-microservice/ticket-service/internal/model"
+)
+
+func TestLegalHoldRejectsChanges(t *testing.T) {
+	svc, ctx := newService(t)
+	held := createTicket(t, svc, ctx, func(tk *model.Ticket) { tk.Status = model.TicketStatusClosed })
+	other := createTicket(t, svc, ctx, nil)
+	if _, err := svc.LinkTickets(ctx, held.ID, other.ID, model.TicketLinkRelatesTo); err != nil {
+		t.Fatalf("LinkTickets: %v", err)
+	}
+	if _, err := svc.PlaceLegalHold(ctx, held.ID, "litigation", "CASE-1", "admin"); err != nil {
+		t.Fatalf("PlaceLegalHold: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"update", func() error {
+			_, err := svc.Update(ctx, held.ID, map[string]interface{}{"priority": "high"})
+			return err
+		}},
+		{"delete", func() error { _, err := svc.Delete(ctx, held.ID); return err }},
+		{"archive", func() error { _, err := svc.Archive(ctx, held.ID); return err }},
+		{"attach session", func() error { _, err := svc.AttachSession(ctx, held.ID, "late-session"); return err }},
+		{"link", func() error { _, err := svc.LinkTickets(ctx, other.ID, held.ID, model.TicketLinkBlocks); return err }},
+		{"unlink", func() error { return svc.UnlinkTickets(ctx, other.ID, held.ID, model.TicketLinkRelatesTo) }},
+		{"merge", func() error { _, _, err := svc.Merge(ctx, other.ID, []uint64{held.ID}); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, errs.ErrTicketOnLegalHold) {
+				t.Errorf("err = %v, want ErrTicketOnLegalHold", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/psds-microservice/ticket-service/internal/celfilter"
+	"github.com/psds-microservice/ticket-service/internal/errs"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// TimeRange — полуинтервал [From, To); nil-граница не ограничивает.
+type TimeRange struct {
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+}
+
+func (r TimeRange) empty() bool { return r.From == nil && r.To == nil }
+
+func (r TimeRange) apply(tx *gorm.DB, column string) *gorm.DB {
+	if r.From != nil {
+		tx = tx.Where(column+" >= ?", *r.From)
+	}
+	if r.To != nil {
+		tx = tx.Where(column+" < ?", *r.To)
+	}
+	return tx
+}
+
+func (r TimeRange) contains(t *time.Time) bool {
+	if r.empty() {
+		return true
+	}
+	if t == nil {
+		return false
+	}
+	return (r.From == nil || !t.Before(*r.From)) && (r.To == nil || t.Before(*r.To))
+}
+
+// ListFilter — условия List. Пустое поле не ограничивает выборку; все заданные условия
+// объединяются через AND, значения внутри Statuses/Priorities — через OR.
+// Имена колонок зашиты в коде, значения уходят в запрос только параметрами.
+type ListFilter struct {
+	ClientID   string               `json:"client_id,omitempty"`
+	OperatorID string               `json:"operator_id,omitempty"`
+	Region     string               `json:"region,omitempty"`
+	Statuses   []model.TicketStatus `json:"statuses,omitempty"`
+	Priorities []string             `json:"priorities,omitempty"`
+	LegalHold  *bool                `json:"legal_hold,omitempty"`
+	// SessionID — тикеты, к которым привязана сессия (исходная или присоединённая позже).
+	SessionID string `json:"session_id,omitempty"`
+	// Unassigned — только тикеты без оператора (с OperatorID не сочетается).
+	Unassigned bool      `json:"unassigned,omitempty"`
+	Created    TimeRange `json:"created,omitempty"`
+	Updated    TimeRange `json:"updated,omitempty"`
+	Closed     TimeRange `json:"closed,omitempty"`
+	// SubjectContains — подстрока темы без учёта регистра. При шифровании subject
+	// (ENCRYPTION_KEYFILE) в БД лежит шифртекст, поэтому фильтр недоступен (errs.ErrSubjectFilterUnavailable).
+	SubjectContains string `json:"subject_contains,omitempty"`
+	// Expr — CEL-выражение (celfilter), объединяется с остальными условиями через AND.
+	Expr *celfilter.Filter `json:"expr,omitempty"`
+}
+
+// usesSubject — фильтр ищет по subject.
+func (f ListFilter) usesSubject() bool {
+	return f.SubjectContains != "" || f.Expr != nil && f.Expr.UsesSubject()
+}
+
+// Validate проверяет несовместимые условия.
+func (f ListFilter) Validate() error {
+	if f.Unassigned && f.OperatorID != "" {
+		return fmt.Errorf("%w: unassigned and operator_id are mutually exclusive", errs.ErrInvalidFilter)
+	}
+	for name, r := range map[string]TimeRange{"created": f.Created, "updated": f.Updated, "closed": f.Closed} {
+		if r.From != nil && r.To != nil && !r.From.Before(*r.To) {
+			return fmt.Errorf("%w: %s range is empty", errs.ErrInvalidFilter, name)
+		}
+	}
+	return nil
+}
+
+// apply добавляет условия фильтра к запросу по tickets.
+func (f ListFilter) apply(tx *gorm.DB) *gorm.DB {
+	if f.ClientID != "" {
+		tx = tx.Where("client_id = ?", f.ClientID)
+	}
+	if f.OperatorID != "" {
+		tx = tx.Where("operator_id = ?", f.OperatorID)
+	}
+	if f.Region != "" {
+		tx = tx.Where("region = ?", f.Region)
+	}
+	if len(f.Statuses) > 0 {
+		tx = tx.Where("status IN ?", f.Statuses)
+	}
+	if len(f.Priorities) > 0 {
+		tx = tx.Where("priority IN ?", f.Priorities)
+	}
+	if f.LegalHold != nil {
+		tx = tx.Where("legal_hold = ?", *f.LegalHold)
+	}
+	if f.SessionID != "" {
+		tx = tx.Where("id IN (SELECT ticket_id FROM ticket_sessions WHERE session_id = ?)", f.SessionID)
+	}
+	if f.Unassigned {
+		tx = tx.Where("COALESCE(operator_id, '') = ''")
+	}
+	tx = f.Created.apply(tx, "created_at")
+	tx = f.Updated.apply(tx, "updated_at")
+	tx = f.Closed.apply(tx, "closed_at")
+	if f.SubjectContains != "" {
+		tx = tx.Where("subject ILIKE ?", "%"+celfilter.EscapeLike(f.SubjectContains)+"%")
+	}
+	if f.Expr != nil {
+		sql, args := f.Expr.SQL()
+		tx = tx.Where(sql, args...)
+	}
+	return tx
+}
+
+// Matches — тикет проходит фильтр (то же, что apply, для тикетов в памяти: WatchTickets).
+func (f ListFilter) Matches(t *model.Ticket) bool {
+	switch {
+	case f.ClientID != "" && t.ClientID != f.ClientID,
+		f.OperatorID != "" && t.OperatorID != f.OperatorID,
+		f.Region != "" && t.Region != f.Region,
+		len(f.Statuses) > 0 && !slices.Contains(f.Statuses, t.Status),
+		len(f.Priorities) > 0 && !slices.Contains(f.Priorities, t.Priority),
+		f.LegalHold != nil && t.LegalHold != *f.LegalHold,
+		f.SessionID != "" && t.SessionID != f.SessionID && !slices.Contains(t.SessionIDs(), f.SessionID),
+		f.Unassigned && t.OperatorID != "",
+		!f.Created.contains(&t.CreatedAt),
+		!f.Updated.contains(&t.UpdatedAt),
+		!f.Closed.contains(t.ClosedAt),
+		f.SubjectContains != "" && !strings.Contains(strings.ToLower(t.Subject), strings.ToLower(f.SubjectContains)),
+		f.Expr != nil && !f.Expr.Matches(t, time.Now()):
+		return false
+	}
+	return true
+}
+
+// OrderField — допустимые поля сортировки List.
+type OrderField string
+
+const (
+	OrderCreatedAt OrderField = "created_at"
+	OrderUpdatedAt OrderField = "updated_at"
+	// OrderPriority — по рангу приоритета: urgent > high > normal/medium > low > прочие.
+	OrderPriority OrderField = "priority"
+	// OrderSLADue — срок SLA. Зарезервировано: у тикетов пока нет срока SLA (нет политик SLA),
+	// поэтому ParseListOrder отвечает ErrSLAUnavailable, а не молча сортирует по другому полю.
+	OrderSLADue OrderField = "sla_due"
+)
+
+// priorityRankSQL — ранг приоритета для сортировки (priority — свободная строка).
+const priorityRankSQL = "CASE LOWER(priority) WHEN 'urgent' THEN 4 WHEN 'high' THEN 3 WHEN 'normal' THEN 2 WHEN 'medium' THEN 2 WHEN 'low' THEN 1 ELSE 0 END"
+
+// PriorityRank — ранг приоритета, как в priorityRankSQL.
+func PriorityRank(priority string) int {
+	switch strings.ToLower(priority) {
+	case "urgent":
+		return 4
+	case "high":
+		return 3
+	case "normal", "medium":
+		return 2
+	case "low":
+		return 1
+	}
+	return 0
+}
+
+// ListOrder — сортировка List. Ключ всегда дополняется id, поэтому порядок однозначный
+// и подходит для keyset-пагинации.
+type ListOrder struct {
+	Field OrderField
+	Desc  bool
+}
+
+// DefaultListOrder — created_at DESC, как было до появления order_by.
+var DefaultListOrder = ListOrder{Field: OrderCreatedAt, Desc: true}
+
+// ParseListOrder разбирает "поле [asc|desc]" (по умолчанию desc); пустая строка — DefaultListOrder.
+func ParseListOrder(s string) (ListOrder, error) {
+	parts := strings.Fields(strings.ToLower(s))
+	if len(parts) == 0 {
+		return DefaultListOrder, nil
+	}
+	o := ListOrder{Field: OrderField(parts[0]), Desc: true}
+	switch o.Field {
+	case OrderCreatedAt, OrderUpdatedAt, OrderPriority:
+	case OrderSLADue:
+		return o, errs.ErrSLAUnavailable
+	default:
+		return o, fmt.Errorf("%w: order_by must be created_at, updated_at or priority, got %q", errs.ErrInvalidFilter, parts[0])
+	}
+	if len(parts) > 2 || len(parts) == 2 && parts[1] != "asc" && parts[1] != "desc" {
+		return o, fmt.Errorf("%w: order_by must be \"<field> [asc|desc]\", got %q", errs.ErrInvalidFilter, s)
+	}
+	if len(parts) == 2 {
+		o.Desc = parts[1] == "desc"
+	}
+	return o, nil
+}
+
+// keySQL — выражение первой колонки ключа сортировки.
+func (o ListOrder) keySQL() string {
+	if o.Field == OrderPriority {
+		return priorityRankSQL
+	}
+	return string(o.Field)
+}
+
+func (o ListOrder) direction() string {
+	if o.Desc {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+func (o ListOrder) apply(tx *gorm.DB) *gorm.DB {
+	return tx.Order(o.keySQL() + " " + o.direction() + ", id " + o.direction())
+}
+
+// after — строки после курсора в порядке o.
+func (o ListOrder) after(tx *gorm.DB, c *ListCursor) *gorm.DB {
+	cmp := ">"
+	if o.Desc {
+		cmp = "<"
+	}
+	var key interface{} = c.Time
+	if o.Field == OrderPriority {
+		key = c.Rank
+	}
+	return tx.Where("("+o.keySQL()+", id) "+cmp+" (?, ?)", key, c.ID)
+}
+
+// cursor — позиция тикета t в порядке o.
+func (o ListOrder) cursor(t *model.Ticket) *ListCursor {
+	c := &ListCursor{ID: t.ID}
+	switch o.Field {
+	case OrderUpdatedAt:
+		c.Time = t.UpdatedAt
+	case OrderPriority:
+		c.Rank = PriorityRank(t.Priority)
+	default:
+		c.Time = t.CreatedAt
+	}
+	return c
+}
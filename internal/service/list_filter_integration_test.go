@@ -0,0 +1,74 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+	"github.com/psds-microservice/ticket-service/internal/testdb"
+)
+
+// TestListFilterApplyMatchesParity проверяет на Postgres, что List (apply) и Matches (WatchTickets)
+// отбирают одни и те же тикеты: для каждого условия, каждой пары и всех условий вместе.
+func TestListFilterApplyMatchesParity(t *testing.T) {
+	svc := NewTicketService(testdb.Open(t), Options{})
+	ctx := tenant.WithID(context.Background(), "acme")
+	for i := range filterTickets {
+		ticket := filterTickets[i]
+		ticket.Sessions = slices.Clone(ticket.Sessions)
+		if err := svc.Create(ctx, &ticket); err != nil {
+			t.Fatalf("create ticket %d: %v", filterTickets[i].ID, err)
+		}
+	}
+
+	conds := filterConditions(t)
+	var combos [][]int
+	for i := range conds {
+		combos = append(combos, []int{i})
+		for j := i + 1; j < len(conds); j++ {
+			combos = append(combos, []int{i, j})
+		}
+	}
+	all := make([]int, 0, len(conds))
+	for i, c := range conds {
+		if c.name != "unassigned" {
+			all = append(all, i)
+		}
+	}
+	combos = append(combos, all)
+
+	for _, combo := range combos {
+		var f ListFilter
+		names := make([]string, len(combo))
+		for k, i := range combo {
+			conds[i].set(&f)
+			names[k] = conds[i].name
+		}
+		if f.Validate() != nil {
+			continue
+		}
+		t.Run(strings.Join(names, "+"), func(t *testing.T) {
+			page, err := svc.List(ctx, f, ListOptions{Order: ListOrder{Field: OrderCreatedAt}, Total: TotalNone})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			var fromSQL, fromMatches []uint64
+			for i := range page.Items {
+				fromSQL = append(fromSQL, page.Items[i].ID)
+			}
+			slices.Sort(fromSQL)
+			for i := range filterTickets {
+				if f.Matches(&filterTickets[i]) {
+					fromMatches = append(fromMatches, filterTickets[i].ID)
+				}
+			}
+			if !slices.Equal(fromSQL, fromMatches) {
+				t.Errorf("List selects %v, Matches selects %v", fromSQL, fromMatches)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/psds-microservice/ticket-service/internal/celfilter"
+	"github.com/psds-microservice/ticket-service/internal/errs"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// filterCondition — одно условие ListFilter: как его задать, какой фрагмент SQL оно добавляет
+// в apply и какие тикеты из filterTickets ему соответствуют.
+type filterCondition struct {
+	name  string
+	set   func(f *ListFilter)
+	sql   string
+	match map[uint64]bool
+}
+
+var filterNow = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+func ago(d time.Duration) *time.Time {
+	t := filterNow.Add(-d)
+	return &t
+}
+
+// filterTickets — тикеты, на которых проверяются условия (индекс = ID - 1).
+var filterTickets = []model.Ticket{
+	{
+		ID: 1, ClientID: "c1", Region: "eu", Status: model.TicketStatusOpen, Priority: "high",
+		SessionID: "s1", Sessions: []model.TicketSession{{SessionID: "s1"}, {SessionID: "s9"}},
+		Subject: "VPN drops", CreatedAt: *ago(72 * time.Hour), UpdatedAt: *ago(time.Hour),
+	},
+	{
+		ID: 2, ClientID: "c2", OperatorID: "op1", Region: "us", Status: model.TicketStatusClosed, Priority: "low",
+		LegalHold: true, SessionID: "s2", Sessions: []model.TicketSession{{SessionID: "s2"}},
+		Subject: "Invoice 50%", CreatedAt: *ago(2 * time.Hour), UpdatedAt: *ago(30 * time.Minute), ClosedAt: ago(30 * time.Minute),
+	},
+	{
+		ID: 3, ClientID: "c1", OperatorID: "op1", Region: "eu", Status: model.TicketStatusInProgress, Priority: "urgent",
+		SessionID: "s3", Sessions: []model.TicketSession{{SessionID: "s3"}},
+		Subject: "vpn slow", CreatedAt: *ago(10 * time.Minute), UpdatedAt: *ago(5 * time.Minute),
+	},
+}
+
+func ids(list ...uint64) map[uint64]bool {
+	m := make(map[uint64]bool, len(list))
+	for _, id := range list {
+		m[id] = true
+	}
+	return m
+}
+
+func filterConditions(t testing.TB) []filterCondition {
+	expr, err := celfilter.Compile(`priority != "low"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hold := true
+	return []filterCondition{
+		{"client", func(f *ListFilter) { f.ClientID = "c1" }, "client_id = $", ids(1, 3)},
+		{"operator", func(f *ListFilter) { f.OperatorID = "op1" }, "operator_id = $", ids(2, 3)},
+		{"region", func(f *ListFilter) { f.Region = "eu" }, "region = $", ids(1, 3)},
+		{"statuses", func(f *ListFilter) {
+			f.Statuses = []model.TicketStatus{model.TicketStatusOpen, model.TicketStatusClosed}
+		}, "status IN ($", ids(1, 2)},
+		{"priorities", func(f *ListFilter) { f.Priorities = []string{"high", "urgent"} }, "priority IN ($", ids(1, 3)},
+		{"legal hold", func(f *ListFilter) { f.LegalHold = &hold }, "legal_hold = $", ids(2)},
+		{"attached session", func(f *ListFilter) { f.SessionID = "s9" }, "id IN (SELECT ticket_id FROM ticket_sessions WHERE session_id = $", ids(1)},
+		{"unassigned", func(f *ListFilter) { f.Unassigned = true }, "COALESCE(operator_id, '') = ''", ids(1)},
+		{"created from", func(f *ListFilter) { f.Created.From = ago(3 * time.Hour) }, "created_at >= $", ids(2, 3)},
+		{"updated to", func(f *ListFilter) { f.Updated.To = ago(20 * time.Minute) }, "updated_at < $", ids(1, 2)},
+		{"closed from", func(f *ListFilter) { f.Closed.From = ago(time.Hour) }, "closed_at >= $", ids(2)},
+		{"subject contains", func(f *ListFilter) { f.SubjectContains = "VPN" }, "subject ILIKE $", ids(1, 3)},
+		{"expression", func(f *ListFilter) { f.Expr = expr }, "priority <> $", ids(1, 3)},
+	}
+}
+
+// dryRunDB строит запросы без подключения к БД.
+func dryRunDB(t testing.TB) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// TestListFilterCombinations перебирает все сочетания условий: apply добавляет ровно
+// выбранные условия, Matches отбирает тикеты, подходящие под каждое из них, а
+// взаимоисключающие unassigned и operator_id отклоняет Validate.
+func TestListFilterCombinations(t *testing.T) {
+	conds := filterConditions(t)
+	db := dryRunDB(t)
+	for mask := 0; mask < 1<<len(conds); mask++ {
+		var f ListFilter
+		var names []string
+		for i, c := range conds {
+			if mask&(1<<i) != 0 {
+				c.set(&f)
+				names = append(names, c.name)
+			}
+		}
+		label := strings.Join(names, "+")
+
+		err := f.Validate()
+		if f.Unassigned && f.OperatorID != "" {
+			if !errors.Is(err, errs.ErrInvalidFilter) {
+				t.Errorf("%s: Validate = %v, want ErrInvalidFilter", label, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: Validate = %v", label, err)
+			continue
+		}
+
+		stmt := f.apply(db.Model(&model.Ticket{})).Find(&[]model.Ticket{}).Statement
+		sql := stmt.SQL.String()
+		for i, c := range conds {
+			if got, want := strings.Contains(sql, c.sql), mask&(1<<i) != 0; got != want {
+				t.Errorf("%s: SQL %q contains %q = %t, want %t", label, sql, c.sql, got, want)
+			}
+		}
+
+		for i := range filterTickets {
+			ticket := &filterTickets[i]
+			want := true
+			for j, c := range conds {
+				if mask&(1<<j) != 0 && !c.match[ticket.ID] {
+					want = false
+				}
+			}
+			if got := f.Matches(ticket); got != want {
+				t.Errorf("%s: Matches(ticket %d) = %t, want %t", label, ticket.ID, got, want)
+			}
+		}
+	}
+}
+
+func TestListFilterApplyArgs(t *testing.T) {
+	f := ListFilter{
+		ClientID:        "c1",
+		Statuses:        []model.TicketStatus{model.TicketStatusOpen, model.TicketStatusClosed},
+		Created:         TimeRange{From: ago(time.Hour), To: ago(time.Minute)},
+		SubjectContains: "50%_off",
+	}
+	stmt := f.apply(dryRunDB(t).Model(&model.Ticket{})).Find(&[]model.Ticket{}).Statement
+	want := []interface{}{"c1", model.TicketStatusOpen, model.TicketStatusClosed, *ago(time.Hour), *ago(time.Minute), `%50\%\_off%`}
+	if got := fmt.Sprint(stmt.Vars); got != fmt.Sprint(want) {
+		t.Errorf("Vars = %s, want %s", got, fmt.Sprint(want))
+	}
+	if !strings.Contains(stmt.SQL.String(), "created_at >= $4 AND created_at < $5") {
+		t.Errorf("SQL %q: want a half-open created range", stmt.SQL.String())
+	}
+}
+
+func TestListFilterValidate(t *testing.T) {
+	from, to := ago(time.Hour), ago(time.Minute)
+	tests := []struct {
+		name    string
+		filter  ListFilter
+		wantErr bool
+	}{
+		{"empty", ListFilter{}, false},
+		{"range", ListFilter{Created: TimeRange{From: from, To: to}}, false},
+		{"open range", ListFilter{Updated: TimeRange{From: from}, Closed: TimeRange{To: to}}, false},
+		{"unassigned with operator", ListFilter{Unassigned: true, OperatorID: "op1"}, true},
+		{"reversed range", ListFilter{Created: TimeRange{From: to, To: from}}, true},
+		{"empty range", ListFilter{Closed: TimeRange{From: from, To: from}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate = %v, want error %t", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, errs.ErrInvalidFilter) {
+				t.Errorf("Validate = %v, want ErrInvalidFilter", err)
+			}
+		})
+	}
+}
+
+func TestTimeRangeContains(t *testing.T) {
+	from, to := ago(time.Hour), ago(time.Minute)
+	r := TimeRange{From: from, To: to}
+	tests := []struct {
+		name string
+		r    TimeRange
+		t    *time.Time
+		want bool
+	}{
+		{"from is included", r, from, true},
+		{"to is excluded", r, to, false},
+		{"inside", r, ago(30 * time.Minute), true},
+		{"before", r, ago(2 * time.Hour), false},
+		{"nil time in a range", r, nil, false},
+		{"nil time without a range", TimeRange{}, nil, true},
+		{"only from", TimeRange{From: from}, ago(0), true},
+		{"only to", TimeRange{To: to}, ago(2 * time.Hour), true},
+	}
+	for _, tt := range tests {
+		if got := tt.r.contains(tt.t); got != tt.want {
+			t.Errorf("%s: contains = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseListOrder(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ListOrder
+		wantErr error
+	}{
+		{"", DefaultListOrder, nil},
+		{"created_at", ListOrder{Field: OrderCreatedAt, Desc: true}, nil},
+		{"updated_at asc", ListOrder{Field: OrderUpdatedAt}, nil},
+		{" PRIORITY  DESC ", ListOrder{Field: OrderPriority, Desc: true}, nil},
+		{"sla_due", ListOrder{}, errs.ErrSLAUnavailable},
+		{"subject", ListOrder{}, errs.ErrInvalidFilter},
+		{"id; DROP TABLE tickets", ListOrder{}, errs.ErrInvalidFilter},
+		{"created_at sideways", ListOrder{}, errs.ErrInvalidFilter},
+		{"created_at asc id", ListOrder{}, errs.ErrInvalidFilter},
+	}
+	for _, tt := range tests {
+		got, err := ParseListOrder(tt.in)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseListOrder(%q) error = %v, want %v", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseListOrder(%q) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestListOrderCursor(t *testing.T) {
+	ticket := &filterTickets[2]
+	db := dryRunDB(t)
+	tests := []struct {
+		order          ListOrder
+		where, orderBy string
+		want           ListCursor
+	}{
+		{ListOrder{Field: OrderCreatedAt, Desc: true}, "(created_at, id) < ($1, $2)", "ORDER BY created_at DESC, id DESC", ListCursor{Time: ticket.CreatedAt, ID: 3}},
+		{ListOrder{Field: OrderUpdatedAt}, "(updated_at, id) > ($1, $2)", "ORDER BY updated_at ASC, id ASC", ListCursor{Time: ticket.UpdatedAt, ID: 3}},
+		{ListOrder{Field: OrderPriority, Desc: true}, "(" + priorityRankSQL + ", id) < ($1, $2)", "ORDER BY " + priorityRankSQL + " DESC, id DESC", ListCursor{Rank: 4, ID: 3}},
+	}
+	for _, tt := range tests {
+		c := tt.order.cursor(ticket)
+		if *c != tt.want {
+			t.Errorf("%+v: cursor = %+v, want %+v", tt.order, *c, tt.want)
+		}
+		sql := tt.order.apply(tt.order.after(db.Model(&model.Ticket{}), c)).Find(&[]model.Ticket{}).Statement.SQL.String()
+		if !strings.Contains(sql, tt.where) || !strings.HasSuffix(sql, tt.orderBy) {
+			t.Errorf("%+v: SQL %q, want %q and %q", tt.order, sql, tt.where, tt.orderBy)
+		}
+	}
+}
+
+func TestPriorityRank(t *testing.T) {
+	for priority, want := range map[string]int{"urgent": 4, "HIGH": 3, "normal": 2, "medium": 2, "low": 1, "": 0, "p1": 0} {
+		if got := PriorityRank(priority); got != want {
+			t.Errorf("PriorityRank(%q) = %d, want %d", priority, got, want)
+		}
+	}
+}
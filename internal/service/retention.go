@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AuditRetentionRun — итог прогона политик хранения в журнале аудита.
+const AuditRetentionRun = "retention.run"
+
+// DefaultRetentionBatchSize — сколько тикетов обрабатывается в одной транзакции.
+const DefaultRetentionBatchSize = 500
+
+// RetentionRunOptions — параметры прогона политик хранения.
+type RetentionRunOptions struct {
+	DryRun    bool
+	BatchSize int
+	Actor     string
+	// Now — момент отсчёта (по умолчанию time.Now()).
+	Now time.Time
+	// OnBatch вызывается после коммита каждой пачки (например, для событий в Kafka).
+	OnBatch func(action model.RetentionAction, tickets []model.Ticket)
+}
+
+// RetentionPolicyResult — итог по одной политике.
+type RetentionPolicyResult struct {
+	PolicyID   uint64                `json:"policy_id"`
+	Region     string                `json:"region"`
+	Status     model.TicketStatus    `json:"status"`
+	Action     model.RetentionAction `json:"action"`
+	RetainDays int                   `json:"retain_days"`
+	Cutoff     time.Time             `json:"cutoff"`
+	// Eligible — подходящие тикеты без legal hold; Processed — удалённые/обезличенные (0 при dry-run).
+	Eligible    int64 `json:"eligible"`
+	Processed   int64 `json:"processed"`
+	OnLegalHold int64 `json:"on_legal_hold"`
+}
+
+// RetentionReport — итог прогона.
+type RetentionReport struct {
+	DryRun   bool                    `json:"dry_run"`
+	Policies []RetentionPolicyResult `json:"policies"`
+}
+
+func (s *TicketService) ListRetentionPolicies(ctx context.Context) ([]model.RetentionPolicy, error) {
+	var items []model.RetentionPolicy
+	if err := s.db.WithContext(ctx).Order("status, region").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// SetRetentionPolicy создаёт или заменяет политику для пары (region, status).
+func (s *TicketService) SetRetentionPolicy(ctx context.Context, p *model.RetentionPolicy) error {
+	if p.RetainDays <= 0 {
+		return errors.New("retain_days must be greater than 0")
+	}
+	if p.Action != model.RetentionPurge && p.Action != model.RetentionAnonymise {
+		return fmt.Errorf("action must be %q or %q", model.RetentionPurge, model.RetentionAnonymise)
+	}
+	if p.Status == "" {
+		return errors.New("status is required")
+	}
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "region"}, {Name: "status"}},
+		DoUpdates: clause.AssignmentColumns([]string{"retain_days", "action", "updated_at"}),
+	}).Create(p).Error
+}
+
+func (s *TicketService) DeleteRetentionPolicy(ctx context.Context, id uint64) error {
+	res := s.db.WithContext(ctx).Delete(&model.RetentionPolicy{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("retention policy %d not found", id)
+	}
+	return nil
+}
+
+// retentionScope — тикеты (включая мягко удалённые), на которые распространяется политика p.
+// Политика с пустым region не действует на регионы, у которых для того же статуса есть своя политика.
+func retentionScope(db *gorm.DB, p model.RetentionPolicy, cutoff time.Time) *gorm.DB {
+	q := db.Unscoped().Model(&model.Ticket{}).
+		Where("status = ?", p.Status).
+		Where("COALESCE(closed_at, updated_at) < ?", cutoff)
+	if p.Region != "" {
+		q = q.Where("region = ?", p.Region)
+	} else {
+		specific := db.Model(&model.RetentionPolicy{}).Select("region").Where("status = ? AND region <> ''", p.Status)
+		q = q.Where("COALESCE(region, '') NOT IN (?)", specific)
+	}
+	if p.Action == model.RetentionAnonymise {
+		q = q.Where("erased_at IS NULL")
+	}
+	return q
+}
+
+// RunRetention применяет все политики хранения пачками. Тикеты на legal hold пропускаются.
+// Итог реального прогона пишется в журнал аудита.
+func (s *TicketService) RunRetention(ctx context.Context, opts RetentionRunOptions) (*RetentionReport, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultRetentionBatchSize
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	policies, err := s.ListRetentionPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db := s.db.WithContext(ctx)
+	report := &RetentionReport{DryRun: opts.DryRun}
+	for _, p := range policies {
+		res := RetentionPolicyResult{
+			PolicyID:   p.ID,
+			Region:     p.Region,
+			Status:     p.Status,
+			Action:     p.Action,
+			RetainDays: p.RetainDays,
+			Cutoff:     opts.Now.AddDate(0, 0, -p.RetainDays),
+		}
+		if err := retentionScope(db, p, res.Cutoff).Where("legal_hold").Count(&res.OnLegalHold).Error; err != nil {
+			return nil, fmt.Errorf("policy %d: %w", p.ID, err)
+		}
+		if err := retentionScope(db, p, res.Cutoff).Where("NOT legal_hold").Count(&res.Eligible).Error; err != nil {
+			return nil, fmt.Errorf("policy %d: %w", p.ID, err)
+		}
+		if !opts.DryRun {
+			if res.Processed, err = s.applyRetention(db, p, res.Cutoff, opts); err != nil {
+				return nil, fmt.Errorf("policy %d: %w", p.ID, err)
+			}
+		}
+		report.Policies = append(report.Policies, res)
+	}
+	if !opts.DryRun {
+		if err := recordAudit(db, model.AuditEntry{Actor: opts.Actor, Action: AuditRetentionRun}, report); err != nil {
+			return report, fmt.Errorf("audit: %w", err)
+		}
+	}
+	return report, nil
+}
+
+func (s *TicketService) applyRetention(db *gorm.DB, p model.RetentionPolicy, cutoff time.Time, opts RetentionRunOptions) (int64, error) {
+	var processed int64
+	for {
+		var batch []model.Ticket
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := retentionScope(tx, p, cutoff).Where("NOT legal_hold").
+				Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+				Order("id").Limit(opts.BatchSize).Find(&batch).Error; err != nil {
+				return err
+			}
+			if len(batch) == 0 {
+				return nil
+			}
+			ids := make([]uint64, len(batch))
+			for i, t := range batch {
+				ids[i] = t.ID
+			}
+			if p.Action == model.RetentionPurge {
+				// Связи и сессии удаляются каскадно (ON DELETE CASCADE).
+				return tx.Unscoped().Where("id IN ?", ids).Delete(&model.Ticket{}).Error
+			}
+			now := time.Now()
+			if err := tx.Unscoped().Model(&model.Ticket{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+				"subject":   ErasedSubject,
+				"notes":     "",
+				"erased_at": now,
+			}).Error; err != nil {
+				return err
+			}
+			for i := range batch {
+				batch[i].Subject, batch[i].Notes, batch[i].ErasedAt = ErasedSubject, "", &now
+			}
+			return nil
+		})
+		if err != nil {
+			return processed, err
+		}
+		if len(batch) == 0 {
+			return processed, nil
+		}
+		processed += int64(len(batch))
+		if opts.OnBatch != nil {
+			opts.OnBatch(p.Action, batch)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+	"gorm.io/gorm"
+)
+
+// requestTenant — тенант запроса для raw SQL, который GORM-плагин тенантов не видит.
+// В служебном контексте (tenant.WithSystem) возвращается fallback — тенант обрабатываемой строки.
+func requestTenant(db *gorm.DB, fallback string) (string, error) {
+	ctx := db.Statement.Context
+	if id, ok := tenant.FromContext(ctx); ok {
+		return id, nil
+	}
+	if tenant.IsSystem(ctx) && fallback != "" {
+		return fallback, nil
+	}
+	return "", tenant.ErrMissing
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// GetByIDs возвращает найденные тикеты в порядке ids; отсутствующие (и чужого тенанта) пропускаются.
+// Удалённые и архивные тикеты возвращаются только при includeDeleted, как в GetByIDWithDeleted.
+func (s *TicketService) GetByIDs(ctx context.Context, ids []uint64, includeDeleted bool) ([]model.Ticket, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	db := s.db.WithContext(ctx)
+	if includeDeleted {
+		db = db.Unscoped()
+	} else {
+		db = db.Where("archived_at IS NULL")
+	}
+	byID, err := ticketsByID(db, ids)
+	if err != nil {
+		return nil, err
+	}
+	tickets := make([]model.Ticket, 0, len(byID))
+	for _, id := range ids {
+		if t, ok := byID[id]; ok {
+			tickets = append(tickets, *t)
+			delete(byID, id) // повторный ID возвращается один раз
+		}
+	}
+	return tickets, nil
+}
+
+// UpdateResult — итог изменения одного тикета в UpdateMany.
+type UpdateResult struct {
+	ID     uint64
+	Ticket *model.Ticket
+	// Children — дочерние тикеты, закрытые каскадом.
+	Children []model.Ticket
+	Err      error
+}
+
+// UpdateMany применяет одни изменения к каждому тикету из ids в одной транзакции; каждый
+// тикет — в своей точке сохранения, поэтому ошибка одного (не найден, legal hold) не отменяет
+// остальные. cascade — при закрытии закрывать и дочерние тикеты (как UpdateCascade).
+// Ошибка — только если не удалась сама транзакция (тогда не изменён ни один тикет).
+func (s *TicketService) UpdateMany(ctx context.Context, ids []uint64, changes map[string]interface{}, cascade bool) ([]UpdateResult, error) {
+	results := make([]UpdateResult, 0, len(ids))
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		results = results[:0]
+		for _, id := range ids {
+			r := UpdateResult{ID: id}
+			r.Err = tx.Transaction(func(itx *gorm.DB) error {
+				t, err := applyUpdate(itx, id, changes)
+				if err != nil {
+					return err
+				}
+				r.Ticket = t
+				if cascade && t.Status == model.TicketStatusClosed {
+					r.Children, err = closeDescendants(itx, t)
+				}
+				return err
+			})
+			if r.Err != nil {
+				r.Ticket, r.Children = nil, nil
+			}
+			results = append(results, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/psds-microservice/ticket-service/internal/model"
+)
+
+// changedAtSQL — время последнего изменения тикета. Мягкое удаление не меняет updated_at,
+// поэтому deleted_at учитывается отдельно.
+const changedAtSQL = "GREATEST(updated_at, COALESCE(deleted_at, updated_at))"
+
+// ChangedAt — время последнего изменения тикета (как в ChangedSince).
+func ChangedAt(t *model.Ticket) time.Time {
+	if t.DeletedAt.Valid && t.DeletedAt.Time.After(t.UpdatedAt) {
+		return t.DeletedAt.Time
+	}
+	return t.UpdatedAt
+}
+
+// ChangedSince возвращает тикеты (включая удалённые), изменённые после позиции (since, afterID),
+// в порядке (время изменения, id): следующую страницу запрашивают с ChangedAt и ID последнего тикета.
+// Для догоняющего чтения ленты изменений после потери LISTEN-соединения; вызывается
+// в служебном контексте (все тенанты).
+func (s *TicketService) ChangedSince(ctx context.Context, since time.Time, afterID uint64, limit int) ([]model.Ticket, error) {
+	var items []model.Ticket
+	err := withSessions(s.db.WithContext(ctx).Unscoped()).
+		Where("("+changedAtSQL+", id) > (?, ?)", since, afterID).
+		Order(changedAtSQL + ", id").
+		Limit(limit).
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,44 @@
+//go:build integration
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/psds-microservice/ticket-service/internal/database"
+	"github.com/psds-microservice/ticket-service/internal/errs"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/internal/service"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+	"github.com/psds-microservice/ticket-service/internal/testdb"
+	"gorm.io/gorm"
+)
+
+func TestCreateReportsOpenSessionConflictOnlyForItsIndex(t *testing.T) {
+	db := testdb.Open(t)
+	svc, ctx := service.NewTicketService(db, service.Options{}), tenant.WithID(context.Background(), "acme")
+	first := createTicket(t, svc, ctx, func(tk *model.Ticket) {
+		tk.SessionID = "s-1"
+		tk.AllowDuplicateSession = false
+	})
+
+	err := svc.Create(ctx, &model.Ticket{SessionID: "s-1", ClientID: "client", Status: model.TicketStatusOpen})
+	var openSession *errs.OpenSessionTicketError
+	if !errors.As(err, &openSession) || openSession.TicketID != first.ID {
+		t.Fatalf("Create with an open session ticket: err = %v, want OpenSessionTicketError for ticket %d", err, first.ID)
+	}
+
+	// Номер тикета выдаётся повторно — нарушение ux_tickets_reference, а не открытой сессии.
+	if err := db.WithContext(ctx).Exec("UPDATE ticket_reference_counters SET last_value = last_value - 1").Error; err != nil {
+		t.Fatalf("rewind reference counter: %v", err)
+	}
+	err = svc.Create(ctx, &model.Ticket{SessionID: "s-2", ClientID: "client", Status: model.TicketStatusOpen})
+	if err == nil || errors.As(err, &openSession) {
+		t.Fatalf("Create with a duplicate reference: err = %v, want a plain duplicate key error", err)
+	}
+	if !errors.Is(err, gorm.ErrDuplicatedKey) || database.ViolatedConstraint(err) != "ux_tickets_reference" {
+		t.Errorf("Create with a duplicate reference: err = %v (constraint %q), want ErrDuplicatedKey on ux_tickets_reference", err, database.ViolatedConstraint(err))
+	}
+}
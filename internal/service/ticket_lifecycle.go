@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/psds-microservice/ticket-service/internal/errs"
+	"github.com/psds-microservice/ticket-service/internal/model"
+)
+
+// Delete мягко удаляет тикет (deleted_at), в том числе архивный, и возвращает его состояние на момент удаления.
+func (s *TicketService) Delete(ctx context.Context, id uint64) (*model.Ticket, error) {
+	t, err := s.GetByIDWithArchived(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t.LegalHold {
+		return nil, errs.ErrTicketOnLegalHold
+	}
+	if err := s.db.WithContext(ctx).Delete(t).Error; err != nil {
+		return nil, err
+	}
+	return s.GetByIDWithDeleted(ctx, id)
+}
+
+// Restore снимает мягкое удаление и архивацию.
+func (s *TicketService) Restore(ctx context.Context, id uint64) (*model.Ticket, error) {
+	t, err := s.GetByIDWithDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !t.DeletedAt.Valid && t.ArchivedAt == nil {
+		return nil, errs.ErrTicketNotRemoved
+	}
+	if err := s.db.WithContext(ctx).Unscoped().Model(t).Updates(map[string]interface{}{
+		"deleted_at":  nil,
+		"archived_at": nil,
+	}).Error; err != nil {
+		return nil, err
+	}
+	return s.GetByID(ctx, id)
+}
+
+// Archive архивирует закрытый (или влитый) тикет. Повторная архивация — no-op.
+func (s *TicketService) Archive(ctx context.Context, id uint64) (*model.Ticket, error) {
+	t, err := s.GetByIDWithArchived(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t.LegalHold {
+		return nil, errs.ErrTicketOnLegalHold
+	}
+	if !slices.Contains(finalStatuses, t.Status) {
+		return nil, errs.ErrTicketNotClosed
+	}
+	if t.ArchivedAt != nil {
+		return t, nil
+	}
+	if err := s.db.WithContext(ctx).Model(t).Updates(map[string]interface{}{"archived_at": time.Now()}).Error; err != nil {
+		return nil, err
+	}
+	return t, nil
+}
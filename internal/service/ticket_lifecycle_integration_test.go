@@ -0,0 +1,49 @@
+//go:build integration
+
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/psds-microservice/ticket-service/internal/errs"
+	"github.com/psds-microservice/ticket-service/internal/model"
+)
+
+func TestArchivedTicketIsHiddenByDefault(t *testing.T) {
+	svc, ctx := newService(t)
+	ticket := createTicket(t, svc, ctx, func(tk *model.Ticket) { tk.Status = model.TicketStatusClosed })
+	if _, err := svc.Archive(ctx, ticket.ID); err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+	// Повторная архивация — no-op.
+	if _, err := svc.Archive(ctx, ticket.ID); err != nil {
+		t.Fatalf("second Archive: %v", err)
+	}
+
+	if _, err := svc.GetByID(ctx, ticket.ID); !errors.Is(err, errs.ErrTicketNotFound) {
+		t.Errorf("GetByID of an archived ticket: err = %v, want ErrTicketNotFound", err)
+	}
+	if _, err := svc.GetByReference(ctx, ticket.Reference, false); !errors.Is(err, errs.ErrTicketNotFound) {
+		t.Errorf("GetByReference of an archived ticket: err = %v, want ErrTicketNotFound", err)
+	}
+	if got, err := svc.GetByIDs(ctx, []uint64{ticket.ID}, false); err != nil || len(got) != 0 {
+		t.Errorf("GetByIDs of an archived ticket = %d tickets, %v; want none", len(got), err)
+	}
+	for name, get := range map[string]func() (*model.Ticket, error){
+		"GetByIDWithArchived": func() (*model.Ticket, error) { return svc.GetByIDWithArchived(ctx, ticket.ID) },
+		"GetByIDWithDeleted":  func() (*model.Ticket, error) { return svc.GetByIDWithDeleted(ctx, ticket.ID) },
+		"GetByReference":      func() (*model.Ticket, error) { return svc.GetByReference(ctx, ticket.Reference, true) },
+	} {
+		if got, err := get(); err != nil || got.ArchivedAt == nil {
+			t.Errorf("%s of an archived ticket = %v, %v; want the archived ticket", name, got, err)
+		}
+	}
+
+	if _, err := svc.Restore(ctx, ticket.ID); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if got, err := svc.GetByID(ctx, ticket.ID); err != nil || got.ArchivedAt != nil {
+		t.Errorf("GetByID after Restore = %v, %v; want the restored ticket", got, err)
+	}
+}
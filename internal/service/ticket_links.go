@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/psds-microservice/ticket-service/internal/errs"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// linkGraphLockKey — ключ pg_advisory_xact_lock: изменения графа связей сериализуются,
+// чтобы две параллельные вставки не образовали цикл вместе.
+const linkGraphLockKey = 7_310_026
+
+// directedLinkTypes — типы связей, для которых циклы запрещены.
+var directedLinkTypes = map[model.TicketLinkType]bool{
+	model.TicketLinkParent:      true,
+	model.TicketLinkBlocks:      true,
+	model.TicketLinkDuplicateOf: true,
+}
+
+// linkReachableSQL проверяет, достижим ли @from из @to по связям типа @type в пределах тенанта @tenant.
+const linkReachableSQL = `
+WITH RECURSIVE reach(id) AS (
+    SELECT linked_ticket_id FROM ticket_links WHERE tenant_id = @tenant AND ticket_id = @to AND link_type = @type
+    UNION
+    SELECT l.linked_ticket_id FROM ticket_links l JOIN reach r ON l.ticket_id = r.id
+    WHERE l.tenant_id = @tenant AND l.link_type = @type
+)
+SELECT EXISTS (SELECT 1 FROM reach WHERE id = @from)`
+
+// descendantsSQL возвращает ID всех потомков тикета по parent-связям в пределах тенанта @tenant.
+const descendantsSQL = `
+WITH RECURSIVE descendants(id) AS (
+    SELECT linked_ticket_id FROM ticket_links WHERE tenant_id = @tenant AND ticket_id = @parent AND link_type = 'parent'
+    UNION
+    SELECT l.linked_ticket_id FROM ticket_links l JOIN descendants d ON l.ticket_id = d.id
+    WHERE l.tenant_id = @tenant AND l.link_type = 'parent'
+)
+SELECT id FROM descendants`
+
+func validLinkType(linkType model.TicketLinkType) bool {
+	switch linkType {
+	case model.TicketLinkParent, model.TicketLinkChild, model.TicketLinkBlocks, model.TicketLinkRelatesTo, model.TicketLinkDuplicateOf:
+		return true
+	}
+	return false
+}
+
+// NormalizeLink проверяет тип связи и приводит child к parent с перестановкой тикетов.
+func NormalizeLink(ticketID, linkedID uint64, linkType model.TicketLinkType) (uint64, uint64, model.TicketLinkType, error) {
+	if !validLinkType(linkType) {
+		return 0, 0, "", errs.ErrInvalidLinkType
+	}
+	if linkType == model.TicketLinkChild {
+		ticketID, linkedID, linkType = linkedID, ticketID, model.TicketLinkParent
+	}
+	if ticketID == linkedID {
+		return 0, 0, "", errs.ErrSelfLink
+	}
+	return ticketID, linkedID, linkType, nil
+}
+
+// linkPairQuery ограничивает запрос связью между двумя тикетами; relates_to симметрична.
+func linkPairQuery(tx *gorm.DB, from, to uint64, linkType model.TicketLinkType) *gorm.DB {
+	tx = tx.Model(&model.TicketLink{}).Where("link_type = ?", linkType)
+	if linkType == model.TicketLinkRelatesTo {
+		return tx.Where("(ticket_id = ? AND linked_ticket_id = ?) OR (ticket_id = ? AND linked_ticket_id = ?)", from, to, to, from)
+	}
+	return tx.Where("ticket_id = ? AND linked_ticket_id = ?", from, to)
+}
+
+func (s *TicketService) LinkTickets(ctx context.Context, ticketID, linkedID uint64, linkType model.TicketLinkType) (*model.TicketLink, error) {
+	from, to, linkType, err := NormalizeLink(ticketID, linkedID, linkType)
+	if err != nil {
+		return nil, err
+	}
+	link := &model.TicketLink{TicketID: from, LinkedTicketID: to, LinkType: linkType}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", linkGraphLockKey).Error; err != nil {
+			return err
+		}
+		var found int64
+		if err := tx.Model(&model.Ticket{}).Where("id IN ?", []uint64{from, to}).Count(&found).Error; err != nil {
+			return err
+		}
+		if found != 2 {
+			return errs.ErrTicketNotFound
+		}
+		if err := ensureNotOnHold(tx, from, to); err != nil {
+			return err
+		}
+		var existing int64
+		if err := linkPairQuery(tx, from, to, linkType).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return errs.ErrLinkExists
+		}
+		if linkType == model.TicketLinkParent {
+			var parents int64
+			if err := tx.Model(&model.TicketLink{}).Where("linked_ticket_id = ? AND link_type = ?", to, linkType).Count(&parents).Error; err != nil {
+				return err
+			}
+			if parents > 0 {
+				return errs.ErrParentExists
+			}
+		}
+		if directedLinkTypes[linkType] {
+			tenantID, err := requestTenant(tx, "")
+			if err != nil {
+				return err
+			}
+			var cycle bool
+			args := map[string]interface{}{"tenant": tenantID, "from": from, "to": to, "type": linkType}
+			if err := tx.Raw(linkReachableSQL, args).Scan(&cycle).Error; err != nil {
+				return err
+			}
+			if cycle {
+				return errs.ErrLinkCycle
+			}
+		}
+		return tx.Create(link).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+func (s *TicketService) UnlinkTickets(ctx context.Context, ticketID, linkedID uint64, linkType model.TicketLinkType) error {
+	from, to, linkType, err := NormalizeLink(ticketID, linkedID, linkType)
+	if err != nil {
+		return err
+	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureNotOnHold(tx, from, to); err != nil {
+			return err
+		}
+		res := linkPairQuery(tx, from, to, linkType).Delete(&model.TicketLink{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errs.ErrTicketLinkNotFound
+		}
+		return nil
+	})
+}
+
+// ListLinks возвращает связи, в которых участвует тикет. Фильтр child эквивалентен parent.
+func (s *TicketService) ListLinks(ctx context.Context, ticketID uint64, linkType model.TicketLinkType) ([]model.TicketLink, error) {
+	if _, err := s.GetByIDWithArchived(ctx, ticketID); err != nil {
+		return nil, err
+	}
+	tx := s.db.WithContext(ctx).Where("ticket_id = ? OR linked_ticket_id = ?", ticketID, ticketID)
+	if linkType != "" {
+		if !validLinkType(linkType) {
+			return nil, errs.ErrInvalidLinkType
+		}
+		if linkType == model.TicketLinkChild {
+			linkType = model.TicketLinkParent
+		}
+		tx = tx.Where("link_type = ?", linkType)
+	}
+	var links []model.TicketLink
+	if err := tx.Order("created_at, id").Find(&links).Error; err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// closeDescendants закрывает все незакрытые дочерние тикеты (рекурсивно) и возвращает их.
+func closeDescendants(tx *gorm.DB, parent *model.Ticket) ([]model.Ticket, error) {
+	tenantID, err := requestTenant(tx, parent.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	var ids []uint64
+	if err := tx.Raw(descendantsSQL, map[string]interface{}{"tenant": tenantID, "parent": parent.ID}).Scan(&ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var open []uint64
+	if err := tx.Model(&model.Ticket{}).Where("id IN ? AND status <> ?", ids, model.TicketStatusClosed).Pluck("id", &open).Error; err != nil {
+		return nil, err
+	}
+	if len(open) == 0 {
+		return nil, nil
+	}
+	var held []uint64
+	if err := tx.Model(&model.Ticket{}).Where("id IN ? AND legal_hold", open).Pluck("id", &held).Error; err != nil {
+		return nil, err
+	}
+	if len(held) > 0 {
+		return nil, fmt.Errorf("child ticket %d: %w", held[0], errs.ErrTicketOnLegalHold)
+	}
+	if err := tx.Model(&model.Ticket{}).Where("id IN ?", open).Updates(map[string]interface{}{
+		"status":    model.TicketStatusClosed,
+		"closed_at": gorm.Expr("NOW()"),
+	}).Error; err != nil {
+		return nil, err
+	}
+	var children []model.Ticket
+	if err := withSessions(tx).Where("id IN ?", open).Order("id").Find(&children).Error; err != nil {
+		return nil, err
+	}
+	return children, nil
+}
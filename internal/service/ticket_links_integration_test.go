@@ -0,0 +1,148 @@
+//go:build integration
+
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/psds-microservice/ticket-service/internal/errs"
+	grpcserver "github.com/psds-microservice/ticket-service/internal/grpc"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/internal/watch"
+	"github.com/psds-microservice/ticket-service/pkg/gen/ticket_service"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestLinkTicketsRejectsCycles(t *testing.T) {
+	for _, typ := range []model.TicketLinkType{model.TicketLinkParent, model.TicketLinkBlocks, model.TicketLinkDuplicateOf} {
+		t.Run(string(typ), func(t *testing.T) {
+			svc, ctx := newService(t)
+			a := createTicket(t, svc, ctx, nil)
+			b := createTicket(t, svc, ctx, nil)
+			c := createTicket(t, svc, ctx, nil)
+			for _, pair := range [][2]uint64{{a.ID, b.ID}, {b.ID, c.ID}} {
+				if _, err := svc.LinkTickets(ctx, pair[0], pair[1], typ); err != nil {
+					t.Fatalf("link %d -> %d: %v", pair[0], pair[1], err)
+				}
+			}
+			// A → B → C → A замкнул бы цикл через рекурсивный обход.
+			if _, err := svc.LinkTickets(ctx, c.ID, a.ID, typ); !errors.Is(err, errs.ErrLinkCycle) {
+				t.Errorf("link C -> A: err = %v, want ErrLinkCycle", err)
+			}
+			if _, err := svc.LinkTickets(ctx, b.ID, a.ID, typ); !errors.Is(err, errs.ErrLinkCycle) {
+				t.Errorf("link B -> A: err = %v, want ErrLinkCycle", err)
+			}
+		})
+	}
+
+	// relates_to симметрична и циклом не считается.
+	svc, ctx := newService(t)
+	a := createTicket(t, svc, ctx, nil)
+	b := createTicket(t, svc, ctx, nil)
+	c := createTicket(t, svc, ctx, nil)
+	for _, pair := range [][2]uint64{{a.ID, b.ID}, {b.ID, c.ID}, {c.ID, a.ID}} {
+		if _, err := svc.LinkTickets(ctx, pair[0], pair[1], model.TicketLinkRelatesTo); err != nil {
+			t.Errorf("relates_to %d -> %d: %v", pair[0], pair[1], err)
+		}
+	}
+	if _, err := svc.LinkTickets(ctx, b.ID, a.ID, model.TicketLinkRelatesTo); !errors.Is(err, errs.ErrLinkExists) {
+		t.Errorf("reverse relates_to: err = %v, want ErrLinkExists", err)
+	}
+}
+
+func TestLinkTicketsNormalisesChildAndAllowsOneParent(t *testing.T) {
+	svc, ctx := newService(t)
+	parent := createTicket(t, svc, ctx, nil)
+	child := createTicket(t, svc, ctx, nil)
+	other := createTicket(t, svc, ctx, nil)
+
+	// child → parent хранится как parent → child.
+	link, err := svc.LinkTickets(ctx, child.ID, parent.ID, model.TicketLinkChild)
+	if err != nil {
+		t.Fatalf("link child: %v", err)
+	}
+	if link.TicketID != parent.ID || link.LinkedTicketID != child.ID || link.LinkType != model.TicketLinkParent {
+		t.Errorf("child link stored as %d -%s-> %d, want %d -parent-> %d", link.TicketID, link.LinkType, link.LinkedTicketID, parent.ID, child.ID)
+	}
+	if _, err := svc.LinkTickets(ctx, parent.ID, child.ID, model.TicketLinkParent); !errors.Is(err, errs.ErrLinkExists) {
+		t.Errorf("same link as parent: err = %v, want ErrLinkExists", err)
+	}
+	links, err := svc.ListLinks(ctx, child.ID, model.TicketLinkChild)
+	if err != nil || len(links) != 1 || links[0].ID != link.ID {
+		t.Errorf("ListLinks(child) = %v, %v; want the normalised link", links, err)
+	}
+
+	if _, err := svc.LinkTickets(ctx, other.ID, child.ID, model.TicketLinkParent); !errors.Is(err, errs.ErrParentExists) {
+		t.Errorf("second parent: err = %v, want ErrParentExists", err)
+	}
+	if _, err := svc.LinkTickets(ctx, child.ID, other.ID, model.TicketLinkChild); !errors.Is(err, errs.ErrParentExists) {
+		t.Errorf("second parent via child: err = %v, want ErrParentExists", err)
+	}
+	if _, err := svc.LinkTickets(ctx, child.ID, child.ID, model.TicketLinkChild); !errors.Is(err, errs.ErrSelfLink) {
+		t.Errorf("self link: err = %v, want ErrSelfLink", err)
+	}
+
+	if err := svc.UnlinkTickets(ctx, child.ID, parent.ID, model.TicketLinkChild); err != nil {
+		t.Fatalf("unlink via child: %v", err)
+	}
+	if _, err := svc.LinkTickets(ctx, other.ID, child.ID, model.TicketLinkParent); err != nil {
+		t.Errorf("new parent after unlink: %v", err)
+	}
+}
+
+func TestCascadeClosePublishesOneEventPerChild(t *testing.T) {
+	svc, ctx := newService(t)
+	parent := createTicket(t, svc, ctx, nil)
+	child := createTicket(t, svc, ctx, nil)
+	grandchild := createTicket(t, svc, ctx, nil)
+	closedChild := createTicket(t, svc, ctx, func(tk *model.Ticket) { tk.Status = model.TicketStatusClosed })
+	unrelated := createTicket(t, svc, ctx, nil)
+	for _, pair := range [][2]uint64{{parent.ID, child.ID}, {child.ID, grandchild.ID}, {parent.ID, closedChild.ID}} {
+		if _, err := svc.LinkTickets(ctx, pair[0], pair[1], model.TicketLinkParent); err != nil {
+			t.Fatalf("link %d -> %d: %v", pair[0], pair[1], err)
+		}
+	}
+	// relates_to и blocks в каскад не входят.
+	if _, err := svc.LinkTickets(ctx, parent.ID, unrelated.ID, model.TicketLinkBlocks); err != nil {
+		t.Fatalf("link blocks: %v", err)
+	}
+
+	broadcaster := watch.NewBroadcaster(watch.DefaultBufferSize)
+	sub, _ := broadcaster.Subscribe("")
+	defer sub.Close()
+	server := grpcserver.NewServer(grpcserver.Deps{Ticket: svc, Watch: broadcaster})
+	callCtx := metadata.NewIncomingContext(ctx, metadata.Pairs("x-caller-id", parent.ClientID))
+	if _, err := server.UpdateTicket(callCtx, &ticket_service.UpdateTicketRequest{
+		Id:                   int64(parent.ID),
+		Status:               string(model.TicketStatusClosed),
+		CascadeCloseChildren: true,
+	}); err != nil {
+		t.Fatalf("UpdateTicket: %v", err)
+	}
+
+	events := make(map[uint64]int)
+	for done := false; !done; {
+		select {
+		case ev := <-sub.Events():
+			if ev.Type != watch.TypeUpdated || ev.Ticket.Status != model.TicketStatusClosed {
+				t.Errorf("event for ticket %d: type %s, status %s; want updated/closed", ev.Ticket.ID, ev.Type, ev.Ticket.Status)
+			}
+			events[ev.Ticket.ID]++
+		default:
+			done = true
+		}
+	}
+	want := map[uint64]int{parent.ID: 1, child.ID: 1, grandchild.ID: 1}
+	if len(events) != len(want) {
+		t.Errorf("events = %v, want one per ticket in %v", events, want)
+	}
+	for id, n := range want {
+		if events[id] != n {
+			t.Errorf("ticket %d: %d events, want %d", id, events[id], n)
+		}
+	}
+	if got, err := svc.GetByID(ctx, unrelated.ID); err != nil || got.Status != model.TicketStatusOpen {
+		t.Errorf("blocked ticket after cascade = %v, %v; want still open", got, err)
+	}
+}
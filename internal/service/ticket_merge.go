@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/psds-microservice/ticket-service/internal/errs"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// moveSessionsSQL переносит привязки сессий source-тикетов на target.
+const moveSessionsSQL = `
+INSERT INTO ticket_sessions (tenant_id, ticket_id, session_id, attached_at)
+SELECT @tenant, @target, session_id, attached_at FROM ticket_sessions WHERE tenant_id = @tenant AND ticket_id IN @sources
+ON CONFLICT DO NOTHING`
+
+// Merge вливает source-тикеты в target в одной транзакции: переносит связи и сессии, дописывает
+// notes источников в target и закрывает источники со статусом merged и ссылкой на target.
+// Сливаются только неархивные тикеты одного клиента (errs.ErrMergeClientMismatch).
+// Возвращает обновлённый target и влитые тикеты.
+func (s *TicketService) Merge(ctx context.Context, targetID uint64, sourceIDs []uint64) (*model.Ticket, []model.Ticket, error) {
+	var (
+		target  model.Ticket
+		sources []model.Ticket
+	)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Граф связей блокируется раньше тикетов — в том же порядке, что и в LinkTickets
+		// (блокировка графа, затем ссылки на строки тикетов), иначе возможен дедлок.
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", linkGraphLockKey).Error; err != nil {
+			return err
+		}
+		ids := append([]uint64{targetID}, sourceIDs...)
+		var locked []model.Ticket
+		// Блокируем в порядке id, чтобы встречные слияния не давали дедлок. Архивные тикеты
+		// не сливаются, как и не находятся через GetByID.
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ? AND archived_at IS NULL", ids).Order("id").Find(&locked).Error; err != nil {
+			return err
+		}
+		if len(locked) != len(ids) {
+			return errs.ErrTicketNotFound
+		}
+		byID := make(map[uint64]model.Ticket, len(locked))
+		for _, t := range locked {
+			byID[t.ID] = t
+		}
+		target = byID[targetID]
+		for _, t := range locked {
+			if t.Status == model.TicketStatusMerged {
+				return fmt.Errorf("ticket %d: %w", t.ID, errs.ErrTicketAlreadyMerged)
+			}
+			if t.LegalHold {
+				return fmt.Errorf("ticket %d: %w", t.ID, errs.ErrTicketOnLegalHold)
+			}
+			if t.ClientID != target.ClientID || t.TenantID != target.TenantID {
+				return fmt.Errorf("ticket %d: %w", t.ID, errs.ErrMergeClientMismatch)
+			}
+		}
+
+		notes := target.Notes
+		for _, id := range sourceIDs {
+			src := byID[id]
+			if src.Notes == "" {
+				continue
+			}
+			if notes != "" {
+				notes += "\n\n"
+			}
+			notes += fmt.Sprintf("--- merged from ticket #%d ---\n%s", src.ID, src.Notes)
+		}
+
+		tenantID, err := requestTenant(tx, target.TenantID)
+		if err != nil {
+			return err
+		}
+		if err := moveLinks(tx, tenantID, targetID, sourceIDs); err != nil {
+			return err
+		}
+		moveArgs := map[string]interface{}{"tenant": tenantID, "sources": sourceIDs, "target": targetID}
+		if err := tx.Exec(moveSessionsSQL, moveArgs).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("ticket_id IN ?", sourceIDs).Delete(&model.TicketSession{}).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := tx.Model(&model.Ticket{}).Where("id IN ?", sourceIDs).Updates(map[string]interface{}{
+			"status":         model.TicketStatusMerged,
+			"merged_into_id": targetID,
+			"closed_at":      now,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&target).Updates(map[string]interface{}{"notes": notes}).Error; err != nil {
+			return err
+		}
+		if err := withSessions(tx).First(&target, targetID).Error; err != nil {
+			return err
+		}
+		return tx.Where("id IN ?", sourceIDs).Order("id").Find(&sources).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &target, sources, nil
+}
+
+// moveLinks переносит связи source-тикетов на target; вызывающий держит блокировку графа связей
+// (linkGraphLockKey), поэтому проверки видят тот же граф, что и LinkTickets. Связь отбрасывается, если LinkTickets её бы не создал: петля между сливаемыми тикетами,
+// дубль существующей связи, второй parent у тикета или цикл по направленной связи.
+func moveLinks(tx *gorm.DB, tenantID string, targetID uint64, sourceIDs []uint64) error {
+	var links []model.TicketLink
+	if err := tx.Where("ticket_id IN ? OR linked_ticket_id IN ?", sourceIDs, sourceIDs).Order("created_at, id").Find(&links).Error; err != nil {
+		return err
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	if err := tx.Where("ticket_id IN ? OR linked_ticket_id IN ?", sourceIDs, sourceIDs).Delete(&model.TicketLink{}).Error; err != nil {
+		return err
+	}
+	sources := make(map[uint64]bool, len(sourceIDs))
+	for _, id := range sourceIDs {
+		sources[id] = true
+	}
+	repoint := func(id uint64) uint64 {
+		if sources[id] {
+			return targetID
+		}
+		return id
+	}
+	for _, l := range links {
+		from, to := repoint(l.TicketID), repoint(l.LinkedTicketID)
+		if from == to {
+			continue
+		}
+		var existing int64
+		if err := linkPairQuery(tx, from, to, l.LinkType).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			continue
+		}
+		if l.LinkType == model.TicketLinkParent {
+			var parents int64
+			if err := tx.Model(&model.TicketLink{}).Where("linked_ticket_id = ? AND link_type = ?", to, l.LinkType).Count(&parents).Error; err != nil {
+				return err
+			}
+			if parents > 0 {
+				continue
+			}
+		}
+		if directedLinkTypes[l.LinkType] {
+			var cycle bool
+			args := map[string]interface{}{"tenant": tenantID, "from": from, "to": to, "type": l.LinkType}
+			if err := tx.Raw(linkReachableSQL, args).Scan(&cycle).Error; err != nil {
+				return err
+			}
+			if cycle {
+				continue
+			}
+		}
+		moved := model.TicketLink{TenantID: tenantID, TicketID: from, LinkedTicketID: to, LinkType: l.LinkType, CreatedAt: l.CreatedAt}
+		if err := tx.Create(&moved).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
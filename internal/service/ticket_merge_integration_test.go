@@ -0,0 +1,107 @@
+//go:build integration
+
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/psds-microservice/ticket-service/internal/errs"
+	"github.com/psds-microservice/ticket-service/internal/model"
+)
+
+func TestMergeDropsLinksThatWouldFormACycle(t *testing.T) {
+	svc, ctx := newService(t)
+	target := createTicket(t, svc, ctx, nil)
+	child := createTicket(t, svc, ctx, nil)
+	source := createTicket(t, svc, ctx, nil)
+	other := createTicket(t, svc, ctx, nil)
+
+	// target → child → source (parent); source blocks other.
+	for _, l := range []struct {
+		from, to uint64
+		typ      model.TicketLinkType
+	}{
+		{target.ID, child.ID, model.TicketLinkParent},
+		{child.ID, source.ID, model.TicketLinkParent},
+		{source.ID, other.ID, model.TicketLinkBlocks},
+	} {
+		if _, err := svc.LinkTickets(ctx, l.from, l.to, l.typ); err != nil {
+			t.Fatalf("link %d -> %d: %v", l.from, l.to, err)
+		}
+	}
+
+	if _, _, err := svc.Merge(ctx, target.ID, []uint64{source.ID}); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	links, err := svc.ListLinks(ctx, target.ID, "")
+	if err != nil {
+		t.Fatalf("ListLinks: %v", err)
+	}
+	got := make(map[[2]uint64]model.TicketLinkType, len(links))
+	for _, l := range links {
+		got[[2]uint64{l.TicketID, l.LinkedTicketID}] = l.LinkType
+	}
+	// child → target замкнул бы цикл target → child → target и отброшен.
+	if _, ok := got[[2]uint64{child.ID, target.ID}]; ok {
+		t.Errorf("merge moved parent link %d -> %d and created a cycle", child.ID, target.ID)
+	}
+	if got[[2]uint64{target.ID, child.ID}] != model.TicketLinkParent || got[[2]uint64{target.ID, other.ID}] != model.TicketLinkBlocks || len(got) != 2 {
+		t.Errorf("links of target after merge = %v, want parent of child and blocks other", got)
+	}
+	if _, err := svc.LinkTickets(ctx, child.ID, target.ID, model.TicketLinkParent); err == nil {
+		t.Error("LinkTickets accepted the cycle that merge dropped")
+	}
+}
+
+func TestMergedTicketRejectsUpdates(t *testing.T) {
+	svc, ctx := newService(t)
+	target := createTicket(t, svc, ctx, nil)
+	source := createTicket(t, svc, ctx, nil)
+	if _, _, err := svc.Merge(ctx, target.ID, []uint64{source.ID}); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	for _, changes := range []map[string]interface{}{
+		{"status": string(model.TicketStatusOpen)},
+		{"subject": "edited"},
+	} {
+		if _, err := svc.Update(ctx, source.ID, changes); !errors.Is(err, errs.ErrTicketAlreadyMerged) {
+			t.Errorf("Update(%v) of a merged ticket: err = %v, want ErrTicketAlreadyMerged", changes, err)
+		}
+	}
+	results, err := svc.UpdateMany(ctx, []uint64{source.ID, target.ID}, map[string]interface{}{"priority": "high"}, false)
+	if err != nil {
+		t.Fatalf("UpdateMany: %v", err)
+	}
+	if !errors.Is(results[0].Err, errs.ErrTicketAlreadyMerged) || results[1].Err != nil {
+		t.Errorf("UpdateMany errors = %v, %v; want ErrTicketAlreadyMerged for the merged ticket only", results[0].Err, results[1].Err)
+	}
+}
+
+func TestMergeRejectsOtherClientsAndArchivedTickets(t *testing.T) {
+	svc, ctx := newService(t)
+	target := createTicket(t, svc, ctx, func(tk *model.Ticket) { tk.ClientID = "client-a"; tk.Notes = "a" })
+	foreign := createTicket(t, svc, ctx, func(tk *model.Ticket) { tk.ClientID = "client-b"; tk.Notes = "b" })
+	archived := createTicket(t, svc, ctx, func(tk *model.Ticket) { tk.ClientID = "client-a"; tk.Status = model.TicketStatusClosed })
+	if _, err := svc.Archive(ctx, archived.ID); err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+
+	if _, _, err := svc.Merge(ctx, target.ID, []uint64{foreign.ID}); !errors.Is(err, errs.ErrMergeClientMismatch) {
+		t.Errorf("Merge of another client's ticket: err = %v, want ErrMergeClientMismatch", err)
+	}
+	if got, err := svc.GetByID(ctx, target.ID); err != nil || got.Notes != "a" {
+		t.Errorf("target after rejected merge = %v, %v; want notes unchanged", got, err)
+	}
+	if got, err := svc.GetByID(ctx, foreign.ID); err != nil || got.Status == model.TicketStatusMerged {
+		t.Errorf("source after rejected merge = %v, %v; want it not merged", got, err)
+	}
+
+	if _, _, err := svc.Merge(ctx, target.ID, []uint64{archived.ID}); !errors.Is(err, errs.ErrTicketNotFound) {
+		t.Errorf("Merge of an archived source: err = %v, want ErrTicketNotFound", err)
+	}
+	if _, _, err := svc.Merge(ctx, archived.ID, []uint64{target.ID}); !errors.Is(err, errs.ErrTicketNotFound) {
+		t.Errorf("Merge into an archived target: err = %v, want ErrTicketNotFound", err)
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/psds-microservice/ticket-service/internal/errs"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// DefaultRefPrefix — префикс номеров тикетов, если для региона не задан свой.
+const DefaultRefPrefix = "TK"
+
+// allocateReferenceSQL увеличивает счётчик (tenant_id, prefix, year) и возвращает новое значение.
+// Строка счётчика остаётся заблокированной до конца транзакции, поэтому параллельные
+// CreateTicket с одним префиксом получают разные номера.
+const allocateReferenceSQL = `
+INSERT INTO ticket_reference_counters (tenant_id, prefix, year, last_value) VALUES (?, ?, ?, 1)
+ON CONFLICT (tenant_id, prefix, year) DO UPDATE SET last_value = ticket_reference_counters.last_value + 1
+RETURNING last_value`
+
+func (s *TicketService) refPrefix(region string) string {
+	if p, ok := s.opts.RefPrefixes[strings.ToLower(region)]; ok {
+		return p
+	}
+	return s.opts.DefaultRefPrefix
+}
+
+// allocateReference выдаёт следующий номер вида EU-2026-000123 (нумерация своя у каждого тенанта).
+// Вызывать внутри транзакции вставки тикета.
+func allocateReference(tx *gorm.DB, tenantID, prefix string, year int) (string, error) {
+	var n int64
+	if err := tx.Raw(allocateReferenceSQL, tenantID, prefix, year).Scan(&n).Error; err != nil {
+		return "", fmt.Errorf("allocate ticket reference: %w", err)
+	}
+	return fmt.Sprintf("%s-%d-%06d", prefix, year, n), nil
+}
+
+// GetByReference ищет тикет по номеру; архивный — только при includeArchived.
+func (s *TicketService) GetByReference(ctx context.Context, ref string, includeArchived bool) (*model.Ticket, error) {
+	db := s.db.WithContext(ctx)
+	if !includeArchived {
+		db = db.Where("archived_at IS NULL")
+	}
+	var t model.Ticket
+	err := withSessions(db).Where("reference = ?", strings.ToUpper(strings.TrimSpace(ref))).First(&t).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrTicketNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+
+	"github.com/psds-microservice/ticket-service/internal/errs"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// searchQuerySQL — запрос websearch_to_tsquery в конфигурации региона (параметры: регион, текст).
+// Без региона — 'simple': совпадают слова без морфологии в тикетах любого региона.
+const searchQuerySQL = "websearch_to_tsquery(ticket_search_config(?), ?)"
+
+// searchHeadlineOptions — выделение найденных слов во фрагментах.
+const searchHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10"
+
+// SearchOptions — страница и видимость удалённых/архивных тикетов в Search.
+type SearchOptions struct {
+	Limit           int
+	Offset          int
+	IncludeDeleted  bool
+	IncludeArchived bool
+}
+
+// SearchHit — тикет, найденный Search.
+type SearchHit struct {
+	Ticket         model.Ticket
+	Rank           float64
+	SubjectSnippet string
+	NotesSnippet   string
+}
+
+// Search ищет тикеты по словам в subject и notes (колонка search_vector) с условиями filter,
+// по убыванию релевантности. Регион фильтра выбирает языковую конфигурацию запроса.
+func (s *TicketService) Search(ctx context.Context, query string, filter ListFilter, opts SearchOptions) ([]SearchHit, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	// В БД шифртекст: индекс по нему бесполезен.
+	if s.opts.SubjectEncrypted {
+		return nil, errs.ErrSearchUnavailable
+	}
+	var rows []struct {
+		ID             uint64
+		Rank           float64
+		SubjectSnippet string
+		NotesSnippet   string
+	}
+	var byID map[uint64]*model.Ticket
+	// Scan читает строки через Row-коллбэки: настройки RLS задаются только в транзакции.
+	err := s.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		if opts.IncludeDeleted {
+			db = db.Unscoped()
+		}
+		tx := db.Model(&model.Ticket{})
+		if !opts.IncludeArchived {
+			tx = tx.Where("archived_at IS NULL")
+		}
+		tx = filter.apply(tx)
+		err := tx.Select("id, ts_rank(search_vector, "+searchQuerySQL+") AS rank, "+
+			"ts_headline(ticket_search_config(?), coalesce(subject, ''), "+searchQuerySQL+", ?) AS subject_snippet, "+
+			"ts_headline(ticket_search_config(?), coalesce(notes, ''), "+searchQuerySQL+", ?) AS notes_snippet",
+			filter.Region, query,
+			filter.Region, filter.Region, query, searchHeadlineOptions,
+			filter.Region, filter.Region, query, searchHeadlineOptions).
+			Where("search_vector @@ "+searchQuerySQL, filter.Region, query).
+			Order("rank DESC, id DESC").
+			Limit(opts.Limit).
+			Offset(opts.Offset).
+			Scan(&rows).Error
+		if err != nil || len(rows) == 0 {
+			return err
+		}
+		ids := make([]uint64, len(rows))
+		for i, r := range rows {
+			ids[i] = r.ID
+		}
+		byID, err = ticketsByID(db, ids)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	hits := make([]SearchHit, 0, len(rows))
+	for _, r := range rows {
+		// Тикет мог исчезнуть между запросами.
+		t, ok := byID[r.ID]
+		if !ok {
+			continue
+		}
+		hits = append(hits, SearchHit{Ticket: *t, Rank: r.Rank, SubjectSnippet: r.SubjectSnippet, NotesSnippet: r.NotesSnippet})
+	}
+	return hits, nil
+}
+
+// ticketsByID загружает тикеты с сессиями по ID (ранжированные выборки сначала получают ID,
+// затем — сами тикеты).
+func ticketsByID(db *gorm.DB, ids []uint64) (map[uint64]*model.Ticket, error) {
+	var tickets []model.Ticket
+	if err := withSessions(db).Find(&tickets, ids).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint64]*model.Ticket, len(tickets))
+	for i := range tickets {
+		byID[tickets[i].ID] = &tickets[i]
+	}
+	return byID, nil
+}
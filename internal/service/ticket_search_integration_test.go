@@ -0,0 +1,38 @@
+//go:build integration
+
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/internal/service"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+)
+
+func TestSearchUnderRLS(t *testing.T) {
+	svc, ctx := newService(t)
+	printer := createTicket(t, svc, ctx, func(tk *model.Ticket) {
+		tk.Subject, tk.Notes = "Printer is jammed", "paper stuck in the printer tray"
+	})
+	createTicket(t, svc, ctx, func(tk *model.Ticket) { tk.Subject = "Password reset" })
+	createTicket(t, svc, tenant.WithID(context.Background(), "globex"), func(tk *model.Ticket) {
+		tk.Subject = "Printer on fire"
+	})
+
+	hits, err := svc.Search(ctx, "printer", service.ListFilter{}, service.SearchOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(hits) != 1 || hits[0].Ticket.ID != printer.ID {
+		t.Fatalf("Search(printer) = %d hits, want only ticket %d of tenant acme", len(hits), printer.ID)
+	}
+	if !strings.Contains(hits[0].SubjectSnippet, "<mark>Printer</mark>") {
+		t.Errorf("SubjectSnippet = %q, want the word highlighted", hits[0].SubjectSnippet)
+	}
+	if hits[0].Rank <= 0 {
+		t.Errorf("Rank = %v, want > 0", hits[0].Rank)
+	}
+}
@@ -3,20 +3,15 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/psds-microservice/ticket-service/internal/database"
 	"github.com/psds-microservice/ticket-service/internal/errs"
 	"github.com/psds-microservice/ticket-service/internal/model"
 	"gorm.io/gorm"
 )
 
-// Allowed List filter keys (column = ?) to prevent SQL injection.
-var allowedListFilters = map[string]bool{
-	"client_id = ?":   true,
-	"operator_id = ?": true,
-	"status = ?":      true,
-	"region = ?":      true,
-}
-
 // Allowed Update field names to prevent SQL injection.
 var allowedUpdateFields = map[string]bool{
 	"subject":  true,
@@ -30,25 +25,190 @@ var allowedUpdateFields = map[string]bool{
 type TicketServicer interface {
 	Create(ctx context.Context, t *model.Ticket) error
 	GetByID(ctx context.Context, id uint64) (*model.Ticket, error)
-	List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]model.Ticket, int64, error)
+	GetByReference(ctx context.Context, ref string, includeArchived bool) (*model.Ticket, error)
+	// GetByIDWithArchived как GetByID, но находит и архивный тикет.
+	GetByIDWithArchived(ctx context.Context, id uint64) (*model.Ticket, error)
+	// GetByIDWithDeleted как GetByID, но находит и мягко удалённый, и архивный тикет.
+	GetByIDWithDeleted(ctx context.Context, id uint64) (*model.Ticket, error)
+	// GetByIDs возвращает найденные тикеты в порядке ids.
+	GetByIDs(ctx context.Context, ids []uint64, includeDeleted bool) ([]model.Ticket, error)
+	List(ctx context.Context, filter ListFilter, opts ListOptions) (*ListPage, error)
+	Search(ctx context.Context, query string, filter ListFilter, opts SearchOptions) ([]SearchHit, error)
+	FindSimilar(ctx context.Context, q SimilarQuery) ([]SimilarTicket, error)
 	Update(ctx context.Context, id uint64, changes map[string]interface{}) (*model.Ticket, error)
+	// UpdateCascade как Update, но при закрытии тикета в той же транзакции закрывает его дочерние тикеты.
+	UpdateCascade(ctx context.Context, id uint64, changes map[string]interface{}) (*model.Ticket, []model.Ticket, error)
+	// UpdateMany применяет изменения к нескольким тикетам с результатом по каждому.
+	UpdateMany(ctx context.Context, ids []uint64, changes map[string]interface{}, cascade bool) ([]UpdateResult, error)
+
+	LinkTickets(ctx context.Context, ticketID, linkedID uint64, linkType model.TicketLinkType) (*model.TicketLink, error)
+	UnlinkTickets(ctx context.Context, ticketID, linkedID uint64, linkType model.TicketLinkType) error
+	ListLinks(ctx context.Context, ticketID uint64, linkType model.TicketLinkType) ([]model.TicketLink, error)
+
+	Merge(ctx context.Context, targetID uint64, sourceIDs []uint64) (*model.Ticket, []model.Ticket, error)
+
+	Delete(ctx context.Context, id uint64) (*model.Ticket, error)
+	Restore(ctx context.Context, id uint64) (*model.Ticket, error)
+	Archive(ctx context.Context, id uint64) (*model.Ticket, error)
+
+	PlaceLegalHold(ctx context.Context, id uint64, reason, caseID, actor string) (*model.Ticket, error)
+	ReleaseLegalHold(ctx context.Context, id uint64, reason, actor string) (*model.Ticket, error)
+
+	AttachSession(ctx context.Context, ticketID uint64, sessionID string) (*model.Ticket, error)
+	ListBySession(ctx context.Context, sessionID string) ([]model.Ticket, error)
+
+	ExportClientData(ctx context.Context, clientID, actor string) (*model.ClientDataExport, error)
+	EraseClientData(ctx context.Context, clientID, actor string) ([]model.Ticket, error)
+}
+
+// SessionDedupPolicy — что делать с CreateTicket для сессии, у которой уже есть незакрытый тикет.
+type SessionDedupPolicy string
+
+const (
+	// SessionDedupReject — вернуть ошибку с ID существующего тикета.
+	SessionDedupReject SessionDedupPolicy = "reject"
+	// SessionDedupReturnExisting — вернуть существующий тикет вместо создания нового.
+	SessionDedupReturnExisting SessionDedupPolicy = "return_existing"
+	// SessionDedupAllow — создавать тикет без проверки.
+	SessionDedupAllow SessionDedupPolicy = "allow"
+)
+
+// finalStatuses — статусы, в которых тикет не считается открытым.
+var finalStatuses = []model.TicketStatus{model.TicketStatusClosed, model.TicketStatusMerged}
+
+// Options — настройки TicketService.
+type Options struct {
+	// RefPrefixes — префикс номера тикета по региону (ключи в нижнем регистре).
+	RefPrefixes map[string]string
+	// DefaultRefPrefix — префикс для регионов без своего префикса.
+	DefaultRefPrefix string
+	// SubjectEncrypted — subject и notes шифруются (ENCRYPTION_KEYFILE): фильтры по subject и Search недоступны.
+	SubjectEncrypted bool
+}
+
+// TotalMode — как List считает общее число подходящих тикетов.
+type TotalMode string
+
+const (
+	// TotalExact — точный COUNT(*) (на больших выборках дорого).
+	TotalExact TotalMode = "exact"
+	// TotalEstimate — COUNT(*) не дальше EstimateCap строк; больше — Total = EstimateCap, TotalEstimated.
+	TotalEstimate TotalMode = "estimate"
+	// TotalNone — не считать (Total = -1).
+	TotalNone TotalMode = "none"
+)
+
+// EstimateCap — предел подсчёта в режиме TotalEstimate.
+const EstimateCap = 10000
+
+// ListCursor — позиция keyset-пагинации: следующая страница начинается после тикета
+// с ключом сортировки (Time или Rank — в зависимости от ListOrder) и ID.
+type ListCursor struct {
+	Time time.Time
+	Rank int
+	ID   uint64
+}
+
+// ListOptions — пагинация и видимость удалённых/архивных тикетов в List.
+// After и Offset взаимоисключающие: After — keyset-пагинация, Offset — для старых клиентов.
+// After действует только с тем же Order, с которым был получен.
+type ListOptions struct {
+	Limit  int
+	Offset int
+	After  *ListCursor
+	// Order — пустое значение означает DefaultListOrder.
+	Order           ListOrder
+	Total           TotalMode
+	IncludeDeleted  bool
+	IncludeArchived bool
+}
+
+// ListPage — страница List.
+type ListPage struct {
+	Items []model.Ticket
+	// Total — -1 при TotalNone.
+	Total          int64
+	TotalEstimated bool
+	// Next — позиция следующей страницы; nil — это последняя страница (или Limit не задан).
+	Next *ListCursor
 }
 
 type TicketService struct {
-	db *gorm.DB
+	db   *gorm.DB
+	opts Options
 }
 
-func NewTicketService(db *gorm.DB) *TicketService {
-	return &TicketService{db: db}
+func NewTicketService(db *gorm.DB, opts Options) *TicketService {
+	if opts.DefaultRefPrefix == "" {
+		opts.DefaultRefPrefix = DefaultRefPrefix
+	}
+	return &TicketService{db: db, opts: opts}
+}
+
+// withSessions подгружает привязанные сессии тикетов.
+func withSessions(db *gorm.DB) *gorm.DB {
+	return db.Preload("Sessions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("attached_at, session_id")
+	})
 }
 
+// openSessionIndex — уникальный индекс: не больше одного открытого тикета на сессию (миграция 000012).
+const openSessionIndex = "ux_tickets_open_session"
+
 func (s *TicketService) Create(ctx context.Context, t *model.Ticket) error {
-	return s.db.WithContext(ctx).Create(t).Error
+	// Исходная сессия сразу попадает в ticket_sessions (GORM вставит её в той же транзакции).
+	if t.SessionID != "" && len(t.Sessions) == 0 {
+		t.Sessions = []model.TicketSession{{SessionID: t.SessionID}}
+	}
+	// Номер выделяется в одной транзакции со вставкой: при откате счётчик тоже откатывается,
+	// поэтому номера идут без пропусков.
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tenantID, err := requestTenant(tx, t.TenantID)
+		if err != nil {
+			return err
+		}
+		ref, err := allocateReference(tx, tenantID, s.refPrefix(t.Region), time.Now().UTC().Year())
+		if err != nil {
+			return err
+		}
+		t.TenantID, t.Reference = tenantID, ref
+		return tx.Create(t).Error
+	})
+	if err != nil {
+		t.Reference = ""
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) && database.ViolatedConstraint(err) == openSessionIndex && !t.AllowDuplicateSession {
+		// Открытый тикет по этой сессии уже есть: находим его для ответа клиенту. Другие нарушения
+		// уникальности (например, номера тикета) возвращаются как есть.
+		var existing model.Ticket
+		if findErr := s.db.WithContext(ctx).
+			Where("session_id = ? AND status NOT IN ? AND NOT allow_duplicate_session", t.SessionID, finalStatuses).
+			First(&existing).Error; findErr == nil {
+			return &errs.OpenSessionTicketError{SessionID: t.SessionID, TicketID: existing.ID}
+		}
+	}
+	return err
 }
 
+// GetByID возвращает тикет; удалённый и архивный тикет не находится (ErrTicketNotFound).
 func (s *TicketService) GetByID(ctx context.Context, id uint64) (*model.Ticket, error) {
+	return getByID(s.db.WithContext(ctx), id, false)
+}
+
+func (s *TicketService) GetByIDWithArchived(ctx context.Context, id uint64) (*model.Ticket, error) {
+	return getByID(s.db.WithContext(ctx), id, true)
+}
+
+func (s *TicketService) GetByIDWithDeleted(ctx context.Context, id uint64) (*model.Ticket, error) {
+	return getByID(s.db.WithContext(ctx).Unscoped(), id, true)
+}
+
+func getByID(db *gorm.DB, id uint64, includeArchived bool) (*model.Ticket, error) {
+	if !includeArchived {
+		db = db.Where("archived_at IS NULL")
+	}
 	var t model.Ticket
-	if err := s.db.WithContext(ctx).First(&t, id).Error; err != nil {
+	if err := withSessions(db).First(&t, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errs.ErrTicketNotFound
 		}
@@ -57,41 +217,103 @@ func (s *TicketService) GetByID(ctx context.Context, id uint64) (*model.Ticket,
 	return &t, nil
 }
 
-func (s *TicketService) List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]model.Ticket, int64, error) {
-	var items []model.Ticket
-	var total int64
+func (s *TicketService) List(ctx context.Context, filter ListFilter, opts ListOptions) (*ListPage, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	if filter.usesSubject() && s.opts.SubjectEncrypted {
+		return nil, errs.ErrSubjectFilterUnavailable
+	}
+	order := opts.Order
+	if order.Field == "" {
+		order = DefaultListOrder
+	}
 	tx := s.db.WithContext(ctx).Model(&model.Ticket{})
-	for k, v := range filter {
-		if !allowedListFilters[k] {
-			continue // ignore unknown keys (whitelist)
-		}
-		tx = tx.Where(k, v)
+	if opts.IncludeDeleted {
+		tx = tx.Unscoped()
 	}
+	if !opts.IncludeArchived {
+		tx = tx.Where("archived_at IS NULL")
+	}
+	tx = filter.apply(tx)
+	page := &ListPage{Total: -1}
 	// Count total before pagination
-	if err := tx.Count(&total).Error; err != nil {
-		return nil, 0, err
+	switch opts.Total {
+	case TotalNone:
+	case TotalEstimate:
+		if err := s.db.WithContext(ctx).Table("(?) AS capped", tx.Session(&gorm.Session{}).Select("1").Limit(EstimateCap+1)).
+			Count(&page.Total).Error; err != nil {
+			return nil, err
+		}
+		if page.Total > EstimateCap {
+			page.Total, page.TotalEstimated = EstimateCap, true
+		}
+	default:
+		if err := tx.Session(&gorm.Session{}).Count(&page.Total).Error; err != nil {
+			return nil, err
+		}
+	}
+	// Apply pagination. Лишняя строка показывает, есть ли следующая страница.
+	if opts.After != nil {
+		tx = order.after(tx, opts.After)
+	}
+	if opts.Limit > 0 {
+		tx = tx.Limit(opts.Limit + 1)
 	}
-	// Apply pagination
-	if limit > 0 {
-		tx = tx.Limit(limit)
+	if opts.Offset > 0 {
+		tx = tx.Offset(opts.Offset)
 	}
-	if offset > 0 {
-		tx = tx.Offset(offset)
+	if err := withSessions(order.apply(tx)).Find(&page.Items).Error; err != nil {
+		return nil, err
 	}
-	if err := tx.Order("created_at DESC").Find(&items).Error; err != nil {
-		return nil, 0, err
+	if opts.Limit > 0 && len(page.Items) > opts.Limit {
+		page.Items = page.Items[:opts.Limit]
+		page.Next = order.cursor(&page.Items[len(page.Items)-1])
 	}
-	return items, total, nil
+	return page, nil
 }
 
 func (s *TicketService) Update(ctx context.Context, id uint64, changes map[string]interface{}) (*model.Ticket, error) {
+	return applyUpdate(s.db.WithContext(ctx), id, changes)
+}
+
+func (s *TicketService) UpdateCascade(ctx context.Context, id uint64, changes map[string]interface{}) (*model.Ticket, []model.Ticket, error) {
+	var (
+		t        *model.Ticket
+		children []model.Ticket
+	)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if t, err = applyUpdate(tx, id, changes); err != nil {
+			return err
+		}
+		if t.Status != model.TicketStatusClosed {
+			return nil
+		}
+		children, err = closeDescendants(tx, t)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, children, nil
+}
+
+func applyUpdate(db *gorm.DB, id uint64, changes map[string]interface{}) (*model.Ticket, error) {
 	var t model.Ticket
-	if err := s.db.WithContext(ctx).First(&t, id).Error; err != nil {
+	if err := withSessions(db).First(&t, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errs.ErrTicketNotFound
 		}
 		return nil, err
 	}
+	if t.LegalHold {
+		return nil, fmt.Errorf("ticket %d: %w", t.ID, errs.ErrTicketOnLegalHold)
+	}
+	// Влитый тикет только указывает на target: переоткрыть или изменить его нельзя.
+	if t.Status == model.TicketStatusMerged {
+		return nil, fmt.Errorf("ticket %d: %w", t.ID, errs.ErrTicketAlreadyMerged)
+	}
 	whitelisted := make(map[string]interface{})
 	for k, v := range changes {
 		if allowedUpdateFields[k] {
@@ -101,7 +323,17 @@ func (s *TicketService) Update(ctx context.Context, id uint64, changes map[strin
 	if len(whitelisted) == 0 {
 		return &t, nil
 	}
-	if err := s.db.WithContext(ctx).Model(&t).Updates(whitelisted).Error; err != nil {
+	// closed_at следует за статусом: выставляется при закрытии, сбрасывается при переоткрытии.
+	if st, ok := whitelisted["status"]; ok {
+		if model.TicketStatus(fmt.Sprint(st)) == model.TicketStatusClosed {
+			if t.ClosedAt == nil {
+				whitelisted["closed_at"] = time.Now()
+			}
+		} else {
+			whitelisted["closed_at"] = nil
+		}
+	}
+	if err := db.Model(&t).Updates(whitelisted).Error; err != nil {
 		return nil, err
 	}
 	return &t, nil
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"github.com/psds-microservice/ticket-service/internal/errs"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"gorm.io/gorm/clause"
+)
+
+// AttachSession привязывает сессию к тикету. Повторная привязка — no-op.
+func (s *TicketService) AttachSession(ctx context.Context, ticketID uint64, sessionID string) (*model.Ticket, error) {
+	t, err := s.GetByID(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if t.LegalHold {
+		return nil, errs.ErrTicketOnLegalHold
+	}
+	ts := &model.TicketSession{TicketID: ticketID, SessionID: sessionID}
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(ts).Error; err != nil {
+		return nil, err
+	}
+	return s.GetByID(ctx, ticketID)
+}
+
+// ListBySession возвращает тикеты, к которым привязана сессия (по индексу ticket_sessions.session_id).
+func (s *TicketService) ListBySession(ctx context.Context, sessionID string) ([]model.Ticket, error) {
+	db := s.db.WithContext(ctx)
+	sub := db.Model(&model.TicketSession{}).Select("ticket_id").Where("session_id = ?", sessionID)
+	var items []model.Ticket
+	if err := withSessions(db).Where("id IN (?)", sub).Order("created_at DESC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/psds-microservice/ticket-service/internal/errs"
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// similarTextSQL — текст тикета для сравнения; совпадает с выражением индекса idx_tickets_text_trgm.
+const similarTextSQL = "(coalesce(subject, '') || ' ' || coalesce(notes, ''))"
+
+const (
+	// SimilarityIndexThreshold — порог оператора % (pg_trgm.similarity_threshold по умолчанию):
+	// ниже него индекс не находит кандидатов, поэтому MinSimilarity не бывает меньше.
+	SimilarityIndexThreshold = 0.3
+	// DefaultMinSimilarity — порог похожести по умолчанию.
+	DefaultMinSimilarity = 0.45
+	// DefaultSimilarWindow — за какой период ищутся похожие тикеты по умолчанию.
+	DefaultSimilarWindow = 30 * 24 * time.Hour
+)
+
+// SimilarQuery — параметры FindSimilar.
+type SimilarQuery struct {
+	Subject, Notes string
+	// ClientID — только тикеты клиента (пусто — все тикеты тенанта).
+	ClientID string
+	// Since — только тикеты, созданные не раньше.
+	Since time.Time
+	// MinSimilarity — порог similarity() от SimilarityIndexThreshold до 1.
+	MinSimilarity float64
+	Limit         int
+	// IncludeClosed — учитывать закрытые и объединённые тикеты.
+	IncludeClosed bool
+	// ExcludeID — не возвращать этот тикет (только что созданный).
+	ExcludeID uint64
+}
+
+// SimilarTicket — похожий тикет и степень похожести (0..1).
+type SimilarTicket struct {
+	Ticket     model.Ticket
+	Similarity float64
+}
+
+// FindSimilar ищет тикеты с похожими subject и notes (pg_trgm), по убыванию похожести.
+func (s *TicketService) FindSimilar(ctx context.Context, q SimilarQuery) ([]SimilarTicket, error) {
+	if s.opts.SubjectEncrypted {
+		return nil, errs.ErrSearchUnavailable
+	}
+	text := strings.TrimSpace(q.Subject + " " + q.Notes)
+	if text == "" {
+		return nil, nil
+	}
+	if q.MinSimilarity < SimilarityIndexThreshold {
+		q.MinSimilarity = SimilarityIndexThreshold
+	}
+	var rows []struct {
+		ID         uint64
+		Similarity float64
+	}
+	var byID map[uint64]*model.Ticket
+	// Scan читает строки через Row-коллбэки: настройки RLS задаются только в транзакции.
+	err := s.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		tx := db.Model(&model.Ticket{}).
+			Where("archived_at IS NULL").
+			Where(similarTextSQL+" % ?", text).
+			Where("similarity("+similarTextSQL+", ?) >= ?", text, q.MinSimilarity).
+			Where("created_at >= ?", q.Since)
+		if q.ClientID != "" {
+			tx = tx.Where("client_id = ?", q.ClientID)
+		}
+		if !q.IncludeClosed {
+			tx = tx.Where("status NOT IN ?", finalStatuses)
+		}
+		if q.ExcludeID != 0 {
+			tx = tx.Where("id <> ?", q.ExcludeID)
+		}
+		err := tx.Select("id, similarity("+similarTextSQL+", ?) AS similarity", text).
+			Order("similarity DESC, id DESC").
+			Limit(q.Limit).
+			Scan(&rows).Error
+		if err != nil || len(rows) == 0 {
+			return err
+		}
+		ids := make([]uint64, len(rows))
+		for i, r := range rows {
+			ids[i] = r.ID
+		}
+		byID, err = ticketsByID(db, ids)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	similar := make([]SimilarTicket, 0, len(rows))
+	for _, r := range rows {
+		if t, ok := byID[r.ID]; ok {
+			similar = append(similar, SimilarTicket{Ticket: *t, Similarity: r.Similarity})
+		}
+	}
+	return similar, nil
+}
@@ -0,0 +1,47 @@
+//go:build integration
+
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/internal/service"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+)
+
+func TestFindSimilarUnderRLS(t *testing.T) {
+	svc, ctx := newService(t)
+	vpn := createTicket(t, svc, ctx, func(tk *model.Ticket) {
+		tk.Subject, tk.Notes = "VPN connection drops every hour", "client on windows laptop"
+	})
+	createTicket(t, svc, ctx, func(tk *model.Ticket) { tk.Subject = "Invoice address change" })
+	createTicket(t, svc, tenant.WithID(context.Background(), "globex"), func(tk *model.Ticket) {
+		tk.Subject, tk.Notes = "VPN connection drops every hour", "client on windows laptop"
+	})
+
+	query := service.SimilarQuery{
+		Subject:       "VPN connection drops every hour",
+		Notes:         "windows laptop",
+		Since:         time.Now().Add(-time.Hour),
+		MinSimilarity: service.DefaultMinSimilarity,
+		Limit:         10,
+	}
+	similar, err := svc.FindSimilar(ctx, query)
+	if err != nil {
+		t.Fatalf("FindSimilar: %v", err)
+	}
+	if len(similar) != 1 || similar[0].Ticket.ID != vpn.ID {
+		t.Fatalf("FindSimilar = %d tickets, want only ticket %d of tenant acme", len(similar), vpn.ID)
+	}
+	if similar[0].Similarity < service.DefaultMinSimilarity {
+		t.Errorf("Similarity = %v, want >= %v", similar[0].Similarity, service.DefaultMinSimilarity)
+	}
+
+	query.ExcludeID = vpn.ID
+	if similar, err = svc.FindSimilar(ctx, query); err != nil || len(similar) != 0 {
+		t.Errorf("FindSimilar with ExcludeID = %d tickets, %v; want none", len(similar), err)
+	}
+}
@@ -0,0 +1,87 @@
+package tenant
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// fieldName — поле моделей, принадлежащих тенанту.
+const fieldName = "TenantID"
+
+// Plugin — GORM-плагин: для моделей с полем TenantID добавляет условие tenant_id = <тенант
+// из контекста> в SELECT/UPDATE/DELETE (включая подзапросы и Preload) и проставляет
+// tenant_id при INSERT. Без тенанта в контексте запрос завершается ErrMissing.
+// Raw/Exec плагин не видит — в таком SQL tenant_id указывается явно.
+type Plugin struct{}
+
+func (Plugin) Name() string { return "tenant" }
+
+func (p Plugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().Before("gorm:create").Register("tenant:create", p.assign); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("tenant:query", p.scope); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("tenant:update", p.scope); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("tenant:delete", p.scope); err != nil {
+		return err
+	}
+	return cb.Row().Before("gorm:row").Register("tenant:row", p.scope)
+}
+
+func tenantField(db *gorm.DB) *schema.Field {
+	if db.Error != nil || db.Statement.Schema == nil || db.Statement.SQL.Len() > 0 {
+		return nil
+	}
+	return db.Statement.Schema.LookUpField(fieldName)
+}
+
+func (Plugin) scope(db *gorm.DB) {
+	f := tenantField(db)
+	if f == nil || IsSystem(db.Statement.Context) {
+		return
+	}
+	id, ok := FromContext(db.Statement.Context)
+	if !ok {
+		_ = db.AddError(ErrMissing)
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: f.DBName}, Value: id},
+	}})
+}
+
+// assign проставляет tenant_id создаваемым строкам. Значение, заданное вызывающим, перезаписывается —
+// кроме служебного контекста, где тенант берётся из самой строки.
+func (Plugin) assign(db *gorm.DB) {
+	f := tenantField(db)
+	if f == nil || IsSystem(db.Statement.Context) {
+		return
+	}
+	id, ok := FromContext(db.Statement.Context)
+	if !ok {
+		_ = db.AddError(ErrMissing)
+		return
+	}
+	ctx, rv := db.Statement.Context, db.Statement.ReflectValue
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := f.Set(ctx, reflect.Indirect(rv.Index(i)), id); err != nil {
+				_ = db.AddError(err)
+				return
+			}
+		}
+	case reflect.Struct:
+		if err := f.Set(ctx, rv, id); err != nil {
+			_ = db.AddError(err)
+		}
+	}
+}
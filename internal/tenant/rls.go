@@ -0,0 +1,128 @@
+package tenant
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// setRLSConfigSQL задаёт настройки, на которые опираются политики RLS (миграция 000013).
+// is_local = true — действуют до конца транзакции, как SET LOCAL.
+const setRLSConfigSQL = "SELECT set_config('app.tenant_id', $1, true), set_config('app.bypass_rls', $2, true)"
+
+const rlsStartedKey = "tenant:rls_started_transaction"
+
+// ErrRowOutsideTransaction — Row/Rows/Scan вызван вне транзакции при включённом RLS.
+var ErrRowOutsideTransaction = errors.New("tenant: Row/Rows/Scan outside a transaction cannot set RLS settings; use Find or run it in db.Transaction")
+
+// RLS — GORM-плагин для row-level security: каждый запрос выполняется в транзакции,
+// в которой заданы app.tenant_id (тенант из контекста) или app.bypass_rls (WithSystem).
+// Запрос вне транзакции открывает собственную и фиксирует её в конце цепочки коллбэков.
+// Row/Rows/Scan вне транзакции так обернуть нельзя (строки читаются уже после коллбэков):
+// такой запрос не видел бы ни одной строки, поэтому он завершается ErrRowOutsideTransaction.
+// Для них — Find (коллбэки Query) или db.Transaction.
+type RLS struct{}
+
+func (RLS) Name() string { return "tenant_rls" }
+
+func (p RLS) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().Before("*").Register("tenant:rls_begin", p.begin); err != nil {
+		return err
+	}
+	if err := cb.Create().After("*").Register("tenant:rls_commit", p.commit); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("*").Register("tenant:rls_begin", p.begin); err != nil {
+		return err
+	}
+	if err := cb.Query().After("*").Register("tenant:rls_commit", p.commit); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("*").Register("tenant:rls_begin", p.begin); err != nil {
+		return err
+	}
+	if err := cb.Update().After("*").Register("tenant:rls_commit", p.commit); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("*").Register("tenant:rls_begin", p.begin); err != nil {
+		return err
+	}
+	if err := cb.Delete().After("*").Register("tenant:rls_commit", p.commit); err != nil {
+		return err
+	}
+	if err := cb.Raw().Before("*").Register("tenant:rls_begin", p.begin); err != nil {
+		return err
+	}
+	if err := cb.Raw().After("*").Register("tenant:rls_commit", p.commit); err != nil {
+		return err
+	}
+	return cb.Row().Before("*").Register("tenant:rls_row", p.row)
+}
+
+// rlsSettings — значения app.tenant_id и app.bypass_rls для контекста запроса.
+func rlsSettings(db *gorm.DB) (tenantID, bypass string) {
+	if IsSystem(db.Statement.Context) {
+		return "", "on"
+	}
+	id, _ := FromContext(db.Statement.Context)
+	return id, "off"
+}
+
+func inTransaction(db *gorm.DB) bool {
+	_, ok := db.Statement.ConnPool.(gorm.TxCommitter)
+	return ok
+}
+
+// begin открывает транзакцию, если запрос выполняется вне неё, и задаёт настройки RLS.
+func (p RLS) begin(db *gorm.DB) {
+	if db.Error != nil || db.DryRun {
+		return
+	}
+	if !inTransaction(db) {
+		tx := db.Begin()
+		if tx.Error != nil {
+			_ = db.AddError(tx.Error)
+			return
+		}
+		db.Statement.ConnPool = tx.Statement.ConnPool
+		db.InstanceSet(rlsStartedKey, true)
+	}
+	p.configure(db)
+}
+
+// row задаёт настройки RLS для Row/Rows/Scan; вне транзакции — ErrRowOutsideTransaction.
+func (p RLS) row(db *gorm.DB) {
+	if db.Error != nil || db.DryRun {
+		return
+	}
+	if !inTransaction(db) {
+		_ = db.AddError(ErrRowOutsideTransaction)
+		return
+	}
+	p.configure(db)
+}
+
+// configure задаёт настройки RLS в текущей транзакции (вне транзакции — ничего не делает).
+func (RLS) configure(db *gorm.DB) {
+	if db.Error != nil || db.DryRun || !inTransaction(db) {
+		return
+	}
+	tenantID, bypass := rlsSettings(db)
+	if _, err := db.Statement.ConnPool.ExecContext(db.Statement.Context, setRLSConfigSQL, tenantID, bypass); err != nil {
+		_ = db.AddError(err)
+	}
+}
+
+// commit фиксирует (или откатывает при ошибке) транзакцию, открытую в begin.
+func (RLS) commit(db *gorm.DB) {
+	if _, ok := db.InstanceGet(rlsStartedKey); !ok {
+		return
+	}
+	if db.Error != nil {
+		db.Rollback()
+	} else {
+		db.Commit()
+	}
+	db.Statement.ConnPool = db.ConnPool
+}
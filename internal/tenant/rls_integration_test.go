@@ -0,0 +1,106 @@
+//go:build integration
+
+package tenant_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/psds-microservice/ticket-service/internal/model"
+	"github.com/psds-microservice/ticket-service/internal/tenant"
+	"github.com/psds-microservice/ticket-service/internal/testdb"
+	"gorm.io/gorm"
+)
+
+func seedTickets(t *testing.T, db *gorm.DB, tenantID string, n int) {
+	t.Helper()
+	ctx := tenant.WithID(context.Background(), tenantID)
+	for i := 0; i < n; i++ {
+		ticket := model.Ticket{
+			Reference: tenantID + "-" + string(rune('a'+i)),
+			SessionID: "s",
+			ClientID:  "c",
+			Status:    model.TicketStatusOpen,
+		}
+		if err := db.WithContext(ctx).Create(&ticket).Error; err != nil {
+			t.Fatalf("create ticket of %s: %v", tenantID, err)
+		}
+	}
+}
+
+func TestRLSQueryWithoutTenantSeesNothing(t *testing.T) {
+	db := testdb.Open(t)
+	seedTickets(t, db, "acme", 2)
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Мимо GORM и плагинов: без app.tenant_id политика не пропускает ни одной строки.
+	for _, table := range []string{"tickets", "ticket_sessions", "ticket_links", "ticket_audit_log", "ticket_reference_counters"} {
+		var n int
+		if err := sqlDB.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&n); err != nil {
+			t.Fatalf("%s: %v", table, err)
+		}
+		if n != 0 {
+			t.Errorf("%s: %d rows visible without a tenant, want 0", table, n)
+		}
+	}
+}
+
+func TestRLSIsolatesTenantsInRawSQL(t *testing.T) {
+	db := testdb.Open(t)
+	seedTickets(t, db, "acme", 2)
+	seedTickets(t, db, "globex", 1)
+
+	// Raw SQL плагин tenant не ограничивает — изоляцию даёт только RLS.
+	count := func(ctx context.Context) int64 {
+		t.Helper()
+		var n int64
+		if err := db.WithContext(ctx).Raw("SELECT COUNT(*) FROM tickets").Find(&n).Error; err != nil {
+			t.Fatalf("count: %v", err)
+		}
+		return n
+	}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want int64
+	}{
+		{"acme", tenant.WithID(context.Background(), "acme"), 2},
+		{"globex", tenant.WithID(context.Background(), "globex"), 1},
+		{"unknown tenant", tenant.WithID(context.Background(), "initech"), 0},
+		{"no tenant", context.Background(), 0},
+		{"system", tenant.WithSystem(context.Background()), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := count(tt.ctx); got != tt.want {
+				t.Errorf("COUNT(*) = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRLSScanOutsideTransaction(t *testing.T) {
+	db := testdb.Open(t)
+	seedTickets(t, db, "acme", 1)
+	ctx := tenant.WithID(context.Background(), "acme")
+
+	var n int64
+	err := db.WithContext(ctx).Raw("SELECT COUNT(*) FROM tickets").Scan(&n).Error
+	if !errors.Is(err, tenant.ErrRowOutsideTransaction) {
+		t.Fatalf("Scan outside a transaction: err = %v, want ErrRowOutsideTransaction", err)
+	}
+
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Raw("SELECT COUNT(*) FROM tickets").Scan(&n).Error
+	})
+	if err != nil {
+		t.Fatalf("Scan in a transaction: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Scan in a transaction: COUNT(*) = %d, want 1", n)
+	}
+}
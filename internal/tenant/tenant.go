@@ -0,0 +1,52 @@
+// Package tenant — изоляция данных брендов (тенантов) в одной инсталляции: tenant_id в контексте
+// запроса и GORM-плагин, который автоматически ограничивает им все запросы.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"regexp"
+)
+
+// DefaultID — тенант, которому принадлежат данные, созданные до мультитенантности.
+const DefaultID = "default"
+
+// ErrMissing — запрос к таблице с tenant_id без тенанта в контексте.
+var ErrMissing = errors.New("tenant: tenant id is not set in context")
+
+var idRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// Valid проверяет формат идентификатора тенанта.
+func Valid(id string) bool { return idRe.MatchString(id) }
+
+type ctxKey struct{}
+
+type scope struct {
+	id     string
+	system bool
+}
+
+// WithID ограничивает запросы в контексте тенантом id.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, scope{id: id})
+}
+
+// WithSystem — доступ ко всем тенантам. Только для служебных задач (CLI, retention, reindex).
+func WithSystem(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKey{}, scope{system: true})
+}
+
+// FromContext возвращает тенант запроса.
+func FromContext(ctx context.Context) (string, bool) {
+	s, ok := ctx.Value(ctxKey{}).(scope)
+	if !ok || s.system || s.id == "" {
+		return "", false
+	}
+	return s.id, true
+}
+
+// IsSystem сообщает, выполняется ли запрос без ограничения тенантом.
+func IsSystem(ctx context.Context) bool {
+	s, ok := ctx.Value(ctxKey{}).(scope)
+	return ok && s.system
+}
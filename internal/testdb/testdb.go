@@ -0,0 +1,135 @@
+//go:build integration
+
+// Package testdb — Postgres для интеграционных тестов (go test -tags integration ./...).
+// База задаётся TEST_DATABASE_URL (роль с правом CREATEROLE, обычно суперпользователь);
+// без переменной тесты пропускаются.
+package testdb
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/lib/pq"
+	"github.com/psds-microservice/ticket-service/internal/database"
+	"gorm.io/gorm"
+)
+
+// EnvURL — переменная окружения с адресом тестовой базы.
+const EnvURL = "TEST_DATABASE_URL"
+
+// appRole — роль сервиса в тестах: без SUPERUSER и BYPASSRLS, поэтому политики RLS
+// действуют на неё так же, как на роль сервиса в продакшене (см. migrate verify).
+const (
+	appRole     = "ticket_service_test"
+	appPassword = "ticket_service_test"
+)
+
+// Open применяет миграции, очищает таблицы и возвращает подключение сервиса
+// (database.Open, плагины tenant) под ролью appRole.
+func Open(t testing.TB) *gorm.DB {
+	t.Helper()
+	adminURL := os.Getenv(EnvURL)
+	if adminURL == "" {
+		t.Skipf("%s is not set", EnvURL)
+	}
+	if err := migrateUp(adminURL); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	admin, err := sql.Open("postgres", adminURL)
+	if err != nil {
+		t.Fatalf("open admin: %v", err)
+	}
+	defer admin.Close()
+	if err := prepare(admin); err != nil {
+		t.Fatalf("prepare: %v", err)
+	}
+
+	appURL, err := url.Parse(adminURL)
+	if err != nil {
+		t.Fatalf("parse %s: %v", EnvURL, err)
+	}
+	appURL.User = url.UserPassword(appRole, appPassword)
+	db, err := database.Open(appURL.String())
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	return db
+}
+
+func migrationsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "database", "migrations")
+}
+
+func migrateUp(databaseURL string) error {
+	m, err := migrate.New("file://"+filepath.ToSlash(migrationsDir()), databaseURL)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// prepare создаёт роль сервиса, выдаёт ей права на таблицы и очищает данные прошлых тестов.
+func prepare(admin *sql.DB) error {
+	var exists bool
+	if err := admin.QueryRow("SELECT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = $1)", appRole).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		if _, err := admin.Exec(fmt.Sprintf("CREATE ROLE %s LOGIN PASSWORD %s NOSUPERUSER NOBYPASSRLS",
+			pq.QuoteIdentifier(appRole), pq.QuoteLiteral(appPassword))); err != nil {
+			return fmt.Errorf("create role: %w", err)
+		}
+	}
+	role := pq.QuoteIdentifier(appRole)
+	for _, stmt := range []string{
+		"GRANT USAGE ON SCHEMA public TO " + role,
+		"GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO " + role,
+		"GRANT USAGE, SELECT, UPDATE ON ALL SEQUENCES IN SCHEMA public TO " + role,
+	} {
+		if _, err := admin.Exec(stmt); err != nil {
+			return fmt.Errorf("%s: %w", stmt, err)
+		}
+	}
+
+	rows, err := admin.Query("SELECT tablename FROM pg_tables WHERE schemaname = 'public' AND tablename <> 'schema_migrations'")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		tables = append(tables, pq.QuoteIdentifier(name))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+	_, err = admin.Exec("TRUNCATE " + strings.Join(tables, ", ") + " RESTART IDENTITY CASCADE")
+	return err
+}
@@ -0,0 +1,177 @@
+// Package tlsconfig — TLS/mTLS для gRPC и HTTP: сертификат сервера и CA клиентов
+// перечитываются с диска при изменении файлов (ротация без перезапуска).
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ParseClientAuth: none — сертификат клиента не запрашивается, optional — проверяется, если
+// предъявлен, require — обязателен (mTLS).
+func ParseClientAuth(s string) (tls.ClientAuthType, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "none":
+		return tls.NoClientCert, nil
+	case "optional":
+		return tls.VerifyClientCertIfGiven, nil
+	case "require":
+		return tls.RequireAndVerifyClientCert, nil
+	default:
+		return tls.NoClientCert, fmt.Errorf("tls: client auth must be none, optional or require, got %q", s)
+	}
+}
+
+// Reloader держит текущие сертификат и пул CA и подменяет их, когда файлы меняются.
+type Reloader struct {
+	certFile, keyFile, caFile string
+	clientAuth                tls.ClientAuthType
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	caPool  *x509.CertPool
+	modTime time.Time
+}
+
+// NewReloader загружает сертификат, ключ и (если задан) CA клиентов.
+func NewReloader(certFile, keyFile, caFile string, clientAuth tls.ClientAuthType) (*Reloader, error) {
+	if clientAuth != tls.NoClientCert && caFile == "" {
+		return nil, errors.New("tls: client CA file is required to verify client certificates")
+	}
+	r := &Reloader{certFile: certFile, keyFile: keyFile, caFile: caFile, clientAuth: clientAuth}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// latestModTime — самое позднее время изменения среди файлов.
+func (r *Reloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, f := range []string{r.certFile, r.keyFile, r.caFile} {
+		if f == "" {
+			continue
+		}
+		st, err := os.Stat(f)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if st.ModTime().After(latest) {
+			latest = st.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+func (r *Reloader) load() error {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return fmt.Errorf("tls: %w", err)
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("tls: load key pair: %w", err)
+	}
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		pem, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return fmt.Errorf("tls: read client CA: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("tls: no certificates in %s", r.caFile)
+		}
+	}
+	r.mu.Lock()
+	r.cert, r.caPool, r.modTime = &cert, pool, modTime
+	r.mu.Unlock()
+	return nil
+}
+
+// Run опрашивает файлы каждые interval и перечитывает их при изменении. Ошибка загрузки
+// (например, файлы записаны не полностью) оставляет прежний сертификат до следующей попытки.
+func (r *Reloader) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			modTime, err := r.latestModTime()
+			if err != nil {
+				log.Printf("tls: reload: %v", err)
+				continue
+			}
+			r.mu.RLock()
+			changed := !modTime.Equal(r.modTime)
+			r.mu.RUnlock()
+			if !changed {
+				continue
+			}
+			if err := r.load(); err != nil {
+				log.Printf("tls: reload: %v", err)
+				continue
+			}
+			log.Printf("tls: reloaded certificates from %s", r.certFile)
+		}
+	}
+}
+
+// ServerConfig — tls.Config, который на каждое рукопожатие берёт текущие сертификат и CA.
+// nextProtos — ALPN листенера: {"h2"} для gRPC, {"h2", "http/1.1"} для HTTP.
+func (r *Reloader) ServerConfig(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: nextProtos,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return r.cert, nil
+		},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   nextProtos,
+				Certificates: []tls.Certificate{*r.cert},
+				ClientCAs:    r.caPool,
+				ClientAuth:   r.clientAuth,
+			}, nil
+		},
+	}
+}
+
+// CertIdentity — идентичность клиента по сертификату: URI SAN (например, SPIFFE ID),
+// иначе первый DNS SAN, иначе CN.
+func CertIdentity(cert *x509.Certificate) string {
+	if cert == nil {
+		return ""
+	}
+	if len(cert.URIs) > 0 {
+		return cert.URIs[0].String()
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+	return cert.Subject.CommonName
+}
+
+// VerifiedIdentity — идентичность из проверенной цепочки соединения (пусто, если клиент
+// не предъявил сертификат или он не проверялся).
+func VerifiedIdentity(state tls.ConnectionState) string {
+	if len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return CertIdentity(state.VerifiedChains[0][0])
+}
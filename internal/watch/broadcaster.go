@@ -0,0 +1,214 @@
+// Package watch — in-process рассылка изменений тикетов подписчикам WatchTickets.
+// Последние события хранятся в кольцевом буфере: переподключившийся клиент получает
+// пропущенное по resume token, не запрашивая снимок заново.
+package watch
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/psds-microservice/ticket-service/internal/model"
+)
+
+// Типы событий WatchTickets.
+const (
+	TypeCreated = "created"
+	TypeUpdated = "updated"
+	TypeDeleted = "deleted"
+)
+
+// DefaultBufferSize — сколько последних событий доступно для возобновления по resume token.
+const DefaultBufferSize = 4096
+
+// subscriberBuffer — очередь событий подписчика. Подписчик, не успевающий её разбирать,
+// отключается (ErrSlowSubscriber), чтобы не задерживать запись тикетов.
+const subscriberBuffer = 256
+
+var (
+	// ErrSlowSubscriber — подписчик отстал и отключён; переподключиться с последним resume token.
+	ErrSlowSubscriber = errors.New("watch: subscriber fell behind")
+	// ErrClosed — broadcaster остановлен.
+	ErrClosed = errors.New("watch: broadcaster closed")
+)
+
+// TypeOf сопоставляет событие Kafka (ticket.created и т.п.) с типом события WatchTickets.
+func TypeOf(event string) string {
+	switch event {
+	case "ticket.created":
+		return TypeCreated
+	case "ticket.deleted":
+		return TypeDeleted
+	default:
+		return TypeUpdated
+	}
+}
+
+// Event — изменение тикета. Ticket — копия состояния на момент события.
+type Event struct {
+	Seq    uint64
+	Type   string
+	Ticket model.Ticket
+}
+
+// Broadcaster рассылает события всем подписчикам. Методы безопасны для nil (no-op).
+type Broadcaster struct {
+	// epoch отличает токены этого процесса: после перезапуска старые токены недействительны.
+	epoch string
+
+	mu     sync.Mutex
+	seq    uint64
+	ring   []Event
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+func NewBroadcaster(bufferSize int) *Broadcaster {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return &Broadcaster{
+		epoch: hex.EncodeToString(b),
+		ring:  make([]Event, 0, bufferSize),
+		subs:  make(map[*Subscription]struct{}),
+	}
+}
+
+// Token — resume token для позиции seq (событие seq уже получено клиентом).
+func (b *Broadcaster) Token(seq uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(b.epoch + ":" + strconv.FormatUint(seq, 10)))
+}
+
+// parseToken возвращает seq из токена этого процесса.
+func (b *Broadcaster) parseToken(token string) (uint64, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, false
+	}
+	epoch, seq, ok := strings.Cut(string(raw), ":")
+	if !ok || epoch != b.epoch {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	return n, err == nil
+}
+
+// Publish рассылает изменение тикета. Не блокируется: отставшие подписчики отключаются.
+func (b *Broadcaster) Publish(typ string, t *model.Ticket) {
+	if b == nil || t == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.seq++
+	ev := Event{Seq: b.seq, Type: typ, Ticket: *t}
+	if len(b.ring) < cap(b.ring) {
+		b.ring = append(b.ring, ev)
+	} else {
+		b.ring[int((ev.Seq-1)%uint64(cap(b.ring)))] = ev
+	}
+	for sub := range b.subs {
+		select {
+		case sub.ch <- ev:
+		default:
+			b.dropLocked(sub, ErrSlowSubscriber)
+		}
+	}
+}
+
+// Subscribe подписывает на события. resumeToken пустой — с текущего момента; иначе с события
+// после токена. resumed=false — токен пустой, чужой или устарел: клиенту нужен снимок,
+// а события начинаются с текущего момента (Seq() подписки — позиция для токена снимка).
+func (b *Broadcaster) Subscribe(resumeToken string) (sub *Subscription, resumed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	sub = &Subscription{b: b, seq: b.seq}
+	var backlog []Event
+	if after, ok := b.parseToken(resumeToken); ok && after <= b.seq && b.seq-after <= uint64(len(b.ring)) {
+		backlog, resumed = b.since(after), true
+		sub.seq = after
+	}
+	// Пропущенные события не должны вытесняться новыми, пока подписчик их не прочитал.
+	sub.ch = make(chan Event, subscriberBuffer+len(backlog))
+	for _, ev := range backlog {
+		sub.ch <- ev
+	}
+	if b.closed {
+		sub.err = ErrClosed
+		close(sub.ch)
+		return sub, resumed
+	}
+	b.subs[sub] = struct{}{}
+	return sub, resumed
+}
+
+// since — события ring после seq в порядке возрастания.
+func (b *Broadcaster) since(seq uint64) []Event {
+	out := make([]Event, 0, b.seq-seq)
+	for s := seq + 1; s <= b.seq; s++ {
+		out = append(out, b.ring[int((s-1)%uint64(cap(b.ring)))])
+	}
+	return out
+}
+
+func (b *Broadcaster) dropLocked(sub *Subscription, err error) {
+	if _, ok := b.subs[sub]; !ok {
+		return
+	}
+	delete(b.subs, sub)
+	sub.err = err
+	close(sub.ch)
+}
+
+// Close отключает всех подписчиков (при остановке сервера).
+func (b *Broadcaster) Close() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for sub := range b.subs {
+		b.dropLocked(sub, ErrClosed)
+	}
+}
+
+// Subscription — подписка одного потока WatchTickets.
+type Subscription struct {
+	b   *Broadcaster
+	ch  chan Event
+	seq uint64
+	err error
+}
+
+// Events — канал событий; закрывается при отключении (причина — Err).
+func (s *Subscription) Events() <-chan Event { return s.ch }
+
+// Seq — позиция, с которой начались события подписки.
+func (s *Subscription) Seq() uint64 { return s.seq }
+
+// Err — причина закрытия Events. Читать после того, как канал закрыт.
+func (s *Subscription) Err() error {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+	return s.err
+}
+
+// Close отменяет подписку.
+func (s *Subscription) Close() {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+	if _, ok := s.b.subs[s]; ok {
+		delete(s.b.subs, s)
+		close(s.ch)
+	}
+}
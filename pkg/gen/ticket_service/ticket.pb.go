@@ -10,6 +10,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -24,17 +25,21 @@ const (
 )
 
 type CreateTicketRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	OperatorId    string                 `protobuf:"bytes,3,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	Priority      string                 `protobuf:"bytes,5,opt,name=priority,proto3" json:"priority,omitempty"`
-	Region        string                 `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
-	Subject       string                 `protobuf:"bytes,7,opt,name=subject,proto3" json:"subject,omitempty"`
-	Notes         string                 `protobuf:"bytes,8,opt,name=notes,proto3" json:"notes,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	SessionId  string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ClientId   string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	OperatorId string                 `protobuf:"bytes,3,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
+	Status     string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Priority   string                 `protobuf:"bytes,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	Region     string                 `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
+	Subject    string                 `protobuf:"bytes,7,opt,name=subject,proto3" json:"subject,omitempty"`
+	Notes      string                 `protobuf:"bytes,8,opt,name=notes,proto3" json:"notes,omitempty"`
+	// check_duplicates — найти похожие открытые тикеты клиента за 30 дней; их ID возвращаются
+	// в метаданных ответа x-similar-ticket-ids (REST: заголовок Grpc-Metadata-X-Similar-Ticket-Ids)
+	// через запятую, самые похожие первыми. Тикет создаётся в любом случае.
+	CheckDuplicates bool `protobuf:"varint,9,opt,name=check_duplicates,json=checkDuplicates,proto3" json:"check_duplicates,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *CreateTicketRequest) Reset() {
@@ -123,11 +128,22 @@ func (x *CreateTicketRequest) GetNotes() string {
 	return ""
 }
 
+func (x *CreateTicketRequest) GetCheckDuplicates() bool {
+	if x != nil {
+		return x.CheckDuplicates
+	}
+	return false
+}
+
 type GetTicketRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// include_deleted — вернуть и удалённый или архивный тикет (только admin).
+	IncludeDeleted bool `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// include_archived — вернуть и архивный тикет; по умолчанию архивный тикет — NOT_FOUND.
+	IncludeArchived bool `protobuf:"varint,3,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *GetTicketRequest) Reset() {
@@ -167,21 +183,129 @@ func (x *GetTicketRequest) GetId() int64 {
 	return 0
 }
 
+func (x *GetTicketRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *GetTicketRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+type GetTicketByReferenceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ref — номер тикета вида EU-2026-000123 (регистр не важен).
+	Ref string `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	// include_archived — вернуть и архивный тикет; по умолчанию архивный тикет — NOT_FOUND.
+	IncludeArchived bool `protobuf:"varint,2,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetTicketByReferenceRequest) Reset() {
+	*x = GetTicketByReferenceRequest{}
+	mi := &file_ticket_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTicketByReferenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTicketByReferenceRequest) ProtoMessage() {}
+
+func (x *GetTicketByReferenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTicketByReferenceRequest.ProtoReflect.Descriptor instead.
+func (*GetTicketByReferenceRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetTicketByReferenceRequest) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *GetTicketByReferenceRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
 type ListTicketsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
-	ClientId      string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	OperatorId    string                 `protobuf:"bytes,4,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
-	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
-	Region        string                 `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// limit — размер страницы: по умолчанию и не больше максимума сервера (LIST_DEFAULT_LIMIT, LIST_MAX_LIMIT).
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// offset — устаревшая пагинация; вместо неё page_token. Вместе с page_token не задаётся.
+	Offset     int32  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	ClientId   string `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	OperatorId string `protobuf:"bytes,4,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
+	Status     string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Region     string `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
+	// include_deleted — включить удалённые тикеты (только admin).
+	IncludeDeleted  bool  `protobuf:"varint,7,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	IncludeArchived bool  `protobuf:"varint,8,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	LegalHold       *bool `protobuf:"varint,9,opt,name=legal_hold,json=legalHold,proto3,oneof" json:"legal_hold,omitempty"`
+	// page_token — next_page_token предыдущего ответа (фильтры должны совпадать).
+	PageToken string `protobuf:"bytes,10,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// total_mode: exact (по умолчанию) — точный total; estimate — подсчёт до 10000 строк
+	// (больше — total = 10000 и total_estimated); none — total не считается (-1).
+	TotalMode string `protobuf:"bytes,11,opt,name=total_mode,json=totalMode,proto3" json:"total_mode,omitempty"`
+	// statuses, priorities — тикет подходит, если значение совпадает с любым из перечисленных
+	// (status и statuses объединяются).
+	Statuses   []string `protobuf:"bytes,12,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	Priorities []string `protobuf:"bytes,13,rep,name=priorities,proto3" json:"priorities,omitempty"`
+	// session_id — тикеты, к которым привязана сессия (исходная или присоединённая).
+	SessionId string `protobuf:"bytes,14,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// unassigned — только тикеты без оператора; вместе с operator_id не задаётся.
+	Unassigned bool `protobuf:"varint,15,opt,name=unassigned,proto3" json:"unassigned,omitempty"`
+	// Диапазоны дат — полуинтервалы [from, to); любая граница может быть опущена.
+	CreatedFrom *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	UpdatedFrom *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=updated_from,json=updatedFrom,proto3" json:"updated_from,omitempty"`
+	UpdatedTo   *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=updated_to,json=updatedTo,proto3" json:"updated_to,omitempty"`
+	ClosedFrom  *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=closed_from,json=closedFrom,proto3" json:"closed_from,omitempty"`
+	ClosedTo    *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=closed_to,json=closedTo,proto3" json:"closed_to,omitempty"`
+	// subject_contains — подстрока темы без учёта регистра. Недоступна при шифровании
+	// полей (FAILED_PRECONDITION).
+	SubjectContains string `protobuf:"bytes,22,opt,name=subject_contains,json=subjectContains,proto3" json:"subject_contains,omitempty"`
+	// order_by — "<поле> [asc|desc]", поле: created_at (по умолчанию), updated_at, priority
+	// (urgent > high > normal > low). По умолчанию desc. page_token действует только с тем же order_by.
+	// sla_due зарезервировано: у тикетов пока нет срока SLA, такой order_by — UNIMPLEMENTED.
+	OrderBy string `protobuf:"bytes,23,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// filter — выражение CEL над полями тикета: id, reference, client_id, operator_id, status,
+	// priority, region, session_id, subject, legal_hold, created_at, updated_at, closed_at (может быть null)
+	// и now. Поддерживаются &&, ||, !, сравнения, in [список], contains/startsWith/endsWith,
+	// timestamp("RFC 3339") и now - duration("48h"). Например:
+	// status in ["open", "in_progress"] && region == "eu" && created_at < now - duration("48h").
+	// Ошибка разбора или неподдерживаемая конструкция — INVALID_ARGUMENT с позицией (filter:строка:колонка).
+	Filter        string `protobuf:"bytes,24,opt,name=filter,proto3" json:"filter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListTicketsRequest) Reset() {
 	*x = ListTicketsRequest{}
-	mi := &file_ticket_proto_msgTypes[2]
+	mi := &file_ticket_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -193,7 +317,7 @@ func (x *ListTicketsRequest) String() string {
 func (*ListTicketsRequest) ProtoMessage() {}
 
 func (x *ListTicketsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ticket_proto_msgTypes[2]
+	mi := &file_ticket_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -206,7 +330,7 @@ func (x *ListTicketsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListTicketsRequest.ProtoReflect.Descriptor instead.
 func (*ListTicketsRequest) Descriptor() ([]byte, []int) {
-	return file_ticket_proto_rawDescGZIP(), []int{2}
+	return file_ticket_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ListTicketsRequest) GetLimit() int32 {
@@ -251,122 +375,179 @@ func (x *ListTicketsRequest) GetRegion() string {
 	return ""
 }
 
-type UpdateTicketRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
-	Notes         string                 `protobuf:"bytes,3,opt,name=notes,proto3" json:"notes,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	Priority      string                 `protobuf:"bytes,5,opt,name=priority,proto3" json:"priority,omitempty"`
-	Region        string                 `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ListTicketsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
 }
 
-func (x *UpdateTicketRequest) Reset() {
-	*x = UpdateTicketRequest{}
-	mi := &file_ticket_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ListTicketsRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
 }
 
-func (x *UpdateTicketRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ListTicketsRequest) GetLegalHold() bool {
+	if x != nil && x.LegalHold != nil {
+		return *x.LegalHold
+	}
+	return false
 }
 
-func (*UpdateTicketRequest) ProtoMessage() {}
+func (x *ListTicketsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
 
-func (x *UpdateTicketRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ticket_proto_msgTypes[3]
+func (x *ListTicketsRequest) GetTotalMode() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.TotalMode
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use UpdateTicketRequest.ProtoReflect.Descriptor instead.
-func (*UpdateTicketRequest) Descriptor() ([]byte, []int) {
-	return file_ticket_proto_rawDescGZIP(), []int{3}
+func (x *ListTicketsRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
 }
 
-func (x *UpdateTicketRequest) GetId() int64 {
+func (x *ListTicketsRequest) GetPriorities() []string {
 	if x != nil {
-		return x.Id
+		return x.Priorities
 	}
-	return 0
+	return nil
 }
 
-func (x *UpdateTicketRequest) GetSubject() string {
+func (x *ListTicketsRequest) GetSessionId() string {
 	if x != nil {
-		return x.Subject
+		return x.SessionId
 	}
 	return ""
 }
 
-func (x *UpdateTicketRequest) GetNotes() string {
+func (x *ListTicketsRequest) GetUnassigned() bool {
 	if x != nil {
-		return x.Notes
+		return x.Unassigned
 	}
-	return ""
+	return false
 }
 
-func (x *UpdateTicketRequest) GetStatus() string {
+func (x *ListTicketsRequest) GetCreatedFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Status
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListTicketsRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListTicketsRequest) GetUpdatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedFrom
+	}
+	return nil
+}
+
+func (x *ListTicketsRequest) GetUpdatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedTo
+	}
+	return nil
+}
+
+func (x *ListTicketsRequest) GetClosedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedFrom
+	}
+	return nil
+}
+
+func (x *ListTicketsRequest) GetClosedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedTo
+	}
+	return nil
+}
+
+func (x *ListTicketsRequest) GetSubjectContains() string {
+	if x != nil {
+		return x.SubjectContains
 	}
 	return ""
 }
 
-func (x *UpdateTicketRequest) GetPriority() string {
+func (x *ListTicketsRequest) GetOrderBy() string {
 	if x != nil {
-		return x.Priority
+		return x.OrderBy
 	}
 	return ""
 }
 
-func (x *UpdateTicketRequest) GetRegion() string {
+func (x *ListTicketsRequest) GetFilter() string {
 	if x != nil {
-		return x.Region
+		return x.Filter
 	}
 	return ""
 }
 
-type Ticket struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	ClientId      string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	OperatorId    string                 `protobuf:"bytes,4,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
-	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
-	Priority      string                 `protobuf:"bytes,6,opt,name=priority,proto3" json:"priority,omitempty"`
-	Region        string                 `protobuf:"bytes,7,opt,name=region,proto3" json:"region,omitempty"`
-	Subject       string                 `protobuf:"bytes,8,opt,name=subject,proto3" json:"subject,omitempty"`
-	Notes         string                 `protobuf:"bytes,9,opt,name=notes,proto3" json:"notes,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	ClosedAt      *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+type WatchTicketsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Фильтры — как в ListTicketsRequest.
+	ClientId   string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	OperatorId string `protobuf:"bytes,2,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
+	Status     string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Region     string `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	// include_deleted — включить удалённые тикеты (только admin).
+	IncludeDeleted  bool  `protobuf:"varint,5,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	IncludeArchived bool  `protobuf:"varint,6,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	LegalHold       *bool `protobuf:"varint,7,opt,name=legal_hold,json=legalHold,proto3,oneof" json:"legal_hold,omitempty"`
+	// resume_token последнего полученного события: поток продолжается с него без снимка.
+	// Если токен устарел (сервер перезапущен или события вытеснены), поток начинается заново со снимка.
+	ResumeToken string `protobuf:"bytes,8,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	// snapshot_limit — сколько тикетов в снимке (по умолчанию и не больше 1000), новые первыми.
+	SnapshotLimit   int32                  `protobuf:"varint,9,opt,name=snapshot_limit,json=snapshotLimit,proto3" json:"snapshot_limit,omitempty"`
+	Statuses        []string               `protobuf:"bytes,10,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	Priorities      []string               `protobuf:"bytes,11,rep,name=priorities,proto3" json:"priorities,omitempty"`
+	SessionId       string                 `protobuf:"bytes,12,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Unassigned      bool                   `protobuf:"varint,13,opt,name=unassigned,proto3" json:"unassigned,omitempty"`
+	CreatedFrom     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo       *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	UpdatedFrom     *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=updated_from,json=updatedFrom,proto3" json:"updated_from,omitempty"`
+	UpdatedTo       *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_to,json=updatedTo,proto3" json:"updated_to,omitempty"`
+	ClosedFrom      *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=closed_from,json=closedFrom,proto3" json:"closed_from,omitempty"`
+	ClosedTo        *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=closed_to,json=closedTo,proto3" json:"closed_to,omitempty"`
+	SubjectContains string                 `protobuf:"bytes,20,opt,name=subject_contains,json=subjectContains,proto3" json:"subject_contains,omitempty"`
+	// filter — выражение CEL, как в ListTicketsRequest.
+	Filter        string `protobuf:"bytes,21,opt,name=filter,proto3" json:"filter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Ticket) Reset() {
-	*x = Ticket{}
+func (x *WatchTicketsRequest) Reset() {
+	*x = WatchTicketsRequest{}
 	mi := &file_ticket_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Ticket) String() string {
+func (x *WatchTicketsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Ticket) ProtoMessage() {}
+func (*WatchTicketsRequest) ProtoMessage() {}
 
-func (x *Ticket) ProtoReflect() protoreflect.Message {
+func (x *WatchTicketsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ticket_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -378,117 +559,204 @@ func (x *Ticket) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Ticket.ProtoReflect.Descriptor instead.
-func (*Ticket) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchTicketsRequest.ProtoReflect.Descriptor instead.
+func (*WatchTicketsRequest) Descriptor() ([]byte, []int) {
 	return file_ticket_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Ticket) GetId() int64 {
+func (x *WatchTicketsRequest) GetClientId() string {
 	if x != nil {
-		return x.Id
+		return x.ClientId
 	}
-	return 0
+	return ""
 }
 
-func (x *Ticket) GetSessionId() string {
+func (x *WatchTicketsRequest) GetOperatorId() string {
 	if x != nil {
-		return x.SessionId
+		return x.OperatorId
 	}
 	return ""
 }
 
-func (x *Ticket) GetClientId() string {
+func (x *WatchTicketsRequest) GetStatus() string {
 	if x != nil {
-		return x.ClientId
+		return x.Status
 	}
 	return ""
 }
 
-func (x *Ticket) GetOperatorId() string {
+func (x *WatchTicketsRequest) GetRegion() string {
 	if x != nil {
-		return x.OperatorId
+		return x.Region
 	}
 	return ""
 }
 
-func (x *Ticket) GetStatus() string {
+func (x *WatchTicketsRequest) GetIncludeDeleted() bool {
 	if x != nil {
-		return x.Status
+		return x.IncludeDeleted
 	}
-	return ""
+	return false
 }
 
-func (x *Ticket) GetPriority() string {
+func (x *WatchTicketsRequest) GetIncludeArchived() bool {
 	if x != nil {
-		return x.Priority
+		return x.IncludeArchived
 	}
-	return ""
+	return false
 }
 
-func (x *Ticket) GetRegion() string {
+func (x *WatchTicketsRequest) GetLegalHold() bool {
+	if x != nil && x.LegalHold != nil {
+		return *x.LegalHold
+	}
+	return false
+}
+
+func (x *WatchTicketsRequest) GetResumeToken() string {
 	if x != nil {
-		return x.Region
+		return x.ResumeToken
 	}
 	return ""
 }
 
-func (x *Ticket) GetSubject() string {
+func (x *WatchTicketsRequest) GetSnapshotLimit() int32 {
 	if x != nil {
-		return x.Subject
+		return x.SnapshotLimit
 	}
-	return ""
+	return 0
 }
 
-func (x *Ticket) GetNotes() string {
+func (x *WatchTicketsRequest) GetStatuses() []string {
 	if x != nil {
-		return x.Notes
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *WatchTicketsRequest) GetPriorities() []string {
+	if x != nil {
+		return x.Priorities
+	}
+	return nil
+}
+
+func (x *WatchTicketsRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
 	}
 	return ""
 }
 
-func (x *Ticket) GetCreatedAt() *timestamppb.Timestamp {
+func (x *WatchTicketsRequest) GetUnassigned() bool {
 	if x != nil {
-		return x.CreatedAt
+		return x.Unassigned
+	}
+	return false
+}
+
+func (x *WatchTicketsRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
 	}
 	return nil
 }
 
-func (x *Ticket) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *WatchTicketsRequest) GetCreatedTo() *timestamppb.Timestamp {
 	if x != nil {
-		return x.UpdatedAt
+		return x.CreatedTo
 	}
 	return nil
 }
 
-func (x *Ticket) GetClosedAt() *timestamppb.Timestamp {
+func (x *WatchTicketsRequest) GetUpdatedFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.ClosedAt
+		return x.UpdatedFrom
 	}
 	return nil
 }
 
-type ListTicketsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tickets       []*Ticket              `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *WatchTicketsRequest) GetUpdatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedTo
+	}
+	return nil
 }
 
-func (x *ListTicketsResponse) Reset() {
-	*x = ListTicketsResponse{}
+func (x *WatchTicketsRequest) GetClosedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedFrom
+	}
+	return nil
+}
+
+func (x *WatchTicketsRequest) GetClosedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedTo
+	}
+	return nil
+}
+
+func (x *WatchTicketsRequest) GetSubjectContains() string {
+	if x != nil {
+		return x.SubjectContains
+	}
+	return ""
+}
+
+func (x *WatchTicketsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+type SearchTicketsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// query — слова для поиска в синтаксисе websearch: "точная фраза", or, -исключить.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// limit — по умолчанию 20, не больше 100.
+	Limit  int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Фильтры — как в ListTicketsRequest. region также выбирает языковую конфигурацию поиска
+	// (морфология); без region слова ищутся без учёта языка.
+	ClientId        string                 `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	OperatorId      string                 `protobuf:"bytes,5,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
+	Status          string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Region          string                 `protobuf:"bytes,7,opt,name=region,proto3" json:"region,omitempty"`
+	IncludeDeleted  bool                   `protobuf:"varint,8,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	IncludeArchived bool                   `protobuf:"varint,9,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	LegalHold       *bool                  `protobuf:"varint,10,opt,name=legal_hold,json=legalHold,proto3,oneof" json:"legal_hold,omitempty"`
+	Statuses        []string               `protobuf:"bytes,11,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	Priorities      []string               `protobuf:"bytes,12,rep,name=priorities,proto3" json:"priorities,omitempty"`
+	SessionId       string                 `protobuf:"bytes,13,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Unassigned      bool                   `protobuf:"varint,14,opt,name=unassigned,proto3" json:"unassigned,omitempty"`
+	CreatedFrom     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo       *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	UpdatedFrom     *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_from,json=updatedFrom,proto3" json:"updated_from,omitempty"`
+	UpdatedTo       *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=updated_to,json=updatedTo,proto3" json:"updated_to,omitempty"`
+	ClosedFrom      *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=closed_from,json=closedFrom,proto3" json:"closed_from,omitempty"`
+	ClosedTo        *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=closed_to,json=closedTo,proto3" json:"closed_to,omitempty"`
+	SubjectContains string                 `protobuf:"bytes,21,opt,name=subject_contains,json=subjectContains,proto3" json:"subject_contains,omitempty"`
+	Filter          string                 `protobuf:"bytes,22,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SearchTicketsRequest) Reset() {
+	*x = SearchTicketsRequest{}
 	mi := &file_ticket_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTicketsResponse) String() string {
+func (x *SearchTicketsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTicketsResponse) ProtoMessage() {}
+func (*SearchTicketsRequest) ProtoMessage() {}
 
-func (x *ListTicketsResponse) ProtoReflect() protoreflect.Message {
+func (x *SearchTicketsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ticket_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -500,30 +768,2347 @@ func (x *ListTicketsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTicketsResponse.ProtoReflect.Descriptor instead.
-func (*ListTicketsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchTicketsRequest.ProtoReflect.Descriptor instead.
+func (*SearchTicketsRequest) Descriptor() ([]byte, []int) {
 	return file_ticket_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ListTicketsResponse) GetTickets() []*Ticket {
+func (x *SearchTicketsRequest) GetQuery() string {
 	if x != nil {
-		return x.Tickets
+		return x.Query
 	}
-	return nil
+	return ""
 }
 
-func (x *ListTicketsResponse) GetTotal() int32 {
+func (x *SearchTicketsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Total
+		return x.Limit
 	}
 	return 0
 }
 
-var File_ticket_proto protoreflect.FileDescriptor
+func (x *SearchTicketsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
 
-const file_ticket_proto_rawDesc = "" +
-	"\n" +
-	"\fticket.proto\x12\x0eticket_service\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\"\xee\x01\n" +
+func (x *SearchTicketsRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *SearchTicketsRequest) GetOperatorId() string {
+	if x != nil {
+		return x.OperatorId
+	}
+	return ""
+}
+
+func (x *SearchTicketsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SearchTicketsRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *SearchTicketsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *SearchTicketsRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+func (x *SearchTicketsRequest) GetLegalHold() bool {
+	if x != nil && x.LegalHold != nil {
+		return *x.LegalHold
+	}
+	return false
+}
+
+func (x *SearchTicketsRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *SearchTicketsRequest) GetPriorities() []string {
+	if x != nil {
+		return x.Priorities
+	}
+	return nil
+}
+
+func (x *SearchTicketsRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SearchTicketsRequest) GetUnassigned() bool {
+	if x != nil {
+		return x.Unassigned
+	}
+	return false
+}
+
+func (x *SearchTicketsRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *SearchTicketsRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *SearchTicketsRequest) GetUpdatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedFrom
+	}
+	return nil
+}
+
+func (x *SearchTicketsRequest) GetUpdatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedTo
+	}
+	return nil
+}
+
+func (x *SearchTicketsRequest) GetClosedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedFrom
+	}
+	return nil
+}
+
+func (x *SearchTicketsRequest) GetClosedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedTo
+	}
+	return nil
+}
+
+func (x *SearchTicketsRequest) GetSubjectContains() string {
+	if x != nil {
+		return x.SubjectContains
+	}
+	return ""
+}
+
+func (x *SearchTicketsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+// SearchHit — найденный тикет. Фрагменты — текст тикета с найденными словами в <mark>…</mark>;
+// остальной текст не экранируется.
+type SearchHit struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Ticket         *Ticket                `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Rank           float32                `protobuf:"fixed32,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	SubjectSnippet string                 `protobuf:"bytes,3,opt,name=subject_snippet,json=subjectSnippet,proto3" json:"subject_snippet,omitempty"`
+	NotesSnippet   string                 `protobuf:"bytes,4,opt,name=notes_snippet,json=notesSnippet,proto3" json:"notes_snippet,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SearchHit) Reset() {
+	*x = SearchHit{}
+	mi := &file_ticket_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHit) ProtoMessage() {}
+
+func (x *SearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHit.ProtoReflect.Descriptor instead.
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SearchHit) GetTicket() *Ticket {
+	if x != nil {
+		return x.Ticket
+	}
+	return nil
+}
+
+func (x *SearchHit) GetRank() float32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *SearchHit) GetSubjectSnippet() string {
+	if x != nil {
+		return x.SubjectSnippet
+	}
+	return ""
+}
+
+func (x *SearchHit) GetNotesSnippet() string {
+	if x != nil {
+		return x.NotesSnippet
+	}
+	return ""
+}
+
+type SearchTicketsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// hits — по убыванию релевантности.
+	Hits          []*SearchHit `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTicketsResponse) Reset() {
+	*x = SearchTicketsResponse{}
+	mi := &file_ticket_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTicketsResponse) ProtoMessage() {}
+
+func (x *SearchTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTicketsResponse.ProtoReflect.Descriptor instead.
+func (*SearchTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SearchTicketsResponse) GetHits() []*SearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+type FindSimilarTicketsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// subject и notes — текст нового обращения (хотя бы одно из полей).
+	Subject string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Notes   string `protobuf:"bytes,2,opt,name=notes,proto3" json:"notes,omitempty"`
+	// client_id — только тикеты клиента (пусто — все тикеты).
+	ClientId string `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// window_days — тикеты, созданные за последние N дней (по умолчанию 30, не больше 365).
+	WindowDays int32 `protobuf:"varint,4,opt,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
+	// min_similarity — порог похожести от 0.3 до 1 (по умолчанию 0.45).
+	MinSimilarity float32 `protobuf:"fixed32,5,opt,name=min_similarity,json=minSimilarity,proto3" json:"min_similarity,omitempty"`
+	// limit — по умолчанию 10, не больше 50.
+	Limit int32 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	// include_closed — учитывать закрытые и объединённые тикеты.
+	IncludeClosed bool `protobuf:"varint,7,opt,name=include_closed,json=includeClosed,proto3" json:"include_closed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindSimilarTicketsRequest) Reset() {
+	*x = FindSimilarTicketsRequest{}
+	mi := &file_ticket_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSimilarTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSimilarTicketsRequest) ProtoMessage() {}
+
+func (x *FindSimilarTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSimilarTicketsRequest.ProtoReflect.Descriptor instead.
+func (*FindSimilarTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FindSimilarTicketsRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *FindSimilarTicketsRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *FindSimilarTicketsRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *FindSimilarTicketsRequest) GetWindowDays() int32 {
+	if x != nil {
+		return x.WindowDays
+	}
+	return 0
+}
+
+func (x *FindSimilarTicketsRequest) GetMinSimilarity() float32 {
+	if x != nil {
+		return x.MinSimilarity
+	}
+	return 0
+}
+
+func (x *FindSimilarTicketsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *FindSimilarTicketsRequest) GetIncludeClosed() bool {
+	if x != nil {
+		return x.IncludeClosed
+	}
+	return false
+}
+
+type SimilarTicket struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Ticket *Ticket                `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	// similarity — степень похожести текста, 0..1.
+	Similarity    float32 `protobuf:"fixed32,2,opt,name=similarity,proto3" json:"similarity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimilarTicket) Reset() {
+	*x = SimilarTicket{}
+	mi := &file_ticket_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimilarTicket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarTicket) ProtoMessage() {}
+
+func (x *SimilarTicket) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarTicket.ProtoReflect.Descriptor instead.
+func (*SimilarTicket) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SimilarTicket) GetTicket() *Ticket {
+	if x != nil {
+		return x.Ticket
+	}
+	return nil
+}
+
+func (x *SimilarTicket) GetSimilarity() float32 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+type FindSimilarTicketsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tickets       []*SimilarTicket       `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindSimilarTicketsResponse) Reset() {
+	*x = FindSimilarTicketsResponse{}
+	mi := &file_ticket_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSimilarTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSimilarTicketsResponse) ProtoMessage() {}
+
+func (x *FindSimilarTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSimilarTicketsResponse.ProtoReflect.Descriptor instead.
+func (*FindSimilarTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FindSimilarTicketsResponse) GetTickets() []*SimilarTicket {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+// TicketEvent — сообщение WatchTickets.
+// type: snapshot — тикет из начального снимка; snapshot_end — снимок передан полностью
+// (ticket пустой; получив snapshot, клиент заменяет своё состояние новым снимком);
+// created, updated, deleted — изменения после снимка.
+// Тикет, который уже был в этом потоке и перестал подходить под фильтры (например, сменил статус),
+// приходит как updated: клиент сам убирает его из выборки.
+type TicketEvent struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Type   string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Ticket *Ticket                `protobuf:"bytes,2,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	// resume_token — передать в WatchTicketsRequest при переподключении. У сообщений snapshot
+	// токена нет: до snapshot_end переподключаться нужно без токена (со снимком).
+	ResumeToken   string `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketEvent) Reset() {
+	*x = TicketEvent{}
+	mi := &file_ticket_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketEvent) ProtoMessage() {}
+
+func (x *TicketEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketEvent.ProtoReflect.Descriptor instead.
+func (*TicketEvent) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TicketEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetTicket() *Ticket {
+	if x != nil {
+		return x.Ticket
+	}
+	return nil
+}
+
+func (x *TicketEvent) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type UpdateTicketRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Subject  string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Notes    string                 `protobuf:"bytes,3,opt,name=notes,proto3" json:"notes,omitempty"`
+	Status   string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Priority string                 `protobuf:"bytes,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	Region   string                 `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
+	// cascade_close_children: при переводе в closed закрыть и все дочерние тикеты (parent-связи).
+	CascadeCloseChildren bool `protobuf:"varint,7,opt,name=cascade_close_children,json=cascadeCloseChildren,proto3" json:"cascade_close_children,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *UpdateTicketRequest) Reset() {
+	*x = UpdateTicketRequest{}
+	mi := &file_ticket_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTicketRequest) ProtoMessage() {}
+
+func (x *UpdateTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTicketRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTicketRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateTicketRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateTicketRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *UpdateTicketRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *UpdateTicketRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateTicketRequest) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *UpdateTicketRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *UpdateTicketRequest) GetCascadeCloseChildren() bool {
+	if x != nil {
+		return x.CascadeCloseChildren
+	}
+	return false
+}
+
+type BatchGetTicketsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ids — не больше 100; повторы игнорируются.
+	Ids []int64 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	// include_deleted — вернуть и удалённые и архивные тикеты (только admin); без него они в missing_ids.
+	IncludeDeleted bool `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BatchGetTicketsRequest) Reset() {
+	*x = BatchGetTicketsRequest{}
+	mi := &file_ticket_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetTicketsRequest) ProtoMessage() {}
+
+func (x *BatchGetTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetTicketsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BatchGetTicketsRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BatchGetTicketsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type BatchGetTicketsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// tickets — в порядке ids.
+	Tickets []*Ticket `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	// missing_ids — не найдены (или принадлежат другому тенанту).
+	MissingIds    []int64 `protobuf:"varint,2,rep,packed,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetTicketsResponse) Reset() {
+	*x = BatchGetTicketsResponse{}
+	mi := &file_ticket_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetTicketsResponse) ProtoMessage() {}
+
+func (x *BatchGetTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetTicketsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BatchGetTicketsResponse) GetTickets() []*Ticket {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+func (x *BatchGetTicketsResponse) GetMissingIds() []int64 {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
+type BulkUpdateTicketsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ids (не больше 500) или filter — выражение CEL, как в ListTicketsRequest
+	// (не больше 500 подходящих тикетов, иначе INVALID_ARGUMENT).
+	Ids    []int64 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	Filter string  `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Изменения — как в UpdateTicketRequest; пустые поля не меняются.
+	Subject              string `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	Notes                string `protobuf:"bytes,4,opt,name=notes,proto3" json:"notes,omitempty"`
+	Status               string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Priority             string `protobuf:"bytes,6,opt,name=priority,proto3" json:"priority,omitempty"`
+	Region               string `protobuf:"bytes,7,opt,name=region,proto3" json:"region,omitempty"`
+	CascadeCloseChildren bool   `protobuf:"varint,8,opt,name=cascade_close_children,json=cascadeCloseChildren,proto3" json:"cascade_close_children,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *BulkUpdateTicketsRequest) Reset() {
+	*x = BulkUpdateTicketsRequest{}
+	mi := &file_ticket_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkUpdateTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkUpdateTicketsRequest) ProtoMessage() {}
+
+func (x *BulkUpdateTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkUpdateTicketsRequest.ProtoReflect.Descriptor instead.
+func (*BulkUpdateTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BulkUpdateTicketsRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BulkUpdateTicketsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *BulkUpdateTicketsRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *BulkUpdateTicketsRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *BulkUpdateTicketsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BulkUpdateTicketsRequest) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *BulkUpdateTicketsRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *BulkUpdateTicketsRequest) GetCascadeCloseChildren() bool {
+	if x != nil {
+		return x.CascadeCloseChildren
+	}
+	return false
+}
+
+// BulkUpdateResult — итог по одному тикету: ticket при успехе, иначе code (google.rpc.Code) и message.
+type BulkUpdateResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ticket        *Ticket                `protobuf:"bytes,2,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Code          int32                  `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkUpdateResult) Reset() {
+	*x = BulkUpdateResult{}
+	mi := &file_ticket_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkUpdateResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkUpdateResult) ProtoMessage() {}
+
+func (x *BulkUpdateResult) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkUpdateResult.ProtoReflect.Descriptor instead.
+func (*BulkUpdateResult) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BulkUpdateResult) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BulkUpdateResult) GetTicket() *Ticket {
+	if x != nil {
+		return x.Ticket
+	}
+	return nil
+}
+
+func (x *BulkUpdateResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BulkUpdateResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type BulkUpdateTicketsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// results — в порядке ids (для filter — в порядке created_at DESC).
+	Results       []*BulkUpdateResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Updated       int32               `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	Failed        int32               `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkUpdateTicketsResponse) Reset() {
+	*x = BulkUpdateTicketsResponse{}
+	mi := &file_ticket_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkUpdateTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkUpdateTicketsResponse) ProtoMessage() {}
+
+func (x *BulkUpdateTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkUpdateTicketsResponse.ProtoReflect.Descriptor instead.
+func (*BulkUpdateTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BulkUpdateTicketsResponse) GetResults() []*BulkUpdateResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BulkUpdateTicketsResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *BulkUpdateTicketsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+type Ticket struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SessionId  string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ClientId   string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	OperatorId string                 `protobuf:"bytes,4,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
+	Status     string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Priority   string                 `protobuf:"bytes,6,opt,name=priority,proto3" json:"priority,omitempty"`
+	Region     string                 `protobuf:"bytes,7,opt,name=region,proto3" json:"region,omitempty"`
+	Subject    string                 `protobuf:"bytes,8,opt,name=subject,proto3" json:"subject,omitempty"`
+	Notes      string                 `protobuf:"bytes,9,opt,name=notes,proto3" json:"notes,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt  *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ClosedAt   *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	// merged_into_id — тикет, в который влит этот (status = merged).
+	MergedIntoId int64 `protobuf:"varint,13,opt,name=merged_into_id,json=mergedIntoId,proto3" json:"merged_into_id,omitempty"`
+	// session_ids — все сессии тикета; session_id остаётся исходной сессией.
+	SessionIds []string `protobuf:"bytes,14,rep,name=session_ids,json=sessionIds,proto3" json:"session_ids,omitempty"`
+	// reference — человекочитаемый номер: <префикс региона>-<год>-<номер за год>, например EU-2026-000123.
+	Reference       string                 `protobuf:"bytes,15,opt,name=reference,proto3" json:"reference,omitempty"`
+	DeletedAt       *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	ArchivedAt      *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	ErasedAt        *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=erased_at,json=erasedAt,proto3" json:"erased_at,omitempty"`
+	LegalHold       bool                   `protobuf:"varint,19,opt,name=legal_hold,json=legalHold,proto3" json:"legal_hold,omitempty"`
+	LegalHoldReason string                 `protobuf:"bytes,20,opt,name=legal_hold_reason,json=legalHoldReason,proto3" json:"legal_hold_reason,omitempty"`
+	LegalHoldCaseId string                 `protobuf:"bytes,21,opt,name=legal_hold_case_id,json=legalHoldCaseId,proto3" json:"legal_hold_case_id,omitempty"`
+	LegalHoldAt     *timestamppb.Timestamp `protobuf:"bytes,22,opt,name=legal_hold_at,json=legalHoldAt,proto3" json:"legal_hold_at,omitempty"`
+	// tenant_id — бренд-владелец тикета (из x-tenant-id или учётной записи вызывающего).
+	TenantId      string `protobuf:"bytes,23,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ticket) Reset() {
+	*x = Ticket{}
+	mi := &file_ticket_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ticket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ticket) ProtoMessage() {}
+
+func (x *Ticket) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ticket.ProtoReflect.Descriptor instead.
+func (*Ticket) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Ticket) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Ticket) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *Ticket) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *Ticket) GetOperatorId() string {
+	if x != nil {
+		return x.OperatorId
+	}
+	return ""
+}
+
+func (x *Ticket) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Ticket) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *Ticket) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Ticket) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Ticket) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *Ticket) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Ticket) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Ticket) GetClosedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return nil
+}
+
+func (x *Ticket) GetMergedIntoId() int64 {
+	if x != nil {
+		return x.MergedIntoId
+	}
+	return 0
+}
+
+func (x *Ticket) GetSessionIds() []string {
+	if x != nil {
+		return x.SessionIds
+	}
+	return nil
+}
+
+func (x *Ticket) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *Ticket) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Ticket) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+func (x *Ticket) GetErasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ErasedAt
+	}
+	return nil
+}
+
+func (x *Ticket) GetLegalHold() bool {
+	if x != nil {
+		return x.LegalHold
+	}
+	return false
+}
+
+func (x *Ticket) GetLegalHoldReason() string {
+	if x != nil {
+		return x.LegalHoldReason
+	}
+	return ""
+}
+
+func (x *Ticket) GetLegalHoldCaseId() string {
+	if x != nil {
+		return x.LegalHoldCaseId
+	}
+	return ""
+}
+
+func (x *Ticket) GetLegalHoldAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LegalHoldAt
+	}
+	return nil
+}
+
+func (x *Ticket) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type DeleteTicketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTicketRequest) Reset() {
+	*x = DeleteTicketRequest{}
+	mi := &file_ticket_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTicketRequest) ProtoMessage() {}
+
+func (x *DeleteTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTicketRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTicketRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeleteTicketRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RestoreTicketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTicketRequest) Reset() {
+	*x = RestoreTicketRequest{}
+	mi := &file_ticket_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTicketRequest) ProtoMessage() {}
+
+func (x *RestoreTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTicketRequest.ProtoReflect.Descriptor instead.
+func (*RestoreTicketRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RestoreTicketRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ArchiveTicketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveTicketRequest) Reset() {
+	*x = ArchiveTicketRequest{}
+	mi := &file_ticket_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveTicketRequest) ProtoMessage() {}
+
+func (x *ArchiveTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveTicketRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveTicketRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ArchiveTicketRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type PlaceLegalHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	CaseId        string                 `protobuf:"bytes,3,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceLegalHoldRequest) Reset() {
+	*x = PlaceLegalHoldRequest{}
+	mi := &file_ticket_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceLegalHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceLegalHoldRequest) ProtoMessage() {}
+
+func (x *PlaceLegalHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceLegalHoldRequest.ProtoReflect.Descriptor instead.
+func (*PlaceLegalHoldRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PlaceLegalHoldRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PlaceLegalHoldRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PlaceLegalHoldRequest) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+type ReleaseLegalHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseLegalHoldRequest) Reset() {
+	*x = ReleaseLegalHoldRequest{}
+	mi := &file_ticket_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseLegalHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseLegalHoldRequest) ProtoMessage() {}
+
+func (x *ReleaseLegalHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseLegalHoldRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseLegalHoldRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReleaseLegalHoldRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ReleaseLegalHoldRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ListTicketsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Tickets []*Ticket              `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	Total   int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// next_page_token — токен следующей страницы; пусто — страница последняя.
+	NextPageToken  string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalEstimated bool   `protobuf:"varint,4,opt,name=total_estimated,json=totalEstimated,proto3" json:"total_estimated,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListTicketsResponse) Reset() {
+	*x = ListTicketsResponse{}
+	mi := &file_ticket_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTicketsResponse) ProtoMessage() {}
+
+func (x *ListTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTicketsResponse.ProtoReflect.Descriptor instead.
+func (*ListTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListTicketsResponse) GetTickets() []*Ticket {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+func (x *ListTicketsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListTicketsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListTicketsResponse) GetTotalEstimated() bool {
+	if x != nil {
+		return x.TotalEstimated
+	}
+	return false
+}
+
+// TicketLink читается как "ticket_id <link_type> linked_ticket_id",
+// например "10 duplicate_of 7" или "7 parent 10".
+// link_type: parent, child, blocks, relates_to, duplicate_of.
+// child хранится как parent с переставленными тикетами, поэтому в ответах встречается только parent.
+type TicketLink struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TicketId       int64                  `protobuf:"varint,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	LinkedTicketId int64                  `protobuf:"varint,2,opt,name=linked_ticket_id,json=linkedTicketId,proto3" json:"linked_ticket_id,omitempty"`
+	LinkType       string                 `protobuf:"bytes,3,opt,name=link_type,json=linkType,proto3" json:"link_type,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TicketLink) Reset() {
+	*x = TicketLink{}
+	mi := &file_ticket_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketLink) ProtoMessage() {}
+
+func (x *TicketLink) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketLink.ProtoReflect.Descriptor instead.
+func (*TicketLink) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TicketLink) GetTicketId() int64 {
+	if x != nil {
+		return x.TicketId
+	}
+	return 0
+}
+
+func (x *TicketLink) GetLinkedTicketId() int64 {
+	if x != nil {
+		return x.LinkedTicketId
+	}
+	return 0
+}
+
+func (x *TicketLink) GetLinkType() string {
+	if x != nil {
+		return x.LinkType
+	}
+	return ""
+}
+
+func (x *TicketLink) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type LinkTicketsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TicketId       int64                  `protobuf:"varint,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	LinkedTicketId int64                  `protobuf:"varint,2,opt,name=linked_ticket_id,json=linkedTicketId,proto3" json:"linked_ticket_id,omitempty"`
+	LinkType       string                 `protobuf:"bytes,3,opt,name=link_type,json=linkType,proto3" json:"link_type,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LinkTicketsRequest) Reset() {
+	*x = LinkTicketsRequest{}
+	mi := &file_ticket_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkTicketsRequest) ProtoMessage() {}
+
+func (x *LinkTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkTicketsRequest.ProtoReflect.Descriptor instead.
+func (*LinkTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *LinkTicketsRequest) GetTicketId() int64 {
+	if x != nil {
+		return x.TicketId
+	}
+	return 0
+}
+
+func (x *LinkTicketsRequest) GetLinkedTicketId() int64 {
+	if x != nil {
+		return x.LinkedTicketId
+	}
+	return 0
+}
+
+func (x *LinkTicketsRequest) GetLinkType() string {
+	if x != nil {
+		return x.LinkType
+	}
+	return ""
+}
+
+type UnlinkTicketsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TicketId       int64                  `protobuf:"varint,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	LinkedTicketId int64                  `protobuf:"varint,2,opt,name=linked_ticket_id,json=linkedTicketId,proto3" json:"linked_ticket_id,omitempty"`
+	LinkType       string                 `protobuf:"bytes,3,opt,name=link_type,json=linkType,proto3" json:"link_type,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UnlinkTicketsRequest) Reset() {
+	*x = UnlinkTicketsRequest{}
+	mi := &file_ticket_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkTicketsRequest) ProtoMessage() {}
+
+func (x *UnlinkTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkTicketsRequest.ProtoReflect.Descriptor instead.
+func (*UnlinkTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UnlinkTicketsRequest) GetTicketId() int64 {
+	if x != nil {
+		return x.TicketId
+	}
+	return 0
+}
+
+func (x *UnlinkTicketsRequest) GetLinkedTicketId() int64 {
+	if x != nil {
+		return x.LinkedTicketId
+	}
+	return 0
+}
+
+func (x *UnlinkTicketsRequest) GetLinkType() string {
+	if x != nil {
+		return x.LinkType
+	}
+	return ""
+}
+
+type UnlinkTicketsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkTicketsResponse) Reset() {
+	*x = UnlinkTicketsResponse{}
+	mi := &file_ticket_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkTicketsResponse) ProtoMessage() {}
+
+func (x *UnlinkTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkTicketsResponse.ProtoReflect.Descriptor instead.
+func (*UnlinkTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{28}
+}
+
+type ListLinkedTicketsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TicketId int64                  `protobuf:"varint,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	// link_type — необязательный фильтр по типу связи.
+	LinkType      string `protobuf:"bytes,2,opt,name=link_type,json=linkType,proto3" json:"link_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLinkedTicketsRequest) Reset() {
+	*x = ListLinkedTicketsRequest{}
+	mi := &file_ticket_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLinkedTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLinkedTicketsRequest) ProtoMessage() {}
+
+func (x *ListLinkedTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLinkedTicketsRequest.ProtoReflect.Descriptor instead.
+func (*ListLinkedTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListLinkedTicketsRequest) GetTicketId() int64 {
+	if x != nil {
+		return x.TicketId
+	}
+	return 0
+}
+
+func (x *ListLinkedTicketsRequest) GetLinkType() string {
+	if x != nil {
+		return x.LinkType
+	}
+	return ""
+}
+
+type ListLinkedTicketsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*TicketLink          `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLinkedTicketsResponse) Reset() {
+	*x = ListLinkedTicketsResponse{}
+	mi := &file_ticket_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLinkedTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLinkedTicketsResponse) ProtoMessage() {}
+
+func (x *ListLinkedTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLinkedTicketsResponse.ProtoReflect.Descriptor instead.
+func (*ListLinkedTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListLinkedTicketsResponse) GetLinks() []*TicketLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+// MergeTicketsRequest — источники и target должны принадлежать одному клиенту (иначе FailedPrecondition);
+// архивные тикеты не сливаются (NotFound).
+type MergeTicketsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TargetId      int64                  `protobuf:"varint,1,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	SourceIds     []int64                `protobuf:"varint,2,rep,packed,name=source_ids,json=sourceIds,proto3" json:"source_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeTicketsRequest) Reset() {
+	*x = MergeTicketsRequest{}
+	mi := &file_ticket_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeTicketsRequest) ProtoMessage() {}
+
+func (x *MergeTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeTicketsRequest.ProtoReflect.Descriptor instead.
+func (*MergeTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *MergeTicketsRequest) GetTargetId() int64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+func (x *MergeTicketsRequest) GetSourceIds() []int64 {
+	if x != nil {
+		return x.SourceIds
+	}
+	return nil
+}
+
+type MergeTicketsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Target          *Ticket                `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	MergedTicketIds []int64                `protobuf:"varint,2,rep,packed,name=merged_ticket_ids,json=mergedTicketIds,proto3" json:"merged_ticket_ids,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MergeTicketsResponse) Reset() {
+	*x = MergeTicketsResponse{}
+	mi := &file_ticket_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeTicketsResponse) ProtoMessage() {}
+
+func (x *MergeTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeTicketsResponse.ProtoReflect.Descriptor instead.
+func (*MergeTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *MergeTicketsResponse) GetTarget() *Ticket {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *MergeTicketsResponse) GetMergedTicketIds() []int64 {
+	if x != nil {
+		return x.MergedTicketIds
+	}
+	return nil
+}
+
+type AttachSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketId      int64                  `protobuf:"varint,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachSessionRequest) Reset() {
+	*x = AttachSessionRequest{}
+	mi := &file_ticket_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachSessionRequest) ProtoMessage() {}
+
+func (x *AttachSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachSessionRequest.ProtoReflect.Descriptor instead.
+func (*AttachSessionRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AttachSessionRequest) GetTicketId() int64 {
+	if x != nil {
+		return x.TicketId
+	}
+	return 0
+}
+
+func (x *AttachSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type GetTicketsBySessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTicketsBySessionRequest) Reset() {
+	*x = GetTicketsBySessionRequest{}
+	mi := &file_ticket_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTicketsBySessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTicketsBySessionRequest) ProtoMessage() {}
+
+func (x *GetTicketsBySessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTicketsBySessionRequest.ProtoReflect.Descriptor instead.
+func (*GetTicketsBySessionRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetTicketsBySessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type AuditEntry struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TicketId int64                  `protobuf:"varint,2,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	ClientId string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Actor    string                 `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	Action   string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	// details — JSON с подробностями операции.
+	Details       string                 `protobuf:"bytes,6,opt,name=details,proto3" json:"details,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_ticket_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AuditEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetTicketId() int64 {
+	if x != nil {
+		return x.TicketId
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ExportClientDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportClientDataRequest) Reset() {
+	*x = ExportClientDataRequest{}
+	mi := &file_ticket_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportClientDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportClientDataRequest) ProtoMessage() {}
+
+func (x *ExportClientDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportClientDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportClientDataRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ExportClientDataRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type ExportClientDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ExportedAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=exported_at,json=exportedAt,proto3" json:"exported_at,omitempty"`
+	Tickets       []*Ticket              `protobuf:"bytes,3,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	Links         []*TicketLink          `protobuf:"bytes,4,rep,name=links,proto3" json:"links,omitempty"`
+	History       []*AuditEntry          `protobuf:"bytes,5,rep,name=history,proto3" json:"history,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportClientDataResponse) Reset() {
+	*x = ExportClientDataResponse{}
+	mi := &file_ticket_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportClientDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportClientDataResponse) ProtoMessage() {}
+
+func (x *ExportClientDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportClientDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportClientDataResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ExportClientDataResponse) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *ExportClientDataResponse) GetExportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExportedAt
+	}
+	return nil
+}
+
+func (x *ExportClientDataResponse) GetTickets() []*Ticket {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+func (x *ExportClientDataResponse) GetLinks() []*TicketLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *ExportClientDataResponse) GetHistory() []*AuditEntry {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+type EraseClientDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseClientDataRequest) Reset() {
+	*x = EraseClientDataRequest{}
+	mi := &file_ticket_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseClientDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseClientDataRequest) ProtoMessage() {}
+
+func (x *EraseClientDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseClientDataRequest.ProtoReflect.Descriptor instead.
+func (*EraseClientDataRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *EraseClientDataRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type EraseClientDataResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ErasedTicketIds []int64                `protobuf:"varint,1,rep,packed,name=erased_ticket_ids,json=erasedTicketIds,proto3" json:"erased_ticket_ids,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EraseClientDataResponse) Reset() {
+	*x = EraseClientDataResponse{}
+	mi := &file_ticket_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseClientDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseClientDataResponse) ProtoMessage() {}
+
+func (x *EraseClientDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseClientDataResponse.ProtoReflect.Descriptor instead.
+func (*EraseClientDataResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *EraseClientDataResponse) GetErasedTicketIds() []int64 {
+	if x != nil {
+		return x.ErasedTicketIds
+	}
+	return nil
+}
+
+var File_ticket_proto protoreflect.FileDescriptor
+
+const file_ticket_proto_rawDesc = "" +
+	"\n" +
+	"\fticket.proto\x12\x0eticket_service\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/api/annotations.proto\"\x99\x02\n" +
 	"\x13CreateTicketRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1b\n" +
@@ -534,9 +3119,15 @@ const file_ticket_proto_rawDesc = "" +
 	"\bpriority\x18\x05 \x01(\tR\bpriority\x12\x16\n" +
 	"\x06region\x18\x06 \x01(\tR\x06region\x12\x18\n" +
 	"\asubject\x18\a \x01(\tR\asubject\x12\x14\n" +
-	"\x05notes\x18\b \x01(\tR\x05notes\"\"\n" +
+	"\x05notes\x18\b \x01(\tR\x05notes\x12)\n" +
+	"\x10check_duplicates\x18\t \x01(\bR\x0fcheckDuplicates\"v\n" +
 	"\x10GetTicketRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"\xb0\x01\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12'\n" +
+	"\x0finclude_deleted\x18\x02 \x01(\bR\x0eincludeDeleted\x12)\n" +
+	"\x10include_archived\x18\x03 \x01(\bR\x0fincludeArchived\"Z\n" +
+	"\x1bGetTicketByReferenceRequest\x12\x10\n" +
+	"\x03ref\x18\x01 \x01(\tR\x03ref\x12)\n" +
+	"\x10include_archived\x18\x02 \x01(\bR\x0fincludeArchived\"\xb8\a\n" +
 	"\x12ListTicketsRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x1b\n" +
@@ -544,14 +3135,167 @@ const file_ticket_proto_rawDesc = "" +
 	"\voperator_id\x18\x04 \x01(\tR\n" +
 	"operatorId\x12\x16\n" +
 	"\x06status\x18\x05 \x01(\tR\x06status\x12\x16\n" +
-	"\x06region\x18\x06 \x01(\tR\x06region\"\xa1\x01\n" +
+	"\x06region\x18\x06 \x01(\tR\x06region\x12'\n" +
+	"\x0finclude_deleted\x18\a \x01(\bR\x0eincludeDeleted\x12)\n" +
+	"\x10include_archived\x18\b \x01(\bR\x0fincludeArchived\x12\"\n" +
+	"\n" +
+	"legal_hold\x18\t \x01(\bH\x00R\tlegalHold\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\n" +
+	" \x01(\tR\tpageToken\x12\x1d\n" +
+	"\n" +
+	"total_mode\x18\v \x01(\tR\ttotalMode\x12\x1a\n" +
+	"\bstatuses\x18\f \x03(\tR\bstatuses\x12\x1e\n" +
+	"\n" +
+	"priorities\x18\r \x03(\tR\n" +
+	"priorities\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x0e \x01(\tR\tsessionId\x12\x1e\n" +
+	"\n" +
+	"unassigned\x18\x0f \x01(\bR\n" +
+	"unassigned\x12=\n" +
+	"\fcreated_from\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12=\n" +
+	"\fupdated_from\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\vupdatedFrom\x129\n" +
+	"\n" +
+	"updated_to\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedTo\x12;\n" +
+	"\vclosed_from\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"closedFrom\x127\n" +
+	"\tclosed_to\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampR\bclosedTo\x12)\n" +
+	"\x10subject_contains\x18\x16 \x01(\tR\x0fsubjectContains\x12\x19\n" +
+	"\border_by\x18\x17 \x01(\tR\aorderBy\x12\x16\n" +
+	"\x06filter\x18\x18 \x01(\tR\x06filterB\r\n" +
+	"\v_legal_hold\"\xfc\x06\n" +
+	"\x13WatchTicketsRequest\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12\x1f\n" +
+	"\voperator_id\x18\x02 \x01(\tR\n" +
+	"operatorId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06region\x12'\n" +
+	"\x0finclude_deleted\x18\x05 \x01(\bR\x0eincludeDeleted\x12)\n" +
+	"\x10include_archived\x18\x06 \x01(\bR\x0fincludeArchived\x12\"\n" +
+	"\n" +
+	"legal_hold\x18\a \x01(\bH\x00R\tlegalHold\x88\x01\x01\x12!\n" +
+	"\fresume_token\x18\b \x01(\tR\vresumeToken\x12%\n" +
+	"\x0esnapshot_limit\x18\t \x01(\x05R\rsnapshotLimit\x12\x1a\n" +
+	"\bstatuses\x18\n" +
+	" \x03(\tR\bstatuses\x12\x1e\n" +
+	"\n" +
+	"priorities\x18\v \x03(\tR\n" +
+	"priorities\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\f \x01(\tR\tsessionId\x12\x1e\n" +
+	"\n" +
+	"unassigned\x18\r \x01(\bR\n" +
+	"unassigned\x12=\n" +
+	"\fcreated_from\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12=\n" +
+	"\fupdated_from\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\vupdatedFrom\x129\n" +
+	"\n" +
+	"updated_to\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedTo\x12;\n" +
+	"\vclosed_from\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"closedFrom\x127\n" +
+	"\tclosed_to\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\bclosedTo\x12)\n" +
+	"\x10subject_contains\x18\x14 \x01(\tR\x0fsubjectContains\x12\x16\n" +
+	"\x06filter\x18\x15 \x01(\tR\x06filterB\r\n" +
+	"\v_legal_hold\"\xf7\x06\n" +
+	"\x14SearchTicketsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x1b\n" +
+	"\tclient_id\x18\x04 \x01(\tR\bclientId\x12\x1f\n" +
+	"\voperator_id\x18\x05 \x01(\tR\n" +
+	"operatorId\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x16\n" +
+	"\x06region\x18\a \x01(\tR\x06region\x12'\n" +
+	"\x0finclude_deleted\x18\b \x01(\bR\x0eincludeDeleted\x12)\n" +
+	"\x10include_archived\x18\t \x01(\bR\x0fincludeArchived\x12\"\n" +
+	"\n" +
+	"legal_hold\x18\n" +
+	" \x01(\bH\x00R\tlegalHold\x88\x01\x01\x12\x1a\n" +
+	"\bstatuses\x18\v \x03(\tR\bstatuses\x12\x1e\n" +
+	"\n" +
+	"priorities\x18\f \x03(\tR\n" +
+	"priorities\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\r \x01(\tR\tsessionId\x12\x1e\n" +
+	"\n" +
+	"unassigned\x18\x0e \x01(\bR\n" +
+	"unassigned\x12=\n" +
+	"\fcreated_from\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12=\n" +
+	"\fupdated_from\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\vupdatedFrom\x129\n" +
+	"\n" +
+	"updated_to\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedTo\x12;\n" +
+	"\vclosed_from\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"closedFrom\x127\n" +
+	"\tclosed_to\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\bclosedTo\x12)\n" +
+	"\x10subject_contains\x18\x15 \x01(\tR\x0fsubjectContains\x12\x16\n" +
+	"\x06filter\x18\x16 \x01(\tR\x06filterB\r\n" +
+	"\v_legal_hold\"\x9d\x01\n" +
+	"\tSearchHit\x12.\n" +
+	"\x06ticket\x18\x01 \x01(\v2\x16.ticket_service.TicketR\x06ticket\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x02R\x04rank\x12'\n" +
+	"\x0fsubject_snippet\x18\x03 \x01(\tR\x0esubjectSnippet\x12#\n" +
+	"\rnotes_snippet\x18\x04 \x01(\tR\fnotesSnippet\"F\n" +
+	"\x15SearchTicketsResponse\x12-\n" +
+	"\x04hits\x18\x01 \x03(\v2\x19.ticket_service.SearchHitR\x04hits\"\xed\x01\n" +
+	"\x19FindSimilarTicketsRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x14\n" +
+	"\x05notes\x18\x02 \x01(\tR\x05notes\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12\x1f\n" +
+	"\vwindow_days\x18\x04 \x01(\x05R\n" +
+	"windowDays\x12%\n" +
+	"\x0emin_similarity\x18\x05 \x01(\x02R\rminSimilarity\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\x12%\n" +
+	"\x0einclude_closed\x18\a \x01(\bR\rincludeClosed\"_\n" +
+	"\rSimilarTicket\x12.\n" +
+	"\x06ticket\x18\x01 \x01(\v2\x16.ticket_service.TicketR\x06ticket\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x02 \x01(\x02R\n" +
+	"similarity\"U\n" +
+	"\x1aFindSimilarTicketsResponse\x127\n" +
+	"\atickets\x18\x01 \x03(\v2\x1d.ticket_service.SimilarTicketR\atickets\"t\n" +
+	"\vTicketEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12.\n" +
+	"\x06ticket\x18\x02 \x01(\v2\x16.ticket_service.TicketR\x06ticket\x12!\n" +
+	"\fresume_token\x18\x03 \x01(\tR\vresumeToken\"\xd7\x01\n" +
 	"\x13UpdateTicketRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
 	"\asubject\x18\x02 \x01(\tR\asubject\x12\x14\n" +
 	"\x05notes\x18\x03 \x01(\tR\x05notes\x12\x16\n" +
 	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1a\n" +
 	"\bpriority\x18\x05 \x01(\tR\bpriority\x12\x16\n" +
-	"\x06region\x18\x06 \x01(\tR\x06region\"\xa0\x03\n" +
+	"\x06region\x18\x06 \x01(\tR\x06region\x124\n" +
+	"\x16cascade_close_children\x18\a \x01(\bR\x14cascadeCloseChildren\"S\n" +
+	"\x16BatchGetTicketsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\x12'\n" +
+	"\x0finclude_deleted\x18\x02 \x01(\bR\x0eincludeDeleted\"l\n" +
+	"\x17BatchGetTicketsResponse\x120\n" +
+	"\atickets\x18\x01 \x03(\v2\x16.ticket_service.TicketR\atickets\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\x03R\n" +
+	"missingIds\"\xf6\x01\n" +
+	"\x18BulkUpdateTicketsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\x12\x16\n" +
+	"\x06filter\x18\x02 \x01(\tR\x06filter\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\x12\x14\n" +
+	"\x05notes\x18\x04 \x01(\tR\x05notes\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1a\n" +
+	"\bpriority\x18\x06 \x01(\tR\bpriority\x12\x16\n" +
+	"\x06region\x18\a \x01(\tR\x06region\x124\n" +
+	"\x16cascade_close_children\x18\b \x01(\bR\x14cascadeCloseChildren\"\x80\x01\n" +
+	"\x10BulkUpdateResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12.\n" +
+	"\x06ticket\x18\x02 \x01(\v2\x16.ticket_service.TicketR\x06ticket\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\x89\x01\n" +
+	"\x19BulkUpdateTicketsResponse\x12:\n" +
+	"\aresults\x18\x01 \x03(\v2 .ticket_service.BulkUpdateResultR\aresults\x12\x18\n" +
+	"\aupdated\x18\x02 \x01(\x05R\aupdated\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\"\x8b\a\n" +
 	"\x06Ticket\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
 	"\n" +
@@ -569,15 +3313,122 @@ const file_ticket_proto_rawDesc = "" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x127\n" +
-	"\tclosed_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\"]\n" +
+	"\tclosed_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\x12$\n" +
+	"\x0emerged_into_id\x18\r \x01(\x03R\fmergedIntoId\x12\x1f\n" +
+	"\vsession_ids\x18\x0e \x03(\tR\n" +
+	"sessionIds\x12\x1c\n" +
+	"\treference\x18\x0f \x01(\tR\treference\x129\n" +
+	"\n" +
+	"deleted_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12;\n" +
+	"\varchived_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"archivedAt\x127\n" +
+	"\terased_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\berasedAt\x12\x1d\n" +
+	"\n" +
+	"legal_hold\x18\x13 \x01(\bR\tlegalHold\x12*\n" +
+	"\x11legal_hold_reason\x18\x14 \x01(\tR\x0flegalHoldReason\x12+\n" +
+	"\x12legal_hold_case_id\x18\x15 \x01(\tR\x0flegalHoldCaseId\x12>\n" +
+	"\rlegal_hold_at\x18\x16 \x01(\v2\x1a.google.protobuf.TimestampR\vlegalHoldAt\x12\x1b\n" +
+	"\ttenant_id\x18\x17 \x01(\tR\btenantId\"%\n" +
+	"\x13DeleteTicketRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"&\n" +
+	"\x14RestoreTicketRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"&\n" +
+	"\x14ArchiveTicketRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"X\n" +
+	"\x15PlaceLegalHoldRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x17\n" +
+	"\acase_id\x18\x03 \x01(\tR\x06caseId\"A\n" +
+	"\x17ReleaseLegalHoldRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\xae\x01\n" +
 	"\x13ListTicketsResponse\x120\n" +
 	"\atickets\x18\x01 \x03(\v2\x16.ticket_service.TicketR\atickets\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total2\xbc\x03\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\x12'\n" +
+	"\x0ftotal_estimated\x18\x04 \x01(\bR\x0etotalEstimated\"\xab\x01\n" +
+	"\n" +
+	"TicketLink\x12\x1b\n" +
+	"\tticket_id\x18\x01 \x01(\x03R\bticketId\x12(\n" +
+	"\x10linked_ticket_id\x18\x02 \x01(\x03R\x0elinkedTicketId\x12\x1b\n" +
+	"\tlink_type\x18\x03 \x01(\tR\blinkType\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"x\n" +
+	"\x12LinkTicketsRequest\x12\x1b\n" +
+	"\tticket_id\x18\x01 \x01(\x03R\bticketId\x12(\n" +
+	"\x10linked_ticket_id\x18\x02 \x01(\x03R\x0elinkedTicketId\x12\x1b\n" +
+	"\tlink_type\x18\x03 \x01(\tR\blinkType\"z\n" +
+	"\x14UnlinkTicketsRequest\x12\x1b\n" +
+	"\tticket_id\x18\x01 \x01(\x03R\bticketId\x12(\n" +
+	"\x10linked_ticket_id\x18\x02 \x01(\x03R\x0elinkedTicketId\x12\x1b\n" +
+	"\tlink_type\x18\x03 \x01(\tR\blinkType\"\x17\n" +
+	"\x15UnlinkTicketsResponse\"T\n" +
+	"\x18ListLinkedTicketsRequest\x12\x1b\n" +
+	"\tticket_id\x18\x01 \x01(\x03R\bticketId\x12\x1b\n" +
+	"\tlink_type\x18\x02 \x01(\tR\blinkType\"M\n" +
+	"\x19ListLinkedTicketsResponse\x120\n" +
+	"\x05links\x18\x01 \x03(\v2\x1a.ticket_service.TicketLinkR\x05links\"Q\n" +
+	"\x13MergeTicketsRequest\x12\x1b\n" +
+	"\ttarget_id\x18\x01 \x01(\x03R\btargetId\x12\x1d\n" +
+	"\n" +
+	"source_ids\x18\x02 \x03(\x03R\tsourceIds\"r\n" +
+	"\x14MergeTicketsResponse\x12.\n" +
+	"\x06target\x18\x01 \x01(\v2\x16.ticket_service.TicketR\x06target\x12*\n" +
+	"\x11merged_ticket_ids\x18\x02 \x03(\x03R\x0fmergedTicketIds\"R\n" +
+	"\x14AttachSessionRequest\x12\x1b\n" +
+	"\tticket_id\x18\x01 \x01(\x03R\bticketId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\";\n" +
+	"\x1aGetTicketsBySessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"\xd9\x01\n" +
+	"\n" +
+	"AuditEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
+	"\tticket_id\x18\x02 \x01(\x03R\bticketId\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12\x18\n" +
+	"\adetails\x18\x06 \x01(\tR\adetails\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"6\n" +
+	"\x17ExportClientDataRequest\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\"\x8e\x02\n" +
+	"\x18ExportClientDataResponse\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12;\n" +
+	"\vexported_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"exportedAt\x120\n" +
+	"\atickets\x18\x03 \x03(\v2\x16.ticket_service.TicketR\atickets\x120\n" +
+	"\x05links\x18\x04 \x03(\v2\x1a.ticket_service.TicketLinkR\x05links\x124\n" +
+	"\ahistory\x18\x05 \x03(\v2\x1a.ticket_service.AuditEntryR\ahistory\"5\n" +
+	"\x16EraseClientDataRequest\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\"E\n" +
+	"\x17EraseClientDataResponse\x12*\n" +
+	"\x11erased_ticket_ids\x18\x01 \x03(\x03R\x0ferasedTicketIds2\xa9\x17\n" +
 	"\rTicketService\x12g\n" +
 	"\fCreateTicket\x12#.ticket_service.CreateTicketRequest\x1a\x16.ticket_service.Ticket\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/api/v1/tickets\x12c\n" +
-	"\tGetTicket\x12 .ticket_service.GetTicketRequest\x1a\x16.ticket_service.Ticket\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/tickets/{id}\x12o\n" +
-	"\vListTickets\x12\".ticket_service.ListTicketsRequest\x1a#.ticket_service.ListTicketsResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/api/v1/tickets\x12l\n" +
-	"\fUpdateTicket\x12#.ticket_service.UpdateTicketRequest\x1a\x16.ticket_service.Ticket\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\x1a\x14/api/v1/tickets/{id}BSZQgithub.com/psds-microservice/ticket-service/pkg/gen/ticket_service;ticket_serviceb\x06proto3"
+	"\tGetTicket\x12 .ticket_service.GetTicketRequest\x1a\x16.ticket_service.Ticket\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/tickets/{id}\x12\x81\x01\n" +
+	"\x14GetTicketByReference\x12+.ticket_service.GetTicketByReferenceRequest\x1a\x16.ticket_service.Ticket\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/api/v1/tickets/by-ref/{ref}\x12o\n" +
+	"\vListTickets\x12\".ticket_service.ListTicketsRequest\x1a#.ticket_service.ListTicketsResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/api/v1/tickets\x12q\n" +
+	"\fWatchTickets\x12#.ticket_service.WatchTicketsRequest\x1a\x1b.ticket_service.TicketEvent\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/tickets/watch0\x01\x12|\n" +
+	"\rSearchTickets\x12$.ticket_service.SearchTicketsRequest\x1a%.ticket_service.SearchTicketsResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/api/v1/tickets/search\x12\x8f\x01\n" +
+	"\x12FindSimilarTickets\x12).ticket_service.FindSimilarTicketsRequest\x1a*.ticket_service.FindSimilarTicketsResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/api/v1/tickets/similar\x12l\n" +
+	"\fUpdateTicket\x12#.ticket_service.UpdateTicketRequest\x1a\x16.ticket_service.Ticket\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\x1a\x14/api/v1/tickets/{id}\x12\x81\x01\n" +
+	"\x0fBatchGetTickets\x12&.ticket_service.BatchGetTicketsRequest\x1a'.ticket_service.BatchGetTicketsResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/tickets/batch\x12\x90\x01\n" +
+	"\x11BulkUpdateTickets\x12(.ticket_service.BulkUpdateTicketsRequest\x1a).ticket_service.BulkUpdateTicketsResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/tickets/bulk-update\x12i\n" +
+	"\fDeleteTicket\x12#.ticket_service.DeleteTicketRequest\x1a\x16.google.protobuf.Empty\"\x1c\x82\xd3\xe4\x93\x02\x16*\x14/api/v1/tickets/{id}\x12v\n" +
+	"\rRestoreTicket\x12$.ticket_service.RestoreTicketRequest\x1a\x16.ticket_service.Ticket\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/tickets/{id}/restore\x12v\n" +
+	"\rArchiveTicket\x12$.ticket_service.ArchiveTicketRequest\x1a\x16.ticket_service.Ticket\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/tickets/{id}/archive\x12{\n" +
+	"\x0ePlaceLegalHold\x12%.ticket_service.PlaceLegalHoldRequest\x1a\x16.ticket_service.Ticket\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/api/v1/tickets/{id}/legal-hold\x12|\n" +
+	"\x10ReleaseLegalHold\x12'.ticket_service.ReleaseLegalHoldRequest\x1a\x16.ticket_service.Ticket\"'\x82\xd3\xe4\x93\x02!*\x1f/api/v1/tickets/{id}/legal-hold\x12{\n" +
+	"\vLinkTickets\x12\".ticket_service.LinkTicketsRequest\x1a\x1a.ticket_service.TicketLink\",\x82\xd3\xe4\x93\x02&:\x01*\"!/api/v1/tickets/{ticket_id}/links\x12\x9a\x01\n" +
+	"\rUnlinkTickets\x12$.ticket_service.UnlinkTicketsRequest\x1a%.ticket_service.UnlinkTicketsResponse\"<\x82\xd3\xe4\x93\x026*4/api/v1/tickets/{ticket_id}/links/{linked_ticket_id}\x12\x93\x01\n" +
+	"\x11ListLinkedTickets\x12(.ticket_service.ListLinkedTicketsRequest\x1a).ticket_service.ListLinkedTicketsResponse\")\x82\xd3\xe4\x93\x02#\x12!/api/v1/tickets/{ticket_id}/links\x12\x87\x01\n" +
+	"\fMergeTickets\x12#.ticket_service.MergeTicketsRequest\x1a$.ticket_service.MergeTicketsResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/api/v1/tickets/{target_id}/merge\x12~\n" +
+	"\rAttachSession\x12$.ticket_service.AttachSessionRequest\x1a\x16.ticket_service.Ticket\"/\x82\xd3\xe4\x93\x02):\x01*\"$/api/v1/tickets/{ticket_id}/sessions\x12\x95\x01\n" +
+	"\x13GetTicketsBySession\x12*.ticket_service.GetTicketsBySessionRequest\x1a#.ticket_service.ListTicketsResponse\"-\x82\xd3\xe4\x93\x02'\x12%/api/v1/sessions/{session_id}/tickets\x12\x91\x01\n" +
+	"\x10ExportClientData\x12'.ticket_service.ExportClientDataRequest\x1a(.ticket_service.ExportClientDataResponse\"*\x82\xd3\xe4\x93\x02$\x12\"/api/v1/clients/{client_id}/export\x12\x90\x01\n" +
+	"\x0fEraseClientData\x12&.ticket_service.EraseClientDataRequest\x1a'.ticket_service.EraseClientDataResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/api/v1/clients/{client_id}/eraseBSZQgithub.com/psds-microservice/ticket-service/pkg/gen/ticket_service;ticket_serviceb\x06proto3"
 
 var (
 	file_ticket_proto_rawDescOnce sync.Once
@@ -591,34 +3442,145 @@ func file_ticket_proto_rawDescGZIP() []byte {
 	return file_ticket_proto_rawDescData
 }
 
-var file_ticket_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_ticket_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_ticket_proto_goTypes = []any{
-	(*CreateTicketRequest)(nil),   // 0: ticket_service.CreateTicketRequest
-	(*GetTicketRequest)(nil),      // 1: ticket_service.GetTicketRequest
-	(*ListTicketsRequest)(nil),    // 2: ticket_service.ListTicketsRequest
-	(*UpdateTicketRequest)(nil),   // 3: ticket_service.UpdateTicketRequest
-	(*Ticket)(nil),                // 4: ticket_service.Ticket
-	(*ListTicketsResponse)(nil),   // 5: ticket_service.ListTicketsResponse
-	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+	(*CreateTicketRequest)(nil),         // 0: ticket_service.CreateTicketRequest
+	(*GetTicketRequest)(nil),            // 1: ticket_service.GetTicketRequest
+	(*GetTicketByReferenceRequest)(nil), // 2: ticket_service.GetTicketByReferenceRequest
+	(*ListTicketsRequest)(nil),          // 3: ticket_service.ListTicketsRequest
+	(*WatchTicketsRequest)(nil),         // 4: ticket_service.WatchTicketsRequest
+	(*SearchTicketsRequest)(nil),        // 5: ticket_service.SearchTicketsRequest
+	(*SearchHit)(nil),                   // 6: ticket_service.SearchHit
+	(*SearchTicketsResponse)(nil),       // 7: ticket_service.SearchTicketsResponse
+	(*FindSimilarTicketsRequest)(nil),   // 8: ticket_service.FindSimilarTicketsRequest
+	(*SimilarTicket)(nil),               // 9: ticket_service.SimilarTicket
+	(*FindSimilarTicketsResponse)(nil),  // 10: ticket_service.FindSimilarTicketsResponse
+	(*TicketEvent)(nil),                 // 11: ticket_service.TicketEvent
+	(*UpdateTicketRequest)(nil),         // 12: ticket_service.UpdateTicketRequest
+	(*BatchGetTicketsRequest)(nil),      // 13: ticket_service.BatchGetTicketsRequest
+	(*BatchGetTicketsResponse)(nil),     // 14: ticket_service.BatchGetTicketsResponse
+	(*BulkUpdateTicketsRequest)(nil),    // 15: ticket_service.BulkUpdateTicketsRequest
+	(*BulkUpdateResult)(nil),            // 16: ticket_service.BulkUpdateResult
+	(*BulkUpdateTicketsResponse)(nil),   // 17: ticket_service.BulkUpdateTicketsResponse
+	(*Ticket)(nil),                      // 18: ticket_service.Ticket
+	(*DeleteTicketRequest)(nil),         // 19: ticket_service.DeleteTicketRequest
+	(*RestoreTicketRequest)(nil),        // 20: ticket_service.RestoreTicketRequest
+	(*ArchiveTicketRequest)(nil),        // 21: ticket_service.ArchiveTicketRequest
+	(*PlaceLegalHoldRequest)(nil),       // 22: ticket_service.PlaceLegalHoldRequest
+	(*ReleaseLegalHoldRequest)(nil),     // 23: ticket_service.ReleaseLegalHoldRequest
+	(*ListTicketsResponse)(nil),         // 24: ticket_service.ListTicketsResponse
+	(*TicketLink)(nil),                  // 25: ticket_service.TicketLink
+	(*LinkTicketsRequest)(nil),          // 26: ticket_service.LinkTicketsRequest
+	(*UnlinkTicketsRequest)(nil),        // 27: ticket_service.UnlinkTicketsRequest
+	(*UnlinkTicketsResponse)(nil),       // 28: ticket_service.UnlinkTicketsResponse
+	(*ListLinkedTicketsRequest)(nil),    // 29: ticket_service.ListLinkedTicketsRequest
+	(*ListLinkedTicketsResponse)(nil),   // 30: ticket_service.ListLinkedTicketsResponse
+	(*MergeTicketsRequest)(nil),         // 31: ticket_service.MergeTicketsRequest
+	(*MergeTicketsResponse)(nil),        // 32: ticket_service.MergeTicketsResponse
+	(*AttachSessionRequest)(nil),        // 33: ticket_service.AttachSessionRequest
+	(*GetTicketsBySessionRequest)(nil),  // 34: ticket_service.GetTicketsBySessionRequest
+	(*AuditEntry)(nil),                  // 35: ticket_service.AuditEntry
+	(*ExportClientDataRequest)(nil),     // 36: ticket_service.ExportClientDataRequest
+	(*ExportClientDataResponse)(nil),    // 37: ticket_service.ExportClientDataResponse
+	(*EraseClientDataRequest)(nil),      // 38: ticket_service.EraseClientDataRequest
+	(*EraseClientDataResponse)(nil),     // 39: ticket_service.EraseClientDataResponse
+	(*timestamppb.Timestamp)(nil),       // 40: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),               // 41: google.protobuf.Empty
 }
 var file_ticket_proto_depIdxs = []int32{
-	6, // 0: ticket_service.Ticket.created_at:type_name -> google.protobuf.Timestamp
-	6, // 1: ticket_service.Ticket.updated_at:type_name -> google.protobuf.Timestamp
-	6, // 2: ticket_service.Ticket.closed_at:type_name -> google.protobuf.Timestamp
-	4, // 3: ticket_service.ListTicketsResponse.tickets:type_name -> ticket_service.Ticket
-	0, // 4: ticket_service.TicketService.CreateTicket:input_type -> ticket_service.CreateTicketRequest
-	1, // 5: ticket_service.TicketService.GetTicket:input_type -> ticket_service.GetTicketRequest
-	2, // 6: ticket_service.TicketService.ListTickets:input_type -> ticket_service.ListTicketsRequest
-	3, // 7: ticket_service.TicketService.UpdateTicket:input_type -> ticket_service.UpdateTicketRequest
-	4, // 8: ticket_service.TicketService.CreateTicket:output_type -> ticket_service.Ticket
-	4, // 9: ticket_service.TicketService.GetTicket:output_type -> ticket_service.Ticket
-	5, // 10: ticket_service.TicketService.ListTickets:output_type -> ticket_service.ListTicketsResponse
-	4, // 11: ticket_service.TicketService.UpdateTicket:output_type -> ticket_service.Ticket
-	8, // [8:12] is the sub-list for method output_type
-	4, // [4:8] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	40, // 0: ticket_service.ListTicketsRequest.created_from:type_name -> google.protobuf.Timestamp
+	40, // 1: ticket_service.ListTicketsRequest.created_to:type_name -> google.protobuf.Timestamp
+	40, // 2: ticket_service.ListTicketsRequest.updated_from:type_name -> google.protobuf.Timestamp
+	40, // 3: ticket_service.ListTicketsRequest.updated_to:type_name -> google.protobuf.Timestamp
+	40, // 4: ticket_service.ListTicketsRequest.closed_from:type_name -> google.protobuf.Timestamp
+	40, // 5: ticket_service.ListTicketsRequest.closed_to:type_name -> google.protobuf.Timestamp
+	40, // 6: ticket_service.WatchTicketsRequest.created_from:type_name -> google.protobuf.Timestamp
+	40, // 7: ticket_service.WatchTicketsRequest.created_to:type_name -> google.protobuf.Timestamp
+	40, // 8: ticket_service.WatchTicketsRequest.updated_from:type_name -> google.protobuf.Timestamp
+	40, // 9: ticket_service.WatchTicketsRequest.updated_to:type_name -> google.protobuf.Timestamp
+	40, // 10: ticket_service.WatchTicketsRequest.closed_from:type_name -> google.protobuf.Timestamp
+	40, // 11: ticket_service.WatchTicketsRequest.closed_to:type_name -> google.protobuf.Timestamp
+	40, // 12: ticket_service.SearchTicketsRequest.created_from:type_name -> google.protobuf.Timestamp
+	40, // 13: ticket_service.SearchTicketsRequest.created_to:type_name -> google.protobuf.Timestamp
+	40, // 14: ticket_service.SearchTicketsRequest.updated_from:type_name -> google.protobuf.Timestamp
+	40, // 15: ticket_service.SearchTicketsRequest.updated_to:type_name -> google.protobuf.Timestamp
+	40, // 16: ticket_service.SearchTicketsRequest.closed_from:type_name -> google.protobuf.Timestamp
+	40, // 17: ticket_service.SearchTicketsRequest.closed_to:type_name -> google.protobuf.Timestamp
+	18, // 18: ticket_service.SearchHit.ticket:type_name -> ticket_service.Ticket
+	6,  // 19: ticket_service.SearchTicketsResponse.hits:type_name -> ticket_service.SearchHit
+	18, // 20: ticket_service.SimilarTicket.ticket:type_name -> ticket_service.Ticket
+	9,  // 21: ticket_service.FindSimilarTicketsResponse.tickets:type_name -> ticket_service.SimilarTicket
+	18, // 22: ticket_service.TicketEvent.ticket:type_name -> ticket_service.Ticket
+	18, // 23: ticket_service.BatchGetTicketsResponse.tickets:type_name -> ticket_service.Ticket
+	18, // 24: ticket_service.BulkUpdateResult.ticket:type_name -> ticket_service.Ticket
+	16, // 25: ticket_service.BulkUpdateTicketsResponse.results:type_name -> ticket_service.BulkUpdateResult
+	40, // 26: ticket_service.Ticket.created_at:type_name -> google.protobuf.Timestamp
+	40, // 27: ticket_service.Ticket.updated_at:type_name -> google.protobuf.Timestamp
+	40, // 28: ticket_service.Ticket.closed_at:type_name -> google.protobuf.Timestamp
+	40, // 29: ticket_service.Ticket.deleted_at:type_name -> google.protobuf.Timestamp
+	40, // 30: ticket_service.Ticket.archived_at:type_name -> google.protobuf.Timestamp
+	40, // 31: ticket_service.Ticket.erased_at:type_name -> google.protobuf.Timestamp
+	40, // 32: ticket_service.Ticket.legal_hold_at:type_name -> google.protobuf.Timestamp
+	18, // 33: ticket_service.ListTicketsResponse.tickets:type_name -> ticket_service.Ticket
+	40, // 34: ticket_service.TicketLink.created_at:type_name -> google.protobuf.Timestamp
+	25, // 35: ticket_service.ListLinkedTicketsResponse.links:type_name -> ticket_service.TicketLink
+	18, // 36: ticket_service.MergeTicketsResponse.target:type_name -> ticket_service.Ticket
+	40, // 37: ticket_service.AuditEntry.created_at:type_name -> google.protobuf.Timestamp
+	40, // 38: ticket_service.ExportClientDataResponse.exported_at:type_name -> google.protobuf.Timestamp
+	18, // 39: ticket_service.ExportClientDataResponse.tickets:type_name -> ticket_service.Ticket
+	25, // 40: ticket_service.ExportClientDataResponse.links:type_name -> ticket_service.TicketLink
+	35, // 41: ticket_service.ExportClientDataResponse.history:type_name -> ticket_service.AuditEntry
+	0,  // 42: ticket_service.TicketService.CreateTicket:input_type -> ticket_service.CreateTicketRequest
+	1,  // 43: ticket_service.TicketService.GetTicket:input_type -> ticket_service.GetTicketRequest
+	2,  // 44: ticket_service.TicketService.GetTicketByReference:input_type -> ticket_service.GetTicketByReferenceRequest
+	3,  // 45: ticket_service.TicketService.ListTickets:input_type -> ticket_service.ListTicketsRequest
+	4,  // 46: ticket_service.TicketService.WatchTickets:input_type -> ticket_service.WatchTicketsRequest
+	5,  // 47: ticket_service.TicketService.SearchTickets:input_type -> ticket_service.SearchTicketsRequest
+	8,  // 48: ticket_service.TicketService.FindSimilarTickets:input_type -> ticket_service.FindSimilarTicketsRequest
+	12, // 49: ticket_service.TicketService.UpdateTicket:input_type -> ticket_service.UpdateTicketRequest
+	13, // 50: ticket_service.TicketService.BatchGetTickets:input_type -> ticket_service.BatchGetTicketsRequest
+	15, // 51: ticket_service.TicketService.BulkUpdateTickets:input_type -> ticket_service.BulkUpdateTicketsRequest
+	19, // 52: ticket_service.TicketService.DeleteTicket:input_type -> ticket_service.DeleteTicketRequest
+	20, // 53: ticket_service.TicketService.RestoreTicket:input_type -> ticket_service.RestoreTicketRequest
+	21, // 54: ticket_service.TicketService.ArchiveTicket:input_type -> ticket_service.ArchiveTicketRequest
+	22, // 55: ticket_service.TicketService.PlaceLegalHold:input_type -> ticket_service.PlaceLegalHoldRequest
+	23, // 56: ticket_service.TicketService.ReleaseLegalHold:input_type -> ticket_service.ReleaseLegalHoldRequest
+	26, // 57: ticket_service.TicketService.LinkTickets:input_type -> ticket_service.LinkTicketsRequest
+	27, // 58: ticket_service.TicketService.UnlinkTickets:input_type -> ticket_service.UnlinkTicketsRequest
+	29, // 59: ticket_service.TicketService.ListLinkedTickets:input_type -> ticket_service.ListLinkedTicketsRequest
+	31, // 60: ticket_service.TicketService.MergeTickets:input_type -> ticket_service.MergeTicketsRequest
+	33, // 61: ticket_service.TicketService.AttachSession:input_type -> ticket_service.AttachSessionRequest
+	34, // 62: ticket_service.TicketService.GetTicketsBySession:input_type -> ticket_service.GetTicketsBySessionRequest
+	36, // 63: ticket_service.TicketService.ExportClientData:input_type -> ticket_service.ExportClientDataRequest
+	38, // 64: ticket_service.TicketService.EraseClientData:input_type -> ticket_service.EraseClientDataRequest
+	18, // 65: ticket_service.TicketService.CreateTicket:output_type -> ticket_service.Ticket
+	18, // 66: ticket_service.TicketService.GetTicket:output_type -> ticket_service.Ticket
+	18, // 67: ticket_service.TicketService.GetTicketByReference:output_type -> ticket_service.Ticket
+	24, // 68: ticket_service.TicketService.ListTickets:output_type -> ticket_service.ListTicketsResponse
+	11, // 69: ticket_service.TicketService.WatchTickets:output_type -> ticket_service.TicketEvent
+	7,  // 70: ticket_service.TicketService.SearchTickets:output_type -> ticket_service.SearchTicketsResponse
+	10, // 71: ticket_service.TicketService.FindSimilarTickets:output_type -> ticket_service.FindSimilarTicketsResponse
+	18, // 72: ticket_service.TicketService.UpdateTicket:output_type -> ticket_service.Ticket
+	14, // 73: ticket_service.TicketService.BatchGetTickets:output_type -> ticket_service.BatchGetTicketsResponse
+	17, // 74: ticket_service.TicketService.BulkUpdateTickets:output_type -> ticket_service.BulkUpdateTicketsResponse
+	41, // 75: ticket_service.TicketService.DeleteTicket:output_type -> google.protobuf.Empty
+	18, // 76: ticket_service.TicketService.RestoreTicket:output_type -> ticket_service.Ticket
+	18, // 77: ticket_service.TicketService.ArchiveTicket:output_type -> ticket_service.Ticket
+	18, // 78: ticket_service.TicketService.PlaceLegalHold:output_type -> ticket_service.Ticket
+	18, // 79: ticket_service.TicketService.ReleaseLegalHold:output_type -> ticket_service.Ticket
+	25, // 80: ticket_service.TicketService.LinkTickets:output_type -> ticket_service.TicketLink
+	28, // 81: ticket_service.TicketService.UnlinkTickets:output_type -> ticket_service.UnlinkTicketsResponse
+	30, // 82: ticket_service.TicketService.ListLinkedTickets:output_type -> ticket_service.ListLinkedTicketsResponse
+	32, // 83: ticket_service.TicketService.MergeTickets:output_type -> ticket_service.MergeTicketsResponse
+	18, // 84: ticket_service.TicketService.AttachSession:output_type -> ticket_service.Ticket
+	24, // 85: ticket_service.TicketService.GetTicketsBySession:output_type -> ticket_service.ListTicketsResponse
+	37, // 86: ticket_service.TicketService.ExportClientData:output_type -> ticket_service.ExportClientDataResponse
+	39, // 87: ticket_service.TicketService.EraseClientData:output_type -> ticket_service.EraseClientDataResponse
+	65, // [65:88] is the sub-list for method output_type
+	42, // [42:65] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_ticket_proto_init() }
@@ -626,13 +3588,16 @@ func file_ticket_proto_init() {
 	if File_ticket_proto != nil {
 		return
 	}
+	file_ticket_proto_msgTypes[3].OneofWrappers = []any{}
+	file_ticket_proto_msgTypes[4].OneofWrappers = []any{}
+	file_ticket_proto_msgTypes[5].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ticket_proto_rawDesc), len(file_ticket_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -19,10 +20,29 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TicketService_CreateTicket_FullMethodName = "/ticket_service.TicketService/CreateTicket"
-	TicketService_GetTicket_FullMethodName    = "/ticket_service.TicketService/GetTicket"
-	TicketService_ListTickets_FullMethodName  = "/ticket_service.TicketService/ListTickets"
-	TicketService_UpdateTicket_FullMethodName = "/ticket_service.TicketService/UpdateTicket"
+	TicketService_CreateTicket_FullMethodName         = "/ticket_service.TicketService/CreateTicket"
+	TicketService_GetTicket_FullMethodName            = "/ticket_service.TicketService/GetTicket"
+	TicketService_GetTicketByReference_FullMethodName = "/ticket_service.TicketService/GetTicketByReference"
+	TicketService_ListTickets_FullMethodName          = "/ticket_service.TicketService/ListTickets"
+	TicketService_WatchTickets_FullMethodName         = "/ticket_service.TicketService/WatchTickets"
+	TicketService_SearchTickets_FullMethodName        = "/ticket_service.TicketService/SearchTickets"
+	TicketService_FindSimilarTickets_FullMethodName   = "/ticket_service.TicketService/FindSimilarTickets"
+	TicketService_UpdateTicket_FullMethodName         = "/ticket_service.TicketService/UpdateTicket"
+	TicketService_BatchGetTickets_FullMethodName      = "/ticket_service.TicketService/BatchGetTickets"
+	TicketService_BulkUpdateTickets_FullMethodName    = "/ticket_service.TicketService/BulkUpdateTickets"
+	TicketService_DeleteTicket_FullMethodName         = "/ticket_service.TicketService/DeleteTicket"
+	TicketService_RestoreTicket_FullMethodName        = "/ticket_service.TicketService/RestoreTicket"
+	TicketService_ArchiveTicket_FullMethodName        = "/ticket_service.TicketService/ArchiveTicket"
+	TicketService_PlaceLegalHold_FullMethodName       = "/ticket_service.TicketService/PlaceLegalHold"
+	TicketService_ReleaseLegalHold_FullMethodName     = "/ticket_service.TicketService/ReleaseLegalHold"
+	TicketService_LinkTickets_FullMethodName          = "/ticket_service.TicketService/LinkTickets"
+	TicketService_UnlinkTickets_FullMethodName        = "/ticket_service.TicketService/UnlinkTickets"
+	TicketService_ListLinkedTickets_FullMethodName    = "/ticket_service.TicketService/ListLinkedTickets"
+	TicketService_MergeTickets_FullMethodName         = "/ticket_service.TicketService/MergeTickets"
+	TicketService_AttachSession_FullMethodName        = "/ticket_service.TicketService/AttachSession"
+	TicketService_GetTicketsBySession_FullMethodName  = "/ticket_service.TicketService/GetTicketsBySession"
+	TicketService_ExportClientData_FullMethodName     = "/ticket_service.TicketService/ExportClientData"
+	TicketService_EraseClientData_FullMethodName      = "/ticket_service.TicketService/EraseClientData"
 )
 
 // TicketServiceClient is the client API for TicketService service.
@@ -31,8 +51,40 @@ const (
 type TicketServiceClient interface {
 	CreateTicket(ctx context.Context, in *CreateTicketRequest, opts ...grpc.CallOption) (*Ticket, error)
 	GetTicket(ctx context.Context, in *GetTicketRequest, opts ...grpc.CallOption) (*Ticket, error)
+	GetTicketByReference(ctx context.Context, in *GetTicketByReferenceRequest, opts ...grpc.CallOption) (*Ticket, error)
 	ListTickets(ctx context.Context, in *ListTicketsRequest, opts ...grpc.CallOption) (*ListTicketsResponse, error)
+	// WatchTickets — снимок тикетов по фильтрам ListTickets, затем события их изменения.
+	// REST: поток JSON-объектов, по одному на строку.
+	WatchTickets(ctx context.Context, in *WatchTicketsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TicketEvent], error)
+	// SearchTickets — полнотекстовый поиск по subject и notes средствами Postgres (без search-service).
+	SearchTickets(ctx context.Context, in *SearchTicketsRequest, opts ...grpc.CallOption) (*SearchTicketsResponse, error)
+	// FindSimilarTickets — недавние тикеты с похожими subject и notes (триграммы pg_trgm).
+	FindSimilarTickets(ctx context.Context, in *FindSimilarTicketsRequest, opts ...grpc.CallOption) (*FindSimilarTicketsResponse, error)
 	UpdateTicket(ctx context.Context, in *UpdateTicketRequest, opts ...grpc.CallOption) (*Ticket, error)
+	// BatchGetTickets — несколько тикетов за один вызов (REST: ?ids=1&ids=2).
+	BatchGetTickets(ctx context.Context, in *BatchGetTicketsRequest, opts ...grpc.CallOption) (*BatchGetTicketsResponse, error)
+	// BulkUpdateTickets — одни изменения для списка тикетов или тикетов по фильтру, с результатом по каждому.
+	BulkUpdateTickets(ctx context.Context, in *BulkUpdateTicketsRequest, opts ...grpc.CallOption) (*BulkUpdateTicketsResponse, error)
+	// DeleteTicket — мягкое удаление (только admin).
+	DeleteTicket(ctx context.Context, in *DeleteTicketRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RestoreTicket снимает удаление и архивацию (только admin).
+	RestoreTicket(ctx context.Context, in *RestoreTicketRequest, opts ...grpc.CallOption) (*Ticket, error)
+	// ArchiveTicket убирает закрытый тикет из выдачи ListTickets по умолчанию.
+	ArchiveTicket(ctx context.Context, in *ArchiveTicketRequest, opts ...grpc.CallOption) (*Ticket, error)
+	// PlaceLegalHold замораживает тикет: обновление, удаление, архивация, слияние, связи, привязка сессий
+	// и retention отклоняются — FAILED_PRECONDITION (только admin).
+	PlaceLegalHold(ctx context.Context, in *PlaceLegalHoldRequest, opts ...grpc.CallOption) (*Ticket, error)
+	ReleaseLegalHold(ctx context.Context, in *ReleaseLegalHoldRequest, opts ...grpc.CallOption) (*Ticket, error)
+	LinkTickets(ctx context.Context, in *LinkTicketsRequest, opts ...grpc.CallOption) (*TicketLink, error)
+	UnlinkTickets(ctx context.Context, in *UnlinkTicketsRequest, opts ...grpc.CallOption) (*UnlinkTicketsResponse, error)
+	ListLinkedTickets(ctx context.Context, in *ListLinkedTicketsRequest, opts ...grpc.CallOption) (*ListLinkedTicketsResponse, error)
+	MergeTickets(ctx context.Context, in *MergeTicketsRequest, opts ...grpc.CallOption) (*MergeTicketsResponse, error)
+	AttachSession(ctx context.Context, in *AttachSessionRequest, opts ...grpc.CallOption) (*Ticket, error)
+	GetTicketsBySession(ctx context.Context, in *GetTicketsBySessionRequest, opts ...grpc.CallOption) (*ListTicketsResponse, error)
+	// ExportClientData — выгрузка всех данных клиента (GDPR, право на доступ; только admin).
+	ExportClientData(ctx context.Context, in *ExportClientDataRequest, opts ...grpc.CallOption) (*ExportClientDataResponse, error)
+	// EraseClientData необратимо обезличивает subject и notes тикетов клиента (GDPR, право на удаление; только admin).
+	EraseClientData(ctx context.Context, in *EraseClientDataRequest, opts ...grpc.CallOption) (*EraseClientDataResponse, error)
 }
 
 type ticketServiceClient struct {
@@ -63,6 +115,16 @@ func (c *ticketServiceClient) GetTicket(ctx context.Context, in *GetTicketReques
 	return out, nil
 }
 
+func (c *ticketServiceClient) GetTicketByReference(ctx context.Context, in *GetTicketByReferenceRequest, opts ...grpc.CallOption) (*Ticket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ticket)
+	err := c.cc.Invoke(ctx, TicketService_GetTicketByReference_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ticketServiceClient) ListTickets(ctx context.Context, in *ListTicketsRequest, opts ...grpc.CallOption) (*ListTicketsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListTicketsResponse)
@@ -73,6 +135,45 @@ func (c *ticketServiceClient) ListTickets(ctx context.Context, in *ListTicketsRe
 	return out, nil
 }
 
+func (c *ticketServiceClient) WatchTickets(ctx context.Context, in *WatchTicketsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TicketEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TicketService_ServiceDesc.Streams[0], TicketService_WatchTickets_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchTicketsRequest, TicketEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TicketService_WatchTicketsClient = grpc.ServerStreamingClient[TicketEvent]
+
+func (c *ticketServiceClient) SearchTickets(ctx context.Context, in *SearchTicketsRequest, opts ...grpc.CallOption) (*SearchTicketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchTicketsResponse)
+	err := c.cc.Invoke(ctx, TicketService_SearchTickets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) FindSimilarTickets(ctx context.Context, in *FindSimilarTicketsRequest, opts ...grpc.CallOption) (*FindSimilarTicketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindSimilarTicketsResponse)
+	err := c.cc.Invoke(ctx, TicketService_FindSimilarTickets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ticketServiceClient) UpdateTicket(ctx context.Context, in *UpdateTicketRequest, opts ...grpc.CallOption) (*Ticket, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Ticket)
@@ -83,14 +184,196 @@ func (c *ticketServiceClient) UpdateTicket(ctx context.Context, in *UpdateTicket
 	return out, nil
 }
 
+func (c *ticketServiceClient) BatchGetTickets(ctx context.Context, in *BatchGetTicketsRequest, opts ...grpc.CallOption) (*BatchGetTicketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetTicketsResponse)
+	err := c.cc.Invoke(ctx, TicketService_BatchGetTickets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) BulkUpdateTickets(ctx context.Context, in *BulkUpdateTicketsRequest, opts ...grpc.CallOption) (*BulkUpdateTicketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkUpdateTicketsResponse)
+	err := c.cc.Invoke(ctx, TicketService_BulkUpdateTickets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) DeleteTicket(ctx context.Context, in *DeleteTicketRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TicketService_DeleteTicket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) RestoreTicket(ctx context.Context, in *RestoreTicketRequest, opts ...grpc.CallOption) (*Ticket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ticket)
+	err := c.cc.Invoke(ctx, TicketService_RestoreTicket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) ArchiveTicket(ctx context.Context, in *ArchiveTicketRequest, opts ...grpc.CallOption) (*Ticket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ticket)
+	err := c.cc.Invoke(ctx, TicketService_ArchiveTicket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) PlaceLegalHold(ctx context.Context, in *PlaceLegalHoldRequest, opts ...grpc.CallOption) (*Ticket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ticket)
+	err := c.cc.Invoke(ctx, TicketService_PlaceLegalHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) ReleaseLegalHold(ctx context.Context, in *ReleaseLegalHoldRequest, opts ...grpc.CallOption) (*Ticket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ticket)
+	err := c.cc.Invoke(ctx, TicketService_ReleaseLegalHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) LinkTickets(ctx context.Context, in *LinkTicketsRequest, opts ...grpc.CallOption) (*TicketLink, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TicketLink)
+	err := c.cc.Invoke(ctx, TicketService_LinkTickets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) UnlinkTickets(ctx context.Context, in *UnlinkTicketsRequest, opts ...grpc.CallOption) (*UnlinkTicketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlinkTicketsResponse)
+	err := c.cc.Invoke(ctx, TicketService_UnlinkTickets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) ListLinkedTickets(ctx context.Context, in *ListLinkedTicketsRequest, opts ...grpc.CallOption) (*ListLinkedTicketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLinkedTicketsResponse)
+	err := c.cc.Invoke(ctx, TicketService_ListLinkedTickets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) MergeTickets(ctx context.Context, in *MergeTicketsRequest, opts ...grpc.CallOption) (*MergeTicketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeTicketsResponse)
+	err := c.cc.Invoke(ctx, TicketService_MergeTickets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) AttachSession(ctx context.Context, in *AttachSessionRequest, opts ...grpc.CallOption) (*Ticket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ticket)
+	err := c.cc.Invoke(ctx, TicketService_AttachSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) GetTicketsBySession(ctx context.Context, in *GetTicketsBySessionRequest, opts ...grpc.CallOption) (*ListTicketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTicketsResponse)
+	err := c.cc.Invoke(ctx, TicketService_GetTicketsBySession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) ExportClientData(ctx context.Context, in *ExportClientDataRequest, opts ...grpc.CallOption) (*ExportClientDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportClientDataResponse)
+	err := c.cc.Invoke(ctx, TicketService_ExportClientData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) EraseClientData(ctx context.Context, in *EraseClientDataRequest, opts ...grpc.CallOption) (*EraseClientDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EraseClientDataResponse)
+	err := c.cc.Invoke(ctx, TicketService_EraseClientData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TicketServiceServer is the server API for TicketService service.
 // All implementations must embed UnimplementedTicketServiceServer
 // for forward compatibility.
 type TicketServiceServer interface {
 	CreateTicket(context.Context, *CreateTicketRequest) (*Ticket, error)
 	GetTicket(context.Context, *GetTicketRequest) (*Ticket, error)
+	GetTicketByReference(context.Context, *GetTicketByReferenceRequest) (*Ticket, error)
 	ListTickets(context.Context, *ListTicketsRequest) (*ListTicketsResponse, error)
+	// WatchTickets — снимок тикетов по фильтрам ListTickets, затем события их изменения.
+	// REST: поток JSON-объектов, по одному на строку.
+	WatchTickets(*WatchTicketsRequest, grpc.ServerStreamingServer[TicketEvent]) error
+	// SearchTickets — полнотекстовый поиск по subject и notes средствами Postgres (без search-service).
+	SearchTickets(context.Context, *SearchTicketsRequest) (*SearchTicketsResponse, error)
+	// FindSimilarTickets — недавние тикеты с похожими subject и notes (триграммы pg_trgm).
+	FindSimilarTickets(context.Context, *FindSimilarTicketsRequest) (*FindSimilarTicketsResponse, error)
 	UpdateTicket(context.Context, *UpdateTicketRequest) (*Ticket, error)
+	// BatchGetTickets — несколько тикетов за один вызов (REST: ?ids=1&ids=2).
+	BatchGetTickets(context.Context, *BatchGetTicketsRequest) (*BatchGetTicketsResponse, error)
+	// BulkUpdateTickets — одни изменения для списка тикетов или тикетов по фильтру, с результатом по каждому.
+	BulkUpdateTickets(context.Context, *BulkUpdateTicketsRequest) (*BulkUpdateTicketsResponse, error)
+	// DeleteTicket — мягкое удаление (только admin).
+	DeleteTicket(context.Context, *DeleteTicketRequest) (*emptypb.Empty, error)
+	// RestoreTicket снимает удаление и архивацию (только admin).
+	RestoreTicket(context.Context, *RestoreTicketRequest) (*Ticket, error)
+	// ArchiveTicket убирает закрытый тикет из выдачи ListTickets по умолчанию.
+	ArchiveTicket(context.Context, *ArchiveTicketRequest) (*Ticket, error)
+	// PlaceLegalHold замораживает тикет: обновление, удаление, архивация, слияние, связи, привязка сессий
+	// и retention отклоняются — FAILED_PRECONDITION (только admin).
+	PlaceLegalHold(context.Context, *PlaceLegalHoldRequest) (*Ticket, error)
+	ReleaseLegalHold(context.Context, *ReleaseLegalHoldRequest) (*Ticket, error)
+	LinkTickets(context.Context, *LinkTicketsRequest) (*TicketLink, error)
+	UnlinkTickets(context.Context, *UnlinkTicketsRequest) (*UnlinkTicketsResponse, error)
+	ListLinkedTickets(context.Context, *ListLinkedTicketsRequest) (*ListLinkedTicketsResponse, error)
+	MergeTickets(context.Context, *MergeTicketsRequest) (*MergeTicketsResponse, error)
+	AttachSession(context.Context, *AttachSessionRequest) (*Ticket, error)
+	GetTicketsBySession(context.Context, *GetTicketsBySessionRequest) (*ListTicketsResponse, error)
+	// ExportClientData — выгрузка всех данных клиента (GDPR, право на доступ; только admin).
+	ExportClientData(context.Context, *ExportClientDataRequest) (*ExportClientDataResponse, error)
+	// EraseClientData необратимо обезличивает subject и notes тикетов клиента (GDPR, право на удаление; только admin).
+	EraseClientData(context.Context, *EraseClientDataRequest) (*EraseClientDataResponse, error)
 	mustEmbedUnimplementedTicketServiceServer()
 }
 
@@ -107,12 +390,69 @@ func (UnimplementedTicketServiceServer) CreateTicket(context.Context, *CreateTic
 func (UnimplementedTicketServiceServer) GetTicket(context.Context, *GetTicketRequest) (*Ticket, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetTicket not implemented")
 }
+func (UnimplementedTicketServiceServer) GetTicketByReference(context.Context, *GetTicketByReferenceRequest) (*Ticket, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTicketByReference not implemented")
+}
 func (UnimplementedTicketServiceServer) ListTickets(context.Context, *ListTicketsRequest) (*ListTicketsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListTickets not implemented")
 }
+func (UnimplementedTicketServiceServer) WatchTickets(*WatchTicketsRequest, grpc.ServerStreamingServer[TicketEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchTickets not implemented")
+}
+func (UnimplementedTicketServiceServer) SearchTickets(context.Context, *SearchTicketsRequest) (*SearchTicketsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchTickets not implemented")
+}
+func (UnimplementedTicketServiceServer) FindSimilarTickets(context.Context, *FindSimilarTicketsRequest) (*FindSimilarTicketsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method FindSimilarTickets not implemented")
+}
 func (UnimplementedTicketServiceServer) UpdateTicket(context.Context, *UpdateTicketRequest) (*Ticket, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateTicket not implemented")
 }
+func (UnimplementedTicketServiceServer) BatchGetTickets(context.Context, *BatchGetTicketsRequest) (*BatchGetTicketsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchGetTickets not implemented")
+}
+func (UnimplementedTicketServiceServer) BulkUpdateTickets(context.Context, *BulkUpdateTicketsRequest) (*BulkUpdateTicketsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BulkUpdateTickets not implemented")
+}
+func (UnimplementedTicketServiceServer) DeleteTicket(context.Context, *DeleteTicketRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteTicket not implemented")
+}
+func (UnimplementedTicketServiceServer) RestoreTicket(context.Context, *RestoreTicketRequest) (*Ticket, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreTicket not implemented")
+}
+func (UnimplementedTicketServiceServer) ArchiveTicket(context.Context, *ArchiveTicketRequest) (*Ticket, error) {
+	return nil, status.Error(codes.Unimplemented, "method ArchiveTicket not implemented")
+}
+func (UnimplementedTicketServiceServer) PlaceLegalHold(context.Context, *PlaceLegalHoldRequest) (*Ticket, error) {
+	return nil, status.Error(codes.Unimplemented, "method PlaceLegalHold not implemented")
+}
+func (UnimplementedTicketServiceServer) ReleaseLegalHold(context.Context, *ReleaseLegalHoldRequest) (*Ticket, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReleaseLegalHold not implemented")
+}
+func (UnimplementedTicketServiceServer) LinkTickets(context.Context, *LinkTicketsRequest) (*TicketLink, error) {
+	return nil, status.Error(codes.Unimplemented, "method LinkTickets not implemented")
+}
+func (UnimplementedTicketServiceServer) UnlinkTickets(context.Context, *UnlinkTicketsRequest) (*UnlinkTicketsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnlinkTickets not implemented")
+}
+func (UnimplementedTicketServiceServer) ListLinkedTickets(context.Context, *ListLinkedTicketsRequest) (*ListLinkedTicketsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLinkedTickets not implemented")
+}
+func (UnimplementedTicketServiceServer) MergeTickets(context.Context, *MergeTicketsRequest) (*MergeTicketsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MergeTickets not implemented")
+}
+func (UnimplementedTicketServiceServer) AttachSession(context.Context, *AttachSessionRequest) (*Ticket, error) {
+	return nil, status.Error(codes.Unimplemented, "method AttachSession not implemented")
+}
+func (UnimplementedTicketServiceServer) GetTicketsBySession(context.Context, *GetTicketsBySessionRequest) (*ListTicketsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTicketsBySession not implemented")
+}
+func (UnimplementedTicketServiceServer) ExportClientData(context.Context, *ExportClientDataRequest) (*ExportClientDataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportClientData not implemented")
+}
+func (UnimplementedTicketServiceServer) EraseClientData(context.Context, *EraseClientDataRequest) (*EraseClientDataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EraseClientData not implemented")
+}
 func (UnimplementedTicketServiceServer) mustEmbedUnimplementedTicketServiceServer() {}
 func (UnimplementedTicketServiceServer) testEmbeddedByValue()                       {}
 
@@ -170,6 +510,24 @@ func _TicketService_GetTicket_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TicketService_GetTicketByReference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTicketByReferenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).GetTicketByReference(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_GetTicketByReference_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).GetTicketByReference(ctx, req.(*GetTicketByReferenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TicketService_ListTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListTicketsRequest)
 	if err := dec(in); err != nil {
@@ -188,6 +546,53 @@ func _TicketService_ListTickets_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TicketService_WatchTickets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTicketsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TicketServiceServer).WatchTickets(m, &grpc.GenericServerStream[WatchTicketsRequest, TicketEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TicketService_WatchTicketsServer = grpc.ServerStreamingServer[TicketEvent]
+
+func _TicketService_SearchTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTicketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).SearchTickets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_SearchTickets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).SearchTickets(ctx, req.(*SearchTicketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_FindSimilarTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindSimilarTicketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).FindSimilarTickets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_FindSimilarTickets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).FindSimilarTickets(ctx, req.(*FindSimilarTicketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TicketService_UpdateTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateTicketRequest)
 	if err := dec(in); err != nil {
@@ -206,6 +611,276 @@ func _TicketService_UpdateTicket_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TicketService_BatchGetTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetTicketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).BatchGetTickets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_BatchGetTickets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).BatchGetTickets(ctx, req.(*BatchGetTicketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_BulkUpdateTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkUpdateTicketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).BulkUpdateTickets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_BulkUpdateTickets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).BulkUpdateTickets(ctx, req.(*BulkUpdateTicketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_DeleteTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).DeleteTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_DeleteTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).DeleteTicket(ctx, req.(*DeleteTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_RestoreTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).RestoreTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_RestoreTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).RestoreTicket(ctx, req.(*RestoreTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_ArchiveTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).ArchiveTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_ArchiveTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).ArchiveTicket(ctx, req.(*ArchiveTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_PlaceLegalHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceLegalHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).PlaceLegalHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_PlaceLegalHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).PlaceLegalHold(ctx, req.(*PlaceLegalHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_ReleaseLegalHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseLegalHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).ReleaseLegalHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_ReleaseLegalHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).ReleaseLegalHold(ctx, req.(*ReleaseLegalHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_LinkTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkTicketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).LinkTickets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_LinkTickets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).LinkTickets(ctx, req.(*LinkTicketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_UnlinkTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlinkTicketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).UnlinkTickets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_UnlinkTickets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).UnlinkTickets(ctx, req.(*UnlinkTicketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_ListLinkedTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLinkedTicketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).ListLinkedTickets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_ListLinkedTickets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).ListLinkedTickets(ctx, req.(*ListLinkedTicketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_MergeTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTicketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).MergeTickets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_MergeTickets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).MergeTickets(ctx, req.(*MergeTicketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_AttachSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).AttachSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_AttachSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).AttachSession(ctx, req.(*AttachSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_GetTicketsBySession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTicketsBySessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).GetTicketsBySession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_GetTicketsBySession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).GetTicketsBySession(ctx, req.(*GetTicketsBySessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_ExportClientData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportClientDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).ExportClientData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_ExportClientData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).ExportClientData(ctx, req.(*ExportClientDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_EraseClientData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseClientDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).EraseClientData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_EraseClientData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).EraseClientData(ctx, req.(*EraseClientDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TicketService_ServiceDesc is the grpc.ServiceDesc for TicketService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -221,15 +896,93 @@ var TicketService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTicket",
 			Handler:    _TicketService_GetTicket_Handler,
 		},
+		{
+			MethodName: "GetTicketByReference",
+			Handler:    _TicketService_GetTicketByReference_Handler,
+		},
 		{
 			MethodName: "ListTickets",
 			Handler:    _TicketService_ListTickets_Handler,
 		},
+		{
+			MethodName: "SearchTickets",
+			Handler:    _TicketService_SearchTickets_Handler,
+		},
+		{
+			MethodName: "FindSimilarTickets",
+			Handler:    _TicketService_FindSimilarTickets_Handler,
+		},
 		{
 			MethodName: "UpdateTicket",
 			Handler:    _TicketService_UpdateTicket_Handler,
 		},
+		{
+			MethodName: "BatchGetTickets",
+			Handler:    _TicketService_BatchGetTickets_Handler,
+		},
+		{
+			MethodName: "BulkUpdateTickets",
+			Handler:    _TicketService_BulkUpdateTickets_Handler,
+		},
+		{
+			MethodName: "DeleteTicket",
+			Handler:    _TicketService_DeleteTicket_Handler,
+		},
+		{
+			MethodName: "RestoreTicket",
+			Handler:    _TicketService_RestoreTicket_Handler,
+		},
+		{
+			MethodName: "ArchiveTicket",
+			Handler:    _TicketService_ArchiveTicket_Handler,
+		},
+		{
+			MethodName: "PlaceLegalHold",
+			Handler:    _TicketService_PlaceLegalHold_Handler,
+		},
+		{
+			MethodName: "ReleaseLegalHold",
+			Handler:    _TicketService_ReleaseLegalHold_Handler,
+		},
+		{
+			MethodName: "LinkTickets",
+			Handler:    _TicketService_LinkTickets_Handler,
+		},
+		{
+			MethodName: "UnlinkTickets",
+			Handler:    _TicketService_UnlinkTickets_Handler,
+		},
+		{
+			MethodName: "ListLinkedTickets",
+			Handler:    _TicketService_ListLinkedTickets_Handler,
+		},
+		{
+			MethodName: "MergeTickets",
+			Handler:    _TicketService_MergeTickets_Handler,
+		},
+		{
+			MethodName: "AttachSession",
+			Handler:    _TicketService_AttachSession_Handler,
+		},
+		{
+			MethodName: "GetTicketsBySession",
+			Handler:    _TicketService_GetTicketsBySession_Handler,
+		},
+		{
+			MethodName: "ExportClientData",
+			Handler:    _TicketService_ExportClientData_Handler,
+		},
+		{
+			MethodName: "EraseClientData",
+			Handler:    _TicketService_EraseClientData_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTickets",
+			Handler:       _TicketService_WatchTickets_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "ticket.proto",
 }